@@ -0,0 +1,120 @@
+package command
+
+//go:generate go run github.com/xtls/xray-core/common/errors/errorgen
+
+import (
+	"context"
+
+	"github.com/xtls/xray-core/app/dispatcher"
+	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/core"
+	"github.com/xtls/xray-core/features/routing"
+	grpc "google.golang.org/grpc"
+)
+
+// connectionManager is implemented by dispatchers that track the connections they dispatch.
+type connectionManager interface {
+	ListConnections() []dispatcher.Connection
+	CloseConnection(id uint64) bool
+	CloseUserConnections(email string) int
+}
+
+// connectionServer is an implementation of ConnectionService.
+type connectionServer struct {
+	dispatcher routing.Dispatcher
+}
+
+func NewConnectionServer(d routing.Dispatcher) ConnectionServiceServer {
+	return &connectionServer{
+		dispatcher: d,
+	}
+}
+
+func (s *connectionServer) getConnectionManager() (connectionManager, error) {
+	m, ok := s.dispatcher.(connectionManager)
+	if !ok {
+		return nil, newError("dispatcher does not track connections")
+	}
+	return m, nil
+}
+
+func toConnection(c dispatcher.Connection) *Connection {
+	conn := &Connection{
+		Id:          c.ID,
+		SessionId:   c.SessionID,
+		InboundTag:  c.InboundTag,
+		Email:       c.Email,
+		Domain:      c.Domain,
+		Protocol:    c.Protocol,
+		OutboundTag: c.OutboundTag,
+		StartTime:   c.Start.Unix(),
+		Uplink:      c.Uplink,
+		Downlink:    c.Downlink,
+	}
+	if c.Source.IsValid() {
+		conn.Source = c.Source.NetAddr()
+	}
+	if c.Destination.IsValid() {
+		conn.Destination = c.Destination.String()
+	}
+	return conn
+}
+
+func (s *connectionServer) ListConnections(ctx context.Context, request *ListConnectionsRequest) (*ListConnectionsResponse, error) {
+	m, err := s.getConnectionManager()
+	if err != nil {
+		return nil, err
+	}
+	response := &ListConnectionsResponse{}
+	for _, c := range m.ListConnections() {
+		if len(request.InboundTag) > 0 && c.InboundTag != request.InboundTag {
+			continue
+		}
+		if len(request.Email) > 0 && c.Email != request.Email {
+			continue
+		}
+		response.Connections = append(response.Connections, toConnection(c))
+	}
+	return response, nil
+}
+
+func (s *connectionServer) CloseConnections(ctx context.Context, request *CloseConnectionsRequest) (*CloseConnectionsResponse, error) {
+	m, err := s.getConnectionManager()
+	if err != nil {
+		return nil, err
+	}
+	var count uint32
+	for _, id := range request.Ids {
+		if m.CloseConnection(id) {
+			count++
+		}
+	}
+	if len(request.Email) > 0 {
+		count += uint32(m.CloseUserConnections(request.Email))
+	}
+	return &CloseConnectionsResponse{
+		Count: count,
+	}, nil
+}
+
+func (s *connectionServer) mustEmbedUnimplementedConnectionServiceServer() {}
+
+type service struct {
+	dispatcher routing.Dispatcher
+}
+
+func (s *service) Register(server *grpc.Server) {
+	RegisterConnectionServiceServer(server, NewConnectionServer(s.dispatcher))
+}
+
+func init() {
+	common.Must(common.RegisterConfig((*Config)(nil), func(ctx context.Context, cfg interface{}) (interface{}, error) {
+		s := new(service)
+
+		core.RequireFeatures(ctx, func(d routing.Dispatcher) {
+			s.dispatcher = d
+		})
+
+		return s, nil
+	}))
+}
@@ -0,0 +1,280 @@
+package dispatcher
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/common/buf"
+	"github.com/xtls/xray-core/common/log"
+	"github.com/xtls/xray-core/common/net"
+	"github.com/xtls/xray-core/common/session"
+	"github.com/xtls/xray-core/transport"
+)
+
+// Connection is a snapshot of a connection dispatched by DefaultDispatcher.
+type Connection struct {
+	ID          uint64
+	SessionID   uint32
+	InboundTag  string
+	Email       string
+	Source      net.Destination
+	Destination net.Destination
+	Domain      string
+	Protocol    string
+	OutboundTag string
+	Start       time.Time
+	Uplink      int64
+	Downlink    int64
+}
+
+type trackedConnection struct {
+	access   sync.Mutex
+	info     Connection
+	uplink   int64 // atomic
+	downlink int64 // atomic
+	refs     int32 // atomic
+
+	registry *connectionRegistry
+	writers  []*ConnectionWriter
+	// reader is the uplink reader of a connection dispatched on an existing link, nil otherwise.
+	reader buf.Reader
+	// accessMessage is recorded with the traffic when the connection is closed.
+	accessMessage *log.AccessMessage
+}
+
+func (c *trackedConnection) setAccessMessage(msg *log.AccessMessage) {
+	c.access.Lock()
+	c.accessMessage = msg
+	c.access.Unlock()
+}
+
+func (c *trackedConnection) setSniffed(domain string, protocol string) {
+	c.access.Lock()
+	c.info.Domain = domain
+	c.info.Protocol = protocol
+	c.access.Unlock()
+}
+
+func (c *trackedConnection) setRoute(destination net.Destination, outboundTag string) {
+	c.access.Lock()
+	c.info.Destination = destination
+	c.info.OutboundTag = outboundTag
+	c.access.Unlock()
+}
+
+func (c *trackedConnection) snapshot() Connection {
+	c.access.Lock()
+	info := c.info
+	c.access.Unlock()
+	info.Uplink = atomic.LoadInt64(&c.uplink)
+	info.Downlink = atomic.LoadInt64(&c.downlink)
+	return info
+}
+
+func (c *trackedConnection) release() {
+	if atomic.AddInt32(&c.refs, -1) != 0 {
+		return
+	}
+	c.registry.remove(c.info.ID)
+
+	c.access.Lock()
+	msg := c.accessMessage
+	c.access.Unlock()
+	if msg != nil {
+		msg.Status = log.AccessClosed
+		msg.Uplink = atomic.LoadInt64(&c.uplink)
+		msg.Downlink = atomic.LoadInt64(&c.downlink)
+		msg.Duration = time.Since(c.info.Start)
+		log.Record(msg)
+	}
+}
+
+// interrupt terminates both directions of the connection.
+func (c *trackedConnection) interrupt() {
+	for _, w := range c.writers {
+		w.Interrupt()
+	}
+	if c.reader != nil {
+		common.Interrupt(c.reader)
+	}
+}
+
+// ConnectionWriter is a buf.Writer that counts the traffic of a tracked connection, and removes the connection from
+// the registry once both the uplink and downlink writers are closed.
+type ConnectionWriter struct {
+	Writer buf.Writer
+
+	conn    *trackedConnection
+	counter *int64
+	once    sync.Once
+}
+
+func (w *ConnectionWriter) WriteMultiBuffer(mb buf.MultiBuffer) error {
+	atomic.AddInt64(w.counter, int64(mb.Len()))
+	return w.Writer.WriteMultiBuffer(mb)
+}
+
+func (w *ConnectionWriter) Close() error {
+	w.once.Do(w.conn.release)
+	return common.Close(w.Writer)
+}
+
+func (w *ConnectionWriter) Interrupt() {
+	w.once.Do(w.conn.release)
+	common.Interrupt(w.Writer)
+}
+
+// ConnectionReader is a buf.Reader that counts the uplink traffic of a tracked connection, which is dispatched on an
+// existing link.
+type ConnectionReader struct {
+	Reader timeoutReader
+
+	counter *int64
+}
+
+func (r *ConnectionReader) ReadMultiBuffer() (buf.MultiBuffer, error) {
+	mb, err := r.Reader.ReadMultiBuffer()
+	atomic.AddInt64(r.counter, int64(mb.Len()))
+	return mb, err
+}
+
+func (r *ConnectionReader) ReadMultiBufferTimeout(timeout time.Duration) (buf.MultiBuffer, error) {
+	mb, err := r.Reader.ReadMultiBufferTimeout(timeout)
+	atomic.AddInt64(r.counter, int64(mb.Len()))
+	return mb, err
+}
+
+func (r *ConnectionReader) Interrupt() {
+	r.Reader.Interrupt()
+}
+
+type connectionKey int
+
+const trackedConnectionKey connectionKey = 0
+
+func connectionFromContext(ctx context.Context) *trackedConnection {
+	if c, ok := ctx.Value(trackedConnectionKey).(*trackedConnection); ok {
+		return c
+	}
+	return nil
+}
+
+// connectionRegistry keeps the connections that are alive.
+type connectionRegistry struct {
+	access sync.RWMutex
+	nextID uint64
+	conns  map[uint64]*trackedConnection
+}
+
+// track registers a connection on the links, and returns a context that carries it.
+func (r *connectionRegistry) track(ctx context.Context, destination net.Destination, inbound *transport.Link, outbound *transport.Link) context.Context {
+	c := newTrackedConnection(ctx, destination, 2)
+	uplink := &ConnectionWriter{Writer: inbound.Writer, conn: c, counter: &c.uplink}
+	downlink := &ConnectionWriter{Writer: outbound.Writer, conn: c, counter: &c.downlink}
+	c.writers = []*ConnectionWriter{uplink, downlink}
+	inbound.Writer = uplink
+	outbound.Writer = downlink
+	return r.add(ctx, c)
+}
+
+// trackLink registers a connection on an existing link, which is owned by the caller of DispatchLink, and returns a
+// context that carries it. The connection is removed once the downlink writer is closed.
+func (r *connectionRegistry) trackLink(ctx context.Context, destination net.Destination, link *transport.Link) context.Context {
+	c := newTrackedConnection(ctx, destination, 1)
+	downlink := &ConnectionWriter{Writer: link.Writer, conn: c, counter: &c.downlink}
+	c.writers = []*ConnectionWriter{downlink}
+	link.Writer = downlink
+	if reader, ok := link.Reader.(timeoutReader); ok {
+		link.Reader = &ConnectionReader{Reader: reader, counter: &c.uplink}
+	}
+	c.reader = link.Reader
+	return r.add(ctx, c)
+}
+
+func newTrackedConnection(ctx context.Context, destination net.Destination, refs int32) *trackedConnection {
+	c := &trackedConnection{
+		info: Connection{
+			SessionID:   uint32(session.IDFromContext(ctx)),
+			Destination: destination,
+			Start:       time.Now(),
+		},
+		refs: refs,
+	}
+	if inbound := session.InboundFromContext(ctx); inbound != nil {
+		c.info.InboundTag = inbound.Tag
+		c.info.Source = inbound.Source
+		if inbound.User != nil {
+			c.info.Email = inbound.User.Email
+		}
+	}
+	return c
+}
+
+func (r *connectionRegistry) add(ctx context.Context, c *trackedConnection) context.Context {
+	c.registry = r
+
+	r.access.Lock()
+	if r.conns == nil {
+		r.conns = make(map[uint64]*trackedConnection)
+	}
+	r.nextID++
+	c.info.ID = r.nextID
+	r.conns[c.info.ID] = c
+	r.access.Unlock()
+
+	return context.WithValue(ctx, trackedConnectionKey, c)
+}
+
+func (r *connectionRegistry) remove(id uint64) {
+	r.access.Lock()
+	delete(r.conns, id)
+	r.access.Unlock()
+}
+
+func (r *connectionRegistry) find(match func(c *trackedConnection) bool) []*trackedConnection {
+	r.access.RLock()
+	defer r.access.RUnlock()
+
+	var result []*trackedConnection
+	for _, c := range r.conns {
+		if match(c) {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+// ListConnections returns the connections that are alive, ordered by ID.
+func (d *DefaultDispatcher) ListConnections() []Connection {
+	conns := d.connections.find(func(*trackedConnection) bool { return true })
+	result := make([]Connection, 0, len(conns))
+	for _, c := range conns {
+		result = append(result, c.snapshot())
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID < result[j].ID
+	})
+	return result
+}
+
+// CloseConnection terminates the connection with the ID. It returns false if the connection doesn't exist.
+func (d *DefaultDispatcher) CloseConnection(id uint64) bool {
+	conns := d.connections.find(func(c *trackedConnection) bool { return c.info.ID == id })
+	for _, c := range conns {
+		c.interrupt()
+	}
+	return len(conns) > 0
+}
+
+// CloseUserConnections terminates all connections of the user with the email, and returns the number of them.
+func (d *DefaultDispatcher) CloseUserConnections(email string) int {
+	conns := d.connections.find(func(c *trackedConnection) bool { return c.info.Email == email })
+	for _, c := range conns {
+		c.interrupt()
+	}
+	return len(conns)
+}
@@ -0,0 +1,178 @@
+package dispatcher
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/common/buf"
+	"github.com/xtls/xray-core/common/log"
+	"github.com/xtls/xray-core/common/net"
+	"github.com/xtls/xray-core/common/protocol"
+	"github.com/xtls/xray-core/common/session"
+	"github.com/xtls/xray-core/features/outbound"
+	"github.com/xtls/xray-core/transport"
+	"github.com/xtls/xray-core/transport/pipe"
+)
+
+func newTestConnection(d *DefaultDispatcher, email string) (context.Context, *transport.Link, *transport.Link) {
+	ctx := session.ContextWithInbound(context.Background(), &session.Inbound{
+		Tag:    "in",
+		Source: net.TCPDestination(net.LocalHostIP, 1234),
+		User:   &protocol.MemoryUser{Email: email},
+	})
+	uplinkReader, uplinkWriter := pipe.New()
+	downlinkReader, downlinkWriter := pipe.New()
+	inbound := &transport.Link{Reader: downlinkReader, Writer: uplinkWriter}
+	outbound := &transport.Link{Reader: uplinkReader, Writer: downlinkWriter}
+	ctx = d.connections.track(ctx, net.TCPDestination(net.DomainAddress("example.com"), 443), inbound, outbound)
+	return ctx, inbound, outbound
+}
+
+func TestConnectionTracking(t *testing.T) {
+	d := new(DefaultDispatcher)
+
+	ctx, inbound, outbound := newTestConnection(d, "a")
+	newTestConnection(d, "b")
+	newTestConnection(d, "b")
+
+	b := buf.New()
+	b.WriteString("abcd")
+	common.Must(inbound.Writer.WriteMultiBuffer(buf.MultiBuffer{b}))
+	connectionFromContext(ctx).setRoute(net.TCPDestination(net.DomainAddress("example.com"), 443), "direct")
+
+	conns := d.ListConnections()
+	if len(conns) != 3 {
+		t.Fatal("expect 3 connections, but got ", len(conns))
+	}
+	if c := conns[0]; c.ID != 1 || c.Email != "a" || c.InboundTag != "in" || c.OutboundTag != "direct" || c.Uplink != 4 || c.Downlink != 0 {
+		t.Error("unexpected connection ", c)
+	}
+
+	if n := d.CloseUserConnections("b"); n != 2 {
+		t.Error("expect 2 connections closed, but got ", n)
+	}
+	if len(d.ListConnections()) != 1 {
+		t.Error("expect 1 connection left")
+	}
+	if d.CloseConnection(100) {
+		t.Error("expect no connection closed")
+	}
+
+	common.Close(inbound.Writer)
+	if len(d.ListConnections()) != 1 {
+		t.Error("expect connection alive until both directions are closed")
+	}
+	common.Close(outbound.Writer)
+	if len(d.ListConnections()) != 0 {
+		t.Error("expect no connection left")
+	}
+}
+
+type testLogHandler struct {
+	sync.Mutex
+	messages []log.Message
+}
+
+func (h *testLogHandler) Handle(msg log.Message) {
+	if _, ok := msg.(*log.AccessMessage); !ok {
+		return
+	}
+	h.Lock()
+	h.messages = append(h.messages, msg)
+	h.Unlock()
+}
+
+func TestConnectionAccessLog(t *testing.T) {
+	handler := new(testLogHandler)
+	log.RegisterHandler(handler)
+
+	d := new(DefaultDispatcher)
+	ctx, inbound, outbound := newTestConnection(d, "a")
+	connectionFromContext(ctx).setAccessMessage(&log.AccessMessage{
+		Status:      log.AccessAccepted,
+		OutboundTag: "direct",
+	})
+
+	b := buf.New()
+	b.WriteString("abcd")
+	common.Must(outbound.Writer.WriteMultiBuffer(buf.MultiBuffer{b}))
+	common.Close(inbound.Writer)
+	common.Close(outbound.Writer)
+
+	if len(handler.messages) != 1 {
+		t.Fatal("expect 1 access log, but got ", len(handler.messages))
+	}
+	if msg := handler.messages[0].(*log.AccessMessage); msg.Status != log.AccessClosed || msg.OutboundTag != "direct" || msg.Uplink != 0 || msg.Downlink != 4 {
+		t.Error("unexpected access log ", msg)
+	}
+}
+
+type echoHandler struct{}
+
+func (echoHandler) Tag() string  { return "echo" }
+func (echoHandler) Start() error { return nil }
+func (echoHandler) Close() error { return nil }
+func (echoHandler) Dispatch(ctx context.Context, link *transport.Link) {
+	if err := buf.Copy(link.Reader, link.Writer); err != nil {
+		common.Interrupt(link.Writer)
+		return
+	}
+	common.Close(link.Writer)
+}
+
+type testOutboundManager struct {
+	handler outbound.Handler
+}
+
+func (m *testOutboundManager) Type() interface{}                                  { return outbound.ManagerType() }
+func (m *testOutboundManager) Start() error                                       { return nil }
+func (m *testOutboundManager) Close() error                                       { return nil }
+func (m *testOutboundManager) GetHandler(tag string) outbound.Handler             { return nil }
+func (m *testOutboundManager) GetDefaultHandler() outbound.Handler                { return m.handler }
+func (m *testOutboundManager) AddHandler(context.Context, outbound.Handler) error { return nil }
+func (m *testOutboundManager) RemoveHandler(context.Context, string) error        { return nil }
+
+func TestDispatchLinkTracking(t *testing.T) {
+	d := &DefaultDispatcher{ohm: &testOutboundManager{handler: echoHandler{}}}
+
+	ctx := session.ContextWithInbound(context.Background(), &session.Inbound{
+		Tag:  "mux",
+		User: &protocol.MemoryUser{Email: "a"},
+	})
+	uplinkReader, uplinkWriter := pipe.New()
+	downlinkReader, downlinkWriter := pipe.New()
+	common.Must(d.DispatchLink(ctx, net.TCPDestination(net.DomainAddress("example.com"), 443), &transport.Link{
+		Reader: uplinkReader,
+		Writer: downlinkWriter,
+	}))
+
+	b := buf.New()
+	b.WriteString("abcd")
+	common.Must(uplinkWriter.WriteMultiBuffer(buf.MultiBuffer{b}))
+	mb, err := downlinkReader.ReadMultiBuffer()
+	common.Must(err)
+	if mb.String() != "abcd" {
+		t.Error("unexpected response ", mb.String())
+	}
+	buf.ReleaseMulti(mb)
+
+	conns := d.ListConnections()
+	if len(conns) != 1 {
+		t.Fatal("expect 1 connection, but got ", len(conns))
+	}
+	if c := conns[0]; c.Email != "a" || c.InboundTag != "mux" || c.OutboundTag != "echo" || c.Uplink != 4 || c.Downlink != 4 {
+		t.Error("unexpected connection ", c)
+	}
+
+	if !d.CloseConnection(conns[0].ID) {
+		t.Fatal("expect connection closed")
+	}
+	if _, err := downlinkReader.ReadMultiBuffer(); err == nil {
+		t.Error("expect error after connection closed")
+	}
+	if len(d.ListConnections()) != 0 {
+		t.Error("expect no connection left")
+	}
+}
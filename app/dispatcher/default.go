@@ -28,9 +28,16 @@ import (
 
 var errSniffingTimeout = newError("timeout on sniffing")
 
+// timeoutReader is the reader of a link, which is usually a *pipe.Reader.
+type timeoutReader interface {
+	buf.Reader
+	buf.TimeoutReader
+	common.Interruptible
+}
+
 type cachedReader struct {
 	sync.Mutex
-	reader *pipe.Reader
+	reader timeoutReader
 	cache  buf.MultiBuffer
 }
 
@@ -95,6 +102,9 @@ type DefaultDispatcher struct {
 	stats  stats.Manager
 	dns    dns.Client
 	fdns   dns.FakeDNSEngine
+
+	limiters    rateLimiters
+	connections connectionRegistry
 }
 
 func init() {
@@ -135,7 +145,18 @@ func (*DefaultDispatcher) Start() error {
 // Close implements common.Closable.
 func (*DefaultDispatcher) Close() error { return nil }
 
-func (d *DefaultDispatcher) getLink(ctx context.Context, network net.Network, sniffing session.SniffingRequest) (*transport.Link, *transport.Link) {
+func (d *DefaultDispatcher) getLink(ctx context.Context, network net.Network, sniffing session.SniffingRequest) (*transport.Link, *transport.Link, error) {
+	sessionInbound := session.InboundFromContext(ctx)
+	var user *protocol.MemoryUser
+	if sessionInbound != nil {
+		user = sessionInbound.User
+	}
+
+	userSession, err := policy.OpenUserSession(ctx, d.policy, user)
+	if err != nil {
+		return nil, nil, newError("rejected by user limits").Base(err)
+	}
+
 	downOpt := pipe.OptionsFromContext(ctx)
 	upOpt := downOpt
 
@@ -217,14 +238,26 @@ func (d *DefaultDispatcher) getLink(ctx context.Context, network net.Network, sn
 		Writer: downlinkWriter,
 	}
 
-	sessionInbound := session.InboundFromContext(ctx)
-	var user *protocol.MemoryUser
-	if sessionInbound != nil {
-		user = sessionInbound.User
+	var uplinkBuckets, downlinkBuckets []*TokenBucket
+	if sessionInbound != nil && len(sessionInbound.Tag) > 0 {
+		if bw, found := d.policy.ForSystem().InboundBandwidth[sessionInbound.Tag]; found {
+			if b := d.limiters.get("inbound>>>"+sessionInbound.Tag+">>>uplink", bw.UplinkRate, bw.UplinkBurst); b != nil {
+				uplinkBuckets = append(uplinkBuckets, b)
+			}
+			if b := d.limiters.get("inbound>>>"+sessionInbound.Tag+">>>downlink", bw.DownlinkRate, bw.DownlinkBurst); b != nil {
+				downlinkBuckets = append(downlinkBuckets, b)
+			}
+		}
 	}
 
 	if user != nil && len(user.Email) > 0 {
 		p := d.policy.ForLevel(user.Level)
+		if b := d.limiters.get("user>>>"+user.Email+">>>uplink", p.Bandwidth.UplinkRate, p.Bandwidth.UplinkBurst); b != nil {
+			uplinkBuckets = append(uplinkBuckets, b)
+		}
+		if b := d.limiters.get("user>>>"+user.Email+">>>downlink", p.Bandwidth.DownlinkRate, p.Bandwidth.DownlinkBurst); b != nil {
+			downlinkBuckets = append(downlinkBuckets, b)
+		}
 		if p.Stats.UserUplink {
 			name := "user>>>" + user.Email + ">>>traffic>>>uplink"
 			if c, _ := stats.GetOrRegisterCounter(d.stats, name); c != nil {
@@ -245,7 +278,24 @@ func (d *DefaultDispatcher) getLink(ctx context.Context, network net.Network, sn
 		}
 	}
 
-	return inboundLink, outboundLink
+	if userSession != nil {
+		inboundLink.Writer, outboundLink.Writer = newUserSessionWriters(userSession, inboundLink.Writer, outboundLink.Writer)
+	}
+
+	if len(uplinkBuckets) > 0 {
+		inboundLink.Writer = &RateLimitedWriter{
+			Buckets: uplinkBuckets,
+			Writer:  inboundLink.Writer,
+		}
+	}
+	if len(downlinkBuckets) > 0 {
+		outboundLink.Writer = &RateLimitedWriter{
+			Buckets: downlinkBuckets,
+			Writer:  outboundLink.Writer,
+		}
+	}
+
+	return inboundLink, outboundLink, nil
 }
 
 func (d *DefaultDispatcher) shouldOverride(ctx context.Context, result SniffResult, request session.SniffingRequest, destination net.Destination) bool {
@@ -297,23 +347,33 @@ func (d *DefaultDispatcher) Dispatch(ctx context.Context, destination net.Destin
 	}
 
 	sniffingRequest := content.SniffingRequest
-	inbound, outbound := d.getLink(ctx, destination.Network, sniffingRequest)
+	inbound, outbound, err := d.getLink(ctx, destination.Network, sniffingRequest)
+	if err != nil {
+		return nil, err
+	}
+	ctx = d.connections.track(ctx, destination, inbound, outbound)
 	if !sniffingRequest.Enabled {
 		go d.routedDispatch(ctx, outbound, destination)
 	} else {
 		go func() {
 			cReader := &cachedReader{
-				reader: outbound.Reader.(*pipe.Reader),
+				reader: outbound.Reader.(timeoutReader),
 			}
 			outbound.Reader = cReader
 			result, err := sniffer(ctx, cReader, sniffingRequest.MetadataOnly, destination.Network)
 			if err == nil {
-				content.Protocol = result.Protocol()
+				setSniffedContent(content, result)
+				if c := connectionFromContext(ctx); c != nil {
+					c.setSniffed(result.Domain(), result.Protocol())
+				}
 			}
 			if err == nil && d.shouldOverride(ctx, result, sniffingRequest, destination) {
 				domain := result.Domain()
 				newError("sniffed domain: ", domain).WriteToLog(session.ExportIDToError(ctx))
 				destination.Address = net.ParseAddress(domain)
+				if accessMessage := log.AccessMessageFromContext(ctx); accessMessage != nil {
+					accessMessage.SniffedTo = destination
+				}
 				if sniffingRequest.RouteOnly && result.Protocol() != "fakedns" {
 					ob.RouteTarget = destination
 				} else {
@@ -340,23 +400,30 @@ func (d *DefaultDispatcher) DispatchLink(ctx context.Context, destination net.De
 		content = new(session.Content)
 		ctx = session.ContextWithContent(ctx, content)
 	}
+	ctx = d.connections.trackLink(ctx, destination, outbound)
 	sniffingRequest := content.SniffingRequest
 	if !sniffingRequest.Enabled {
 		go d.routedDispatch(ctx, outbound, destination)
 	} else {
 		go func() {
 			cReader := &cachedReader{
-				reader: outbound.Reader.(*pipe.Reader),
+				reader: outbound.Reader.(timeoutReader),
 			}
 			outbound.Reader = cReader
 			result, err := sniffer(ctx, cReader, sniffingRequest.MetadataOnly, destination.Network)
 			if err == nil {
-				content.Protocol = result.Protocol()
+				setSniffedContent(content, result)
+				if c := connectionFromContext(ctx); c != nil {
+					c.setSniffed(result.Domain(), result.Protocol())
+				}
 			}
 			if err == nil && d.shouldOverride(ctx, result, sniffingRequest, destination) {
 				domain := result.Domain()
 				newError("sniffed domain: ", domain).WriteToLog(session.ExportIDToError(ctx))
 				destination.Address = net.ParseAddress(domain)
+				if accessMessage := log.AccessMessageFromContext(ctx); accessMessage != nil {
+					accessMessage.SniffedTo = destination
+				}
 				if sniffingRequest.RouteOnly && result.Protocol() != "fakedns" {
 					ob.RouteTarget = destination
 				} else {
@@ -411,6 +478,10 @@ func sniffer(ctx context.Context, cReader *cachedReader, metadataOnly bool, netw
 		return metaresult, nil
 	}
 	if contentErr == nil && metadataErr == nil {
+		// metadata results without domain, like the ones of mail protocols, are only used when the content is unknown
+		if metaresult.Domain() == "" {
+			return contentResult, nil
+		}
 		return CompositeResult(metaresult, contentResult), nil
 	}
 	return contentResult, contentErr
@@ -436,6 +507,7 @@ func (d *DefaultDispatcher) routedDispatch(ctx context.Context, link *transport.
 	routingLink := routing_session.AsRoutingContext(ctx)
 	inTag := routingLink.GetInboundTag()
 	isPickRoute := 0
+	var ruleTag string
 	if forcedOutboundTag := session.GetForcedOutboundTagFromContext(ctx); forcedOutboundTag != "" {
 		ctx = session.SetForcedOutboundTagToContext(ctx, "")
 		if h := d.ohm.GetHandler(forcedOutboundTag); h != nil {
@@ -451,6 +523,9 @@ func (d *DefaultDispatcher) routedDispatch(ctx context.Context, link *transport.
 	} else if d.router != nil {
 		if route, err := d.router.PickRoute(routingLink); err == nil {
 			outTag := route.GetOutboundTag()
+			if r, ok := route.(interface{ GetRuleTag() string }); ok {
+				ruleTag = r.GetRuleTag()
+			}
 			if h := d.ohm.GetHandler(outTag); h != nil {
 				isPickRoute = 2
 				newError("taking detour [", outTag, "] for [", destination, "]").WriteToLog(session.ExportIDToError(ctx))
@@ -486,8 +561,20 @@ func (d *DefaultDispatcher) routedDispatch(ctx context.Context, link *transport.
 				accessMessage.Detour = inTag + " >> " + tag
 			}
 		}
+		accessMessage.InboundTag = inTag
+		accessMessage.RuleTag = ruleTag
+		accessMessage.OutboundTag = handler.Tag()
+		if c := connectionFromContext(ctx); c != nil {
+			closing := *accessMessage
+			c.setAccessMessage(&closing)
+			accessMessage.Deferred = true
+		}
 		log.Record(accessMessage)
 	}
 
+	if c := connectionFromContext(ctx); c != nil {
+		c.setRoute(ob.Target, handler.Tag())
+	}
+
 	handler.Dispatch(ctx, link)
 }
@@ -0,0 +1,52 @@
+package dispatcher
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/common/buf"
+	"github.com/xtls/xray-core/features/policy"
+)
+
+// UserSessionWriter is a buf.Writer that counts the traffic to the user session, and closes the session once both
+// the uplink and downlink writers are closed.
+type UserSessionWriter struct {
+	Session policy.UserSession
+	Writer  buf.Writer
+
+	refs *int32
+	once sync.Once
+}
+
+func newUserSessionWriters(s policy.UserSession, uplink, downlink buf.Writer) (*UserSessionWriter, *UserSessionWriter) {
+	refs := int32(2)
+	return &UserSessionWriter{Session: s, Writer: uplink, refs: &refs},
+		&UserSessionWriter{Session: s, Writer: downlink, refs: &refs}
+}
+
+func (w *UserSessionWriter) WriteMultiBuffer(mb buf.MultiBuffer) error {
+	if err := w.Session.AddTraffic(int64(mb.Len())); err != nil {
+		buf.ReleaseMulti(mb)
+		return err
+	}
+	return w.Writer.WriteMultiBuffer(mb)
+}
+
+func (w *UserSessionWriter) release() {
+	w.once.Do(func() {
+		if atomic.AddInt32(w.refs, -1) == 0 {
+			w.Session.Close()
+		}
+	})
+}
+
+func (w *UserSessionWriter) Close() error {
+	w.release()
+	return common.Close(w.Writer)
+}
+
+func (w *UserSessionWriter) Interrupt() {
+	w.release()
+	common.Interrupt(w.Writer)
+}
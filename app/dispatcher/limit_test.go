@@ -0,0 +1,103 @@
+package dispatcher
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	app_policy "github.com/xtls/xray-core/app/policy"
+	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/common/buf"
+	"github.com/xtls/xray-core/common/mux"
+	"github.com/xtls/xray-core/common/net"
+	"github.com/xtls/xray-core/common/protocol"
+	"github.com/xtls/xray-core/common/session"
+	"github.com/xtls/xray-core/transport"
+	"github.com/xtls/xray-core/transport/pipe"
+)
+
+func TestUserConnectionLimitMux(t *testing.T) {
+	pm, err := app_policy.New(context.Background(), &app_policy.Config{
+		Level: map[uint32]*app_policy.Policy{
+			0: {
+				Limit: &app_policy.Policy_Limit{
+					MaxConnections: 1,
+				},
+			},
+		},
+	})
+	common.Must(err)
+	d := &DefaultDispatcher{ohm: &testOutboundManager{handler: echoHandler{}}, policy: pm}
+
+	newInboundContext := func() context.Context {
+		ctx := session.ContextWithID(context.Background(), session.NewID())
+		return session.ContextWithInbound(ctx, &session.Inbound{
+			Tag:    "in",
+			Source: net.TCPDestination(net.LocalHostIP, 1234),
+			User:   &protocol.MemoryUser{Email: "a"},
+		})
+	}
+
+	// A mux connection carrying several sub-connections.
+	uplinkReader, uplinkWriter := pipe.New(pipe.WithoutSizeLimit())
+	downlinkReader, downlinkWriter := pipe.New(pipe.WithoutSizeLimit())
+	_, err = mux.NewServerWorker(newInboundContext(), d, &transport.Link{Reader: uplinkReader, Writer: downlinkWriter})
+	common.Must(err)
+	client, err := mux.NewClientWorker(transport.Link{Reader: downlinkReader, Writer: uplinkWriter}, mux.ClientStrategy{MaxConcurrency: 4})
+	common.Must(err)
+
+	var writers []buf.Writer
+	for i := 0; i < 3; i++ {
+		ctx := session.ContextWithOutbound(context.Background(), &session.Outbound{
+			Target: net.TCPDestination(net.DomainAddress("example.com"), 443),
+		})
+		inputReader, inputWriter := pipe.New(pipe.WithoutSizeLimit())
+		outputReader, outputWriter := pipe.New(pipe.WithoutSizeLimit())
+		if !client.Dispatch(ctx, &transport.Link{Reader: inputReader, Writer: outputWriter}) {
+			t.Fatal("failed to dispatch sub-connection ", i)
+		}
+
+		b := buf.New()
+		b.WriteString("abcd")
+		common.Must(inputWriter.WriteMultiBuffer(buf.MultiBuffer{b}))
+		mb, err := outputReader.ReadMultiBuffer()
+		if err != nil {
+			t.Fatal("sub-connection ", i, " rejected: ", err)
+		}
+		if mb.String() != "abcd" {
+			t.Error("unexpected response ", mb.String())
+		}
+		buf.ReleaseMulti(mb)
+		writers = append(writers, inputWriter)
+	}
+
+	usage, _ := pm.GetUserUsage("a", false)
+	if usage.Connections != 1 {
+		t.Error("expect 1 connection, but got ", usage.Connections)
+	}
+
+	// Another inbound connection exceeds the limit.
+	if _, err := d.Dispatch(newInboundContext(), net.TCPDestination(net.DomainAddress("example.com"), 443)); err == nil {
+		t.Error("expect too many connections")
+	}
+
+	for _, w := range writers {
+		common.Close(w)
+	}
+}
+
+func TestRateLimitersCleanup(t *testing.T) {
+	var r rateLimiters
+	idle := r.get("user>>>idle>>>uplink", 1000, 0)
+	active := r.get("user>>>active>>>uplink", 1000, 0)
+	idle.last = time.Now().Add(-bucketIdleTimeout)
+	r.cleaned = time.Time{}
+
+	r.cleanup(time.Now())
+	if len(r.buckets) != 1 || r.buckets["user>>>active>>>uplink"] != active {
+		t.Error("expect only the idle bucket evicted, but got ", r.buckets)
+	}
+	if r.get("user>>>idle>>>uplink", 1000, 0) == idle {
+		t.Error("expect a new bucket after eviction")
+	}
+}
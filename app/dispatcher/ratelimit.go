@@ -0,0 +1,161 @@
+package dispatcher
+
+import (
+	"sync"
+	"time"
+
+	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/common/buf"
+	"github.com/xtls/xray-core/common/signal/done"
+)
+
+// TokenBucket limits the throughput to rate bytes per second, allowing bursts of at most burst bytes.
+type TokenBucket struct {
+	access sync.Mutex
+	rate   int64
+	burst  int64
+	tokens float64
+	last   time.Time
+}
+
+// NewTokenBucket creates a full TokenBucket. burst defaults to rate if not positive.
+func NewTokenBucket(rate, burst int64) *TokenBucket {
+	b := &TokenBucket{last: time.Now()}
+	b.SetLimit(rate, burst)
+	b.tokens = float64(b.burst)
+	return b
+}
+
+// SetLimit changes the rate and burst of the TokenBucket.
+func (b *TokenBucket) SetLimit(rate, burst int64) {
+	if burst <= 0 {
+		burst = rate
+	}
+
+	b.access.Lock()
+	defer b.access.Unlock()
+
+	b.rate = rate
+	b.burst = burst
+}
+
+// Take takes n tokens from the TokenBucket, and returns how long to wait before the tokens are available. The tokens
+// are taken regardless, so that writes larger than the burst are delayed instead of blocked forever.
+func (b *TokenBucket) Take(n int64) time.Duration {
+	b.access.Lock()
+	defer b.access.Unlock()
+
+	now := time.Now()
+	b.tokens += now.Sub(b.last).Seconds() * float64(b.rate)
+	if b.tokens > float64(b.burst) {
+		b.tokens = float64(b.burst)
+	}
+	b.last = now
+
+	b.tokens -= float64(n)
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / float64(b.rate) * float64(time.Second))
+}
+
+// lastUsed returns the time the TokenBucket was last taken from.
+func (b *TokenBucket) lastUsed() time.Time {
+	b.access.Lock()
+	defer b.access.Unlock()
+
+	return b.last
+}
+
+// RateLimitedWriter is a buf.Writer that delays writes to comply with all of its TokenBuckets.
+type RateLimitedWriter struct {
+	Buckets []*TokenBucket
+	Writer  buf.Writer
+	done    *done.Instance
+	once    sync.Once
+}
+
+func (w *RateLimitedWriter) getDone() *done.Instance {
+	w.once.Do(func() {
+		w.done = done.New()
+	})
+	return w.done
+}
+
+func (w *RateLimitedWriter) WriteMultiBuffer(mb buf.MultiBuffer) error {
+	n := int64(mb.Len())
+	var wait time.Duration
+	for _, b := range w.Buckets {
+		if d := b.Take(n); d > wait {
+			wait = d
+		}
+	}
+	if wait > 0 {
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-w.getDone().Wait():
+			timer.Stop()
+			buf.ReleaseMulti(mb)
+			return newError("rate limited writer interrupted")
+		}
+	}
+	return w.Writer.WriteMultiBuffer(mb)
+}
+
+func (w *RateLimitedWriter) Close() error {
+	return common.Close(w.Writer)
+}
+
+func (w *RateLimitedWriter) Interrupt() {
+	w.getDone().Close()
+	common.Interrupt(w.Writer)
+}
+
+// bucketIdleTimeout is how long an unused TokenBucket is kept in rateLimiters. It is longer than the default
+// connection idle timeout, so that buckets of live connections are rarely evicted.
+const bucketIdleTimeout = 10 * time.Minute
+
+// rateLimiters holds the TokenBuckets shared by connections, such as the ones of the same user.
+type rateLimiters struct {
+	access  sync.Mutex
+	buckets map[string]*TokenBucket
+	cleaned time.Time
+}
+
+// get returns the TokenBucket of the given name, updated to the given limits. It returns nil if rate is not positive.
+func (r *rateLimiters) get(name string, rate, burst int64) *TokenBucket {
+	r.access.Lock()
+	defer r.access.Unlock()
+
+	r.cleanup(time.Now())
+	if rate <= 0 {
+		delete(r.buckets, name)
+		return nil
+	}
+	if r.buckets == nil {
+		r.buckets = make(map[string]*TokenBucket)
+	}
+	b, found := r.buckets[name]
+	if !found {
+		b = NewTokenBucket(rate, burst)
+		r.buckets[name] = b
+		return b
+	}
+	b.SetLimit(rate, burst)
+	return b
+}
+
+// cleanup removes the TokenBuckets unused for bucketIdleTimeout. It scans the buckets at most once per
+// bucketIdleTimeout.
+func (r *rateLimiters) cleanup(now time.Time) {
+	if now.Sub(r.cleaned) < bucketIdleTimeout {
+		return
+	}
+	r.cleaned = now
+	for name, b := range r.buckets {
+		if now.Sub(b.lastUsed()) >= bucketIdleTimeout {
+			delete(r.buckets, name)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package dispatcher_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/xtls/xray-core/app/dispatcher"
+	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/common/buf"
+)
+
+func TestTokenBucket(t *testing.T) {
+	b := NewTokenBucket(1000, 0)
+	if d := b.Take(1000); d != 0 {
+		t.Error("expect no wait within burst, but got ", d)
+	}
+	if d := b.Take(500); d < 400*time.Millisecond || d > 500*time.Millisecond {
+		t.Error("expect to wait about 500ms, but got ", d)
+	}
+
+	b.SetLimit(1000000, 0)
+	time.Sleep(10 * time.Millisecond)
+	if d := b.Take(1000); d != 0 {
+		t.Error("expect no wait after refilled, but got ", d)
+	}
+}
+
+func TestRateLimitedWriter(t *testing.T) {
+	var c TestCounter
+	writer := &RateLimitedWriter{
+		Buckets: []*TokenBucket{NewTokenBucket(10000, 0), NewTokenBucket(5000, 0)},
+		Writer: &SizeStatWriter{
+			Counter: &c,
+			Writer:  buf.Discard,
+		},
+	}
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		mb := buf.MergeBytes(nil, make([]byte, 5000))
+		common.Must(writer.WriteMultiBuffer(mb))
+	}
+	// 5000 bytes of burst, then 10000 bytes at 5000 bytes per second.
+	if d := time.Since(start); d < 1900*time.Millisecond {
+		t.Error("expect to take about 2s, but only took ", d)
+	}
+	if c.Value() != 15000 {
+		t.Error("expect 15000 bytes written, but got ", c.Value())
+	}
+
+	mb := buf.MergeBytes(nil, make([]byte, 5000))
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		writer.Interrupt()
+	}()
+	if err := writer.WriteMultiBuffer(mb); err == nil {
+		t.Error("expect error after interrupted")
+	}
+}
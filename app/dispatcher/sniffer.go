@@ -6,9 +6,15 @@ import (
 	"github.com/xtls/xray-core/common"
 	"github.com/xtls/xray-core/common/net"
 	"github.com/xtls/xray-core/common/protocol/bittorrent"
+	"github.com/xtls/xray-core/common/protocol/dtls"
 	"github.com/xtls/xray-core/common/protocol/http"
+	"github.com/xtls/xray-core/common/protocol/mail"
 	"github.com/xtls/xray-core/common/protocol/quic"
+	"github.com/xtls/xray-core/common/protocol/rdp"
+	"github.com/xtls/xray-core/common/protocol/ssh"
+	"github.com/xtls/xray-core/common/protocol/stun"
 	"github.com/xtls/xray-core/common/protocol/tls"
+	"github.com/xtls/xray-core/common/session"
 )
 
 type SniffResult interface {
@@ -37,8 +43,12 @@ func NewSniffer(ctx context.Context) *Sniffer {
 			{func(c context.Context, b []byte) (SniffResult, error) { return http.SniffHTTP(b) }, false, net.Network_TCP},
 			{func(c context.Context, b []byte) (SniffResult, error) { return tls.SniffTLS(b) }, false, net.Network_TCP},
 			{func(c context.Context, b []byte) (SniffResult, error) { return bittorrent.SniffBittorrent(b) }, false, net.Network_TCP},
+			{func(c context.Context, b []byte) (SniffResult, error) { return ssh.SniffSSH(b) }, false, net.Network_TCP},
+			{func(c context.Context, b []byte) (SniffResult, error) { return rdp.SniffRDP(b) }, false, net.Network_TCP},
 			{func(c context.Context, b []byte) (SniffResult, error) { return quic.SniffQUIC(b) }, false, net.Network_UDP},
 			{func(c context.Context, b []byte) (SniffResult, error) { return bittorrent.SniffUTP(b) }, false, net.Network_UDP},
+			{func(c context.Context, b []byte) (SniffResult, error) { return dtls.SniffDTLS(b) }, false, net.Network_UDP},
+			{func(c context.Context, b []byte) (SniffResult, error) { return stun.SniffSTUN(b) }, false, net.Network_UDP},
 		},
 	}
 	if sniffer, err := newFakeDNSSniffer(ctx); err == nil {
@@ -49,9 +59,22 @@ func NewSniffer(ctx context.Context) *Sniffer {
 			ret.sniffer = append([]protocolSnifferWithMetadata{fakeDNSThenOthers}, ret.sniffer...)
 		}
 	}
+	// The mail sniffer comes after the fake DNS one, as it gives no domain.
+	ret.sniffer = append(ret.sniffer, newMailSniffer())
 	return ret
 }
 
+// newMailSniffer creates a metadata sniffer for mail protocols, in which the server speaks first.
+func newMailSniffer() protocolSnifferWithMetadata {
+	return protocolSnifferWithMetadata{protocolSniffer: func(ctx context.Context, b []byte) (SniffResult, error) {
+		outbound := session.OutboundFromContext(ctx)
+		if outbound == nil {
+			return nil, errUnknownContent
+		}
+		return mail.SniffMail(outbound.Target)
+	}, metadataSniffer: true}
+}
+
 var errUnknownContent = newError("unknown content")
 
 func (s *Sniffer) Sniff(c context.Context, payload []byte, network net.Network) (SniffResult, error) {
@@ -135,3 +158,23 @@ type SnifferResultComposite interface {
 type SnifferIsProtoSubsetOf interface {
 	IsProtoSubsetOf(protocolName string) bool
 }
+
+// SnifferResultClientHello is the result of sniffing TLS or QUIC client hellos.
+type SnifferResultClientHello interface {
+	ALPN() []string
+	JA3() string
+	JA4() string
+}
+
+// setSniffedContent records the sniffing result in the content for routing.
+func setSniffedContent(content *session.Content, result SniffResult) {
+	content.Protocol = result.Protocol()
+	if composite, ok := result.(*compositeResult); ok {
+		result = composite.protocolResult
+	}
+	if hello, ok := result.(SnifferResultClientHello); ok {
+		content.ALPN = hello.ALPN()
+		content.JA3 = hello.JA3()
+		content.JA4 = hello.JA4()
+	}
+}
@@ -0,0 +1,70 @@
+package dispatcher_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/xtls/xray-core/app/dispatcher"
+	"github.com/xtls/xray-core/app/proxyman"
+	_ "github.com/xtls/xray-core/app/proxyman/inbound"
+	_ "github.com/xtls/xray-core/app/proxyman/outbound"
+	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/common/net"
+	"github.com/xtls/xray-core/common/serial"
+	"github.com/xtls/xray-core/common/session"
+	"github.com/xtls/xray-core/core"
+	"github.com/xtls/xray-core/features/outbound"
+	"github.com/xtls/xray-core/transport"
+)
+
+// sniffedHandler is an outbound that reports the sniffed protocol of the connections.
+type sniffedHandler chan string
+
+func (sniffedHandler) Tag() string  { return "sniffed" }
+func (sniffedHandler) Start() error { return nil }
+func (sniffedHandler) Close() error { return nil }
+func (h sniffedHandler) Dispatch(ctx context.Context, link *transport.Link) {
+	h <- session.ContentFromContext(ctx).Protocol
+	common.Interrupt(link.Writer)
+}
+
+func TestSniffServerFirstProtocol(t *testing.T) {
+	server, err := core.New(&core.Config{
+		App: []*serial.TypedMessage{
+			serial.ToTypedMessage(&Config{}),
+			serial.ToTypedMessage(&proxyman.InboundConfig{}),
+			serial.ToTypedMessage(&proxyman.OutboundConfig{}),
+		},
+	})
+	common.Must(err)
+	handler := make(sniffedHandler, 1)
+	common.Must(server.GetFeature(outbound.ManagerType()).(outbound.Manager).AddHandler(context.Background(), handler))
+
+	cases := []struct {
+		port     net.Port
+		protocol string
+	}{
+		{25, "smtp"},
+		{993, "imap"},
+		{8080, ""},
+	}
+	for _, test := range cases {
+		ctx := session.ContextWithContent(context.Background(), &session.Content{
+			SniffingRequest: session.SniffingRequest{Enabled: true},
+		})
+		// the client sends nothing, waiting for the greeting of the server
+		conn, err := core.Dial(ctx, server, net.TCPDestination(net.ParseAddress("192.0.2.1"), test.port))
+		common.Must(err)
+
+		select {
+		case protocol := <-handler:
+			if protocol != test.protocol {
+				t.Error("expect ", test.protocol, " for port ", test.port, ", but got ", protocol)
+			}
+		case <-time.After(5 * time.Second):
+			t.Error("connection to port ", test.port, " not dispatched")
+		}
+		conn.Close()
+	}
+}
@@ -0,0 +1,421 @@
+package dns
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/common/net"
+	"github.com/xtls/xray-core/common/task"
+	"github.com/xtls/xray-core/core"
+	dns_feature "github.com/xtls/xray-core/features/dns"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+const (
+	// Popular records are refreshed within prefetchBefore of expiry.
+	prefetchBefore = 10 * time.Second
+	// A record is popular once it is hit prefetchHits times since last update.
+	prefetchHits = 2
+	// A record is not refreshed again within refreshInterval, if the previous refresh gets no answer.
+	refreshInterval = 10 * time.Second
+)
+
+type cacheEntry struct {
+	record
+	hits      uint32
+	refreshAt time.Time
+}
+
+type recordEntry struct {
+	*RRRecord
+	refreshAt time.Time
+}
+
+// Cache is the record cache shared by the name servers. Records are keyed by name server and domain. Records of types
+// other than A and AAAA are not saved to file.
+type Cache struct {
+	sync.RWMutex
+	entries    map[string]*cacheEntry
+	records    map[string]*recordEntry
+	serveStale time.Duration
+	prefetch   bool
+	path       string
+	dirty      bool
+	cleanup    *task.Periodic
+}
+
+// NewCache creates a cache. Expired records are served for at most serveStale while being refreshed, and popular
+// records are refreshed before expiry if prefetch is enabled. If path is not empty, the cache is saved to and loaded
+// from the file.
+func NewCache(serveStale time.Duration, prefetch bool, path string) *Cache {
+	c := &Cache{
+		entries:    make(map[string]*cacheEntry),
+		records:    make(map[string]*recordEntry),
+		serveStale: serveStale,
+		prefetch:   prefetch,
+		path:       path,
+	}
+	c.cleanup = &task.Periodic{
+		Interval: time.Minute,
+		Execute:  c.Cleanup,
+	}
+	return c
+}
+
+func cacheKey(server string, domain string) string {
+	return server + "|" + domain
+}
+
+// setOptions takes the options of another cache, used on reloading. If the cache file changes, the cache is saved
+// to the old file first, then merged with the records in the new file and saved to it.
+func (c *Cache) setOptions(o *Cache) {
+	c.RLock()
+	pathChanged := c.path != o.path
+	c.RUnlock()
+	if pathChanged {
+		if err := c.Save(); err != nil {
+			newError("failed to save DNS cache").Base(err).AtWarning().WriteToLog()
+		}
+	}
+
+	c.Lock()
+	c.serveStale = o.serveStale
+	c.prefetch = o.prefetch
+	c.path = o.path
+	if pathChanged {
+		c.dirty = len(c.entries) > 0
+	}
+	c.Unlock()
+
+	if pathChanged {
+		if err := c.Load(); err != nil {
+			newError("failed to load DNS cache").Base(err).AtWarning().WriteToLog()
+		}
+	}
+}
+
+// Cleanup clears records that are expired beyond serve stale, and saves the cache to file.
+func (c *Cache) Cleanup() error {
+	now := time.Now()
+	c.Lock()
+
+	if len(c.entries) == 0 && len(c.records) == 0 {
+		c.Unlock()
+		return newError("nothing to do. stopping...")
+	}
+
+	for key, entry := range c.entries {
+		if entry.A != nil && entry.A.Expire.Add(c.serveStale).Before(now) {
+			entry.A = nil
+		}
+		if entry.AAAA != nil && entry.AAAA.Expire.Add(c.serveStale).Before(now) {
+			entry.AAAA = nil
+		}
+		if entry.A == nil && entry.AAAA == nil {
+			newError("cleanup ", key).AtDebug().WriteToLog()
+			delete(c.entries, key)
+			c.dirty = true
+		}
+	}
+	for key, entry := range c.records {
+		if entry.Expire.Add(c.serveStale).Before(now) {
+			newError("cleanup ", key).AtDebug().WriteToLog()
+			delete(c.records, key)
+		}
+	}
+	c.Unlock()
+
+	if err := c.Save(); err != nil {
+		newError("failed to save DNS cache").Base(err).AtWarning().WriteToLog()
+	}
+	return nil
+}
+
+// update stores the newer records of the domain.
+func (c *Cache) update(server string, domain string, rec *record) {
+	key := cacheKey(server, domain)
+	c.Lock()
+	entry, found := c.entries[key]
+	if !found {
+		entry = &cacheEntry{}
+		c.entries[key] = entry
+	}
+	if isNewer(entry.A, rec.A) {
+		entry.A = rec.A
+		entry.hits = 0
+		entry.refreshAt = time.Time{}
+		c.dirty = true
+	}
+	if isNewer(entry.AAAA, rec.AAAA) {
+		entry.AAAA = rec.AAAA
+		entry.hits = 0
+		entry.refreshAt = time.Time{}
+		c.dirty = true
+	}
+	c.Unlock()
+	common.Must(c.cleanup.Start())
+}
+
+func (c *Cache) getIPs(r *IPRecord, now time.Time, stale bool) ([]net.Address, bool, error) {
+	if r == nil {
+		return nil, false, errRecordNotFound
+	}
+	expired := r.Expire.Before(now)
+	if expired && (!stale || r.Expire.Add(c.serveStale).Before(now)) {
+		return nil, false, errRecordNotFound
+	}
+	if r.RCode != dnsmessage.RCodeSuccess {
+		return nil, expired, dns_feature.RCodeError(r.RCode)
+	}
+	return r.IP, expired, nil
+}
+
+// lookup returns the cached IPs of the domain, or errRecordNotFound if there is no usable record. Expired records
+// within serve stale are used if stale is true. refresh is true if the record should be refreshed in the background,
+// as it is stale or is popular and about to expire.
+func (c *Cache) lookup(server string, domain string, option dns_feature.IPOption, stale bool) (ips []net.IP, refresh bool, err error) {
+	now := time.Now()
+	c.Lock()
+	defer c.Unlock()
+
+	entry, found := c.entries[cacheKey(server, domain)]
+	if !found {
+		return nil, false, errRecordNotFound
+	}
+
+	var err4, err6 error
+	var addrs, addrs6 []net.Address
+	var stale4, stale6 bool
+	var expire time.Time
+
+	if option.IPv4Enable {
+		addrs, stale4, err4 = c.getIPs(entry.A, now, stale)
+		if entry.A != nil {
+			expire = entry.A.Expire
+		}
+	}
+	if option.IPv6Enable {
+		addrs6, stale6, err6 = c.getIPs(entry.AAAA, now, stale)
+		addrs = append(addrs, addrs6...)
+		if entry.AAAA != nil && (expire.IsZero() || entry.AAAA.Expire.Before(expire)) {
+			expire = entry.AAAA.Expire
+		}
+	}
+
+	switch {
+	case len(addrs) > 0:
+		ips, err = toNetIP(addrs)
+	case err4 != nil && err4 != errRecordNotFound:
+		err = err4
+	case err6 != nil && err6 != errRecordNotFound:
+		err = err6
+	case err4 == errRecordNotFound || err6 == errRecordNotFound:
+		return nil, false, errRecordNotFound
+	case (option.IPv4Enable && entry.A != nil) || (option.IPv6Enable && entry.AAAA != nil):
+		err = dns_feature.ErrEmptyResponse
+	default:
+		return nil, false, errRecordNotFound
+	}
+
+	entry.hits++
+	refresh = stale4 || stale6 || (c.prefetch && entry.hits >= prefetchHits && expire.Sub(now) < prefetchBefore)
+	if refresh {
+		if now.Before(entry.refreshAt) {
+			refresh = false
+		} else {
+			entry.refreshAt = now.Add(refreshInterval)
+		}
+	}
+	return ips, refresh, err
+}
+
+func recordKey(server string, domain string, qType dnsmessage.Type) string {
+	return server + "|" + domain + "|" + qType.String()
+}
+
+// updateRecords stores the newer records of the type for the domain.
+func (c *Cache) updateRecords(server string, domain string, qType dnsmessage.Type, rec *RRRecord) {
+	key := recordKey(server, domain, qType)
+	c.Lock()
+	if entry, found := c.records[key]; !found || entry.Expire.Before(rec.Expire) {
+		c.records[key] = &recordEntry{RRRecord: rec}
+	}
+	c.Unlock()
+	common.Must(c.cleanup.Start())
+}
+
+// lookupRecords returns the cached records of the type for the domain, like lookup.
+func (c *Cache) lookupRecords(server string, domain string, qType dnsmessage.Type, stale bool) (answers []dnsmessage.Resource, refresh bool, err error) {
+	now := time.Now()
+	c.Lock()
+	defer c.Unlock()
+
+	entry, found := c.records[recordKey(server, domain, qType)]
+	if !found {
+		return nil, false, errRecordNotFound
+	}
+	expired := entry.Expire.Before(now)
+	if expired && (!stale || entry.Expire.Add(c.serveStale).Before(now)) {
+		return nil, false, errRecordNotFound
+	}
+	if expired && !now.Before(entry.refreshAt) {
+		entry.refreshAt = now.Add(refreshInterval)
+		refresh = true
+	}
+	answers, err = entry.getAnswers(now)
+	return answers, refresh, err
+}
+
+// getRecords looks up the cache for a query of the type, and refreshes the records in the background with query if
+// they are stale.
+func (c *Cache) getRecords(ctx context.Context, server string, domain string, qType dnsmessage.Type, query func(context.Context, string)) ([]dnsmessage.Resource, error) {
+	answers, refresh, err := c.lookupRecords(server, domain, qType, true)
+	if refresh {
+		newError(server, " refreshing ", domain, " ", qType).AtDebug().WriteToLog()
+		go query(core.ToBackgroundDetachedContext(ctx), domain)
+	}
+	return answers, err
+}
+
+type cacheFileRecord struct {
+	Server string    `json:"server"`
+	Domain string    `json:"domain"`
+	Type   string    `json:"type"`
+	IP     []string  `json:"ip,omitempty"`
+	RCode  uint16    `json:"rcode,omitempty"`
+	Expire time.Time `json:"expire"`
+}
+
+func toCacheFileRecord(key string, qType string, r *IPRecord) cacheFileRecord {
+	server, domain, _ := strings.Cut(key, "|")
+	rec := cacheFileRecord{
+		Server: server,
+		Domain: domain,
+		Type:   qType,
+		RCode:  uint16(r.RCode),
+		Expire: r.Expire,
+	}
+	for _, ip := range r.IP {
+		rec.IP = append(rec.IP, ip.String())
+	}
+	return rec
+}
+
+// Save writes the cache to its file, if the cache has changed.
+func (c *Cache) Save() error {
+	c.Lock()
+	if len(c.path) == 0 || !c.dirty {
+		c.Unlock()
+		return nil
+	}
+	path := c.path
+	records := make([]cacheFileRecord, 0, len(c.entries)*2)
+	for key, entry := range c.entries {
+		if entry.A != nil {
+			records = append(records, toCacheFileRecord(key, "A", entry.A))
+		}
+		if entry.AAAA != nil {
+			records = append(records, toCacheFileRecord(key, "AAAA", entry.AAAA))
+		}
+	}
+	c.dirty = false
+	c.Unlock()
+
+	content, err := json.Marshal(records)
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, content, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// Load reads the cache from its file, skipping the records expired beyond serve stale.
+func (c *Cache) Load() error {
+	c.RLock()
+	path := c.path
+	c.RUnlock()
+	if len(path) == 0 {
+		return nil
+	}
+
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var records []cacheFileRecord
+	if err := json.Unmarshal(content, &records); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	count := 0
+	c.Lock()
+	for _, r := range records {
+		if r.Expire.Add(c.serveStale).Before(now) {
+			continue
+		}
+		ipRec := &IPRecord{
+			Expire: r.Expire,
+			RCode:  dnsmessage.RCode(r.RCode),
+		}
+		for _, ip := range r.IP {
+			ipRec.IP = append(ipRec.IP, net.ParseAddress(ip))
+		}
+		key := cacheKey(r.Server, r.Domain)
+		entry, found := c.entries[key]
+		if !found {
+			entry = &cacheEntry{}
+		}
+		// the records in memory are kept if they are fresher than the ones in the file
+		switch r.Type {
+		case "A":
+			if !isNewer(entry.A, ipRec) {
+				continue
+			}
+			entry.A = ipRec
+		case "AAAA":
+			if !isNewer(entry.AAAA, ipRec) {
+				continue
+			}
+			entry.AAAA = ipRec
+		default:
+			continue
+		}
+		c.entries[key] = entry
+		count++
+	}
+	c.Unlock()
+	if count > 0 {
+		common.Must(c.cleanup.Start())
+	}
+	newError("loaded ", count, " DNS records from ", path).AtInfo().WriteToLog()
+	return nil
+}
+
+// Close stops cleaning up the cache and saves it.
+func (c *Cache) Close() error {
+	c.cleanup.Close()
+	return c.Save()
+}
+
+// get looks up the cache for a query, and refreshes the record in the background with query if it is stale or about
+// to expire.
+func (c *Cache) get(ctx context.Context, server string, domain string, option dns_feature.IPOption, query func(context.Context)) ([]net.IP, error) {
+	ips, refresh, err := c.lookup(server, domain, option, true)
+	if refresh {
+		newError(server, " refreshing ", domain).AtDebug().WriteToLog()
+		go query(core.ToBackgroundDetachedContext(ctx))
+	}
+	return ips, err
+}
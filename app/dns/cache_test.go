@@ -0,0 +1,161 @@
+package dns
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/common/net"
+	dns_feature "github.com/xtls/xray-core/features/dns"
+)
+
+func TestCacheServeStale(t *testing.T) {
+	c := NewCache(time.Minute, false, "")
+	defer c.Close()
+
+	option := dns_feature.IPOption{IPv4Enable: true}
+	c.update("udp", "example.com.", &record{A: &IPRecord{
+		IP:     []net.Address{net.ParseAddress("1.2.3.4")},
+		Expire: time.Now().Add(-time.Second),
+	}})
+
+	if _, _, err := c.lookup("udp", "example.com.", option, false); err != errRecordNotFound {
+		t.Error("expect expired record not found, but got ", err)
+	}
+
+	ips, refresh, err := c.lookup("udp", "example.com.", option, true)
+	common.Must(err)
+	if r := cmp.Diff(ips, []net.IP{{1, 2, 3, 4}}); r != "" {
+		t.Error(r)
+	}
+	if !refresh {
+		t.Error("expect stale record to be refreshed")
+	}
+
+	if _, refresh, _ := c.lookup("udp", "example.com.", option, true); refresh {
+		t.Error("expect no refresh while the previous one is pending")
+	}
+
+	if _, _, err := c.lookup("tcp", "example.com.", option, true); err != errRecordNotFound {
+		t.Error("expect records of other servers not found, but got ", err)
+	}
+}
+
+func TestCachePrefetch(t *testing.T) {
+	c := NewCache(0, true, "")
+	defer c.Close()
+
+	option := dns_feature.IPOption{IPv4Enable: true}
+	c.update("udp", "example.com.", &record{A: &IPRecord{
+		IP:     []net.Address{net.ParseAddress("1.2.3.4")},
+		Expire: time.Now().Add(5 * time.Second),
+	}})
+
+	if _, refresh, _ := c.lookup("udp", "example.com.", option, true); refresh {
+		t.Error("expect no prefetch of unpopular record")
+	}
+	if _, refresh, _ := c.lookup("udp", "example.com.", option, true); !refresh {
+		t.Error("expect prefetch of popular record")
+	}
+}
+
+func TestCacheSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dns.cache")
+
+	c := NewCache(0, false, path)
+	c.update("udp", "example.com.", &record{
+		A: &IPRecord{
+			IP:     []net.Address{net.ParseAddress("1.2.3.4")},
+			Expire: time.Now().Add(time.Hour),
+		},
+		AAAA: &IPRecord{
+			IP:     []net.Address{net.ParseAddress("2001:db8::1")},
+			Expire: time.Now().Add(time.Hour),
+		},
+	})
+	c.update("udp", "expired.com.", &record{A: &IPRecord{
+		IP:     []net.Address{net.ParseAddress("5.6.7.8")},
+		Expire: time.Now().Add(-time.Second),
+	}})
+	common.Must(c.Close())
+
+	c = NewCache(0, false, path)
+	defer c.Close()
+	common.Must(c.Load())
+
+	ips, _, err := c.lookup("udp", "example.com.", dns_feature.IPOption{IPv4Enable: true, IPv6Enable: true}, false)
+	common.Must(err)
+	if r := cmp.Diff(ips, []net.IP{{1, 2, 3, 4}, net.ParseIP("2001:db8::1")}); r != "" {
+		t.Error(r)
+	}
+	if _, _, err := c.lookup("udp", "expired.com.", dns_feature.IPOption{IPv4Enable: true}, true); err != errRecordNotFound {
+		t.Error("expect expired record not loaded, but got ", err)
+	}
+}
+
+func TestCacheChangeFile(t *testing.T) {
+	dir := t.TempDir()
+	oldPath := filepath.Join(dir, "old.cache")
+	newPath := filepath.Join(dir, "new.cache")
+
+	other := NewCache(0, false, newPath)
+	other.update("udp", "example.org.", &record{A: &IPRecord{
+		IP:     []net.Address{net.ParseAddress("5.6.7.8")},
+		Expire: time.Now().Add(time.Hour),
+	}})
+	common.Must(other.Close())
+
+	c := NewCache(0, false, oldPath)
+	c.update("udp", "example.com.", &record{A: &IPRecord{
+		IP:     []net.Address{net.ParseAddress("1.2.3.4")},
+		Expire: time.Now().Add(time.Hour),
+	}})
+	c.setOptions(NewCache(0, false, newPath))
+	common.Must(c.Close())
+
+	for _, path := range []string{oldPath, newPath} {
+		loaded := NewCache(0, false, path)
+		common.Must(loaded.Load())
+		if _, _, err := loaded.lookup("udp", "example.com.", dns_feature.IPOption{IPv4Enable: true}, false); err != nil {
+			t.Error("expect example.com saved to ", path, ", but got ", err)
+		}
+		loaded.cleanup.Close()
+	}
+	if _, _, err := c.lookup("udp", "example.org.", dns_feature.IPOption{IPv4Enable: true}, false); err != nil {
+		t.Error("expect example.org loaded from the new file, but got ", err)
+	}
+}
+
+func TestCacheLoadKeepsFresherRecords(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dns.cache")
+
+	saved := NewCache(0, false, path)
+	saved.update("udp", "example.com.", &record{
+		A: &IPRecord{
+			IP:     []net.Address{net.ParseAddress("1.2.3.4")},
+			Expire: time.Now().Add(time.Minute),
+		},
+		AAAA: &IPRecord{
+			IP:     []net.Address{net.ParseAddress("2001:db8::1")},
+			Expire: time.Now().Add(time.Minute),
+		},
+	})
+	common.Must(saved.Close())
+
+	// the record is not updated with update, which saves the cache to the file as the cleanup starts
+	c := NewCache(0, false, path)
+	defer c.cleanup.Close()
+	c.entries[cacheKey("udp", "example.com.")] = &cacheEntry{record: record{A: &IPRecord{
+		IP:     []net.Address{net.ParseAddress("5.6.7.8")},
+		Expire: time.Now().Add(time.Hour),
+	}}}
+	common.Must(c.Load())
+
+	ips, _, err := c.lookup("udp", "example.com.", dns_feature.IPOption{IPv4Enable: true, IPv6Enable: true}, false)
+	common.Must(err)
+	if r := cmp.Diff(ips, []net.IP{{5, 6, 7, 8}, net.ParseIP("2001:db8::1")}); r != "" {
+		t.Error(r)
+	}
+}
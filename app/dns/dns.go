@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/xtls/xray-core/app/router"
 	"github.com/xtls/xray-core/common"
@@ -17,11 +18,12 @@ import (
 	"github.com/xtls/xray-core/common/strmatcher"
 	"github.com/xtls/xray-core/features"
 	"github.com/xtls/xray-core/features/dns"
+	"golang.org/x/net/dns/dnsmessage"
 )
 
 // DNS is a DNS rely server.
 type DNS struct {
-	sync.Mutex
+	sync.RWMutex
 	tag                    string
 	disableCache           bool
 	disableFallback        bool
@@ -32,6 +34,12 @@ type DNS struct {
 	ctx                    context.Context
 	domainMatcher          strmatcher.IndexMatcher
 	matcherInfos           []*DomainMatcherInfo
+	cache                  *Cache
+}
+
+// cacheSetter is implemented by the name servers that cache records, so that they can share the cache of DNS.
+type cacheSetter interface {
+	setCache(cache *Cache)
 }
 
 // DomainMatcherInfo contains information attached to index returned by Server.domainMatcher
@@ -143,6 +151,7 @@ func New(ctx context.Context, config *Config) (*DNS, error) {
 		disableCache:           config.DisableCache,
 		disableFallback:        config.DisableFallback,
 		disableFallbackIfMatch: config.DisableFallbackIfMatch,
+		cache:                  NewCache(time.Duration(config.ServeStale)*time.Second, config.Prefetch, config.CacheFile),
 	}, nil
 }
 
@@ -153,28 +162,92 @@ func (*DNS) Type() interface{} {
 
 // Start implements common.Runnable.
 func (s *DNS) Start() error {
+	s.Lock()
+	defer s.Unlock()
+
+	attachCache(s.clients, s.cache)
+	if err := s.cache.Load(); err != nil {
+		newError("failed to load DNS cache").Base(err).AtWarning().WriteToLog()
+	}
 	return nil
 }
 
 // Close implements common.Closable.
 func (s *DNS) Close() error {
-	return nil
+	s.RLock()
+	clients := s.clients
+	s.RUnlock()
+
+	closeClients(clients)
+	return s.cache.Close()
+}
+
+// closeClients closes the name servers of the clients.
+func closeClients(clients []*Client) {
+	for _, client := range clients {
+		if err := client.Close(); err != nil {
+			newError("failed to close DNS client ", client.Name()).Base(err).AtWarning().WriteToLog()
+		}
+	}
+}
+
+// attachCache makes the name servers of the clients share the cache.
+func attachCache(clients []*Client, cache *Cache) {
+	for _, client := range clients {
+		if setter, ok := client.server.(cacheSetter); ok {
+			setter.setCache(cache)
+		}
+	}
 }
 
 // IsOwnLink implements proxy.dns.ownLinkVerifier
 func (s *DNS) IsOwnLink(ctx context.Context) bool {
+	s.RLock()
+	defer s.RUnlock()
+
 	inbound := session.InboundFromContext(ctx)
 	return inbound != nil && inbound.Tag == s.tag
 }
 
+// Reload implements features.Reloadable.
+func (s *DNS) Reload(f features.Feature) error {
+	n, ok := f.(*DNS)
+	if !ok {
+		return newError("not a DNS client")
+	}
+
+	s.Lock()
+	oldClients := s.clients
+	s.tag = n.tag
+	s.disableCache = n.disableCache
+	s.disableFallback = n.disableFallback
+	s.disableFallbackIfMatch = n.disableFallbackIfMatch
+	s.ipOption = n.ipOption
+	s.hosts = n.hosts
+	s.clients = n.clients
+	attachCache(s.clients, s.cache)
+	s.domainMatcher = n.domainMatcher
+	s.matcherInfos = n.matcherInfos
+	cache := s.cache
+	s.Unlock()
+
+	// the cache file may be read and written, so the options are not set under lock
+	cache.setOptions(n.cache)
+	closeClients(oldClients)
+	return nil
+}
+
 // LookupIP implements dns.Client.
 func (s *DNS) LookupIP(domain string, option dns.IPOption) ([]net.IP, error) {
 	if domain == "" {
 		return nil, newError("empty domain name")
 	}
 
+	s.RLock()
 	option.IPv4Enable = option.IPv4Enable && s.ipOption.IPv4Enable
 	option.IPv6Enable = option.IPv6Enable && s.ipOption.IPv6Enable
+	hosts := s.hosts
+	s.RUnlock()
 
 	if !option.IPv4Enable && !option.IPv6Enable {
 		return nil, dns.ErrEmptyResponse
@@ -186,7 +259,7 @@ func (s *DNS) LookupIP(domain string, option dns.IPOption) ([]net.IP, error) {
 	}
 
 	// Static host lookup
-	switch addrs := s.hosts.Lookup(domain, option); {
+	switch addrs := hosts.Lookup(domain, option); {
 	case addrs == nil: // Domain not recorded in static host
 		break
 	case len(addrs) == 0: // Domain recorded, but no valid IP returned (e.g. IPv4 address with only IPv6 enabled)
@@ -200,14 +273,19 @@ func (s *DNS) LookupIP(domain string, option dns.IPOption) ([]net.IP, error) {
 	}
 
 	// Name servers lookup
-	errs := []error{}
+	s.RLock()
+	clients := s.sortClients(domain)
+	disableCache := s.disableCache
 	ctx := session.ContextWithInbound(s.ctx, &session.Inbound{Tag: s.tag})
-	for _, client := range s.sortClients(domain) {
+	s.RUnlock()
+
+	errs := []error{}
+	for _, client := range clients {
 		if !option.FakeEnable && strings.EqualFold(client.Name(), "FakeDNS") {
 			newError("skip DNS resolution for domain ", domain, " at server ", client.Name()).AtDebug().WriteToLog()
 			continue
 		}
-		ips, err := client.QueryIP(ctx, domain, option, s.disableCache)
+		ips, err := client.QueryIP(ctx, domain, option, disableCache)
 		if len(ips) > 0 {
 			return ips, nil
 		}
@@ -223,6 +301,96 @@ func (s *DNS) LookupIP(domain string, option dns.IPOption) ([]net.IP, error) {
 	return nil, newError("returning nil for domain ", domain).Base(errors.Combine(errs...))
 }
 
+// Query implements dns.Client. A and AAAA queries are resolved by LookupIP, so that FakeDNS and expected IPs apply.
+func (s *DNS) Query(ctx context.Context, domain string, qType dnsmessage.Type) ([]dnsmessage.Resource, error) {
+	// Normalize the FQDN form query
+	domain = strings.TrimSuffix(domain, ".")
+	if domain == "" {
+		// The root zone has no addresses, while its other records, like NS, are queried at the name servers.
+		if isIPQuery(qType) {
+			return nil, dns.ErrEmptyResponse
+		}
+		return s.queryRecords(ctx, domain, qType)
+	}
+
+	if isIPQuery(qType) {
+		ips, err := s.LookupIP(domain, dns.IPOption{
+			IPv4Enable: qType == dnsmessage.TypeA,
+			IPv6Enable: qType == dnsmessage.TypeAAAA,
+			FakeEnable: true,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return toIPResources(domain, qType, ips)
+	}
+
+	s.RLock()
+	hosts := s.hosts
+	s.RUnlock()
+
+	// Static host lookup. Domains with static IPs have no records of other types.
+	switch addrs := hosts.Lookup(domain, dns.IPOption{IPv4Enable: true, IPv6Enable: true}); {
+	case addrs == nil:
+		break
+	case len(addrs) == 1 && addrs[0].Family().IsDomain():
+		newError("domain replaced: ", domain, " -> ", addrs[0].Domain()).WriteToLog()
+		domain = addrs[0].Domain()
+	default:
+		return nil, dns.ErrEmptyResponse
+	}
+
+	return s.queryRecords(ctx, domain, qType)
+}
+
+// queryRecords queries the records of the type for the domain at the name servers.
+func (s *DNS) queryRecords(ctx context.Context, domain string, qType dnsmessage.Type) ([]dnsmessage.Resource, error) {
+	s.RLock()
+	clients := s.sortClients(domain)
+	disableCache := s.disableCache
+	queryCtx := session.ContextWithInbound(s.ctx, &session.Inbound{Tag: s.tag})
+	s.RUnlock()
+
+	errs := []error{}
+	for _, client := range clients {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		answers, err := client.QueryRecords(queryCtx, domain, qType, disableCache)
+		if err == nil {
+			return answers, nil
+		}
+		newError("failed to query ", qType, " for domain ", domain, " at server ", client.Name()).Base(err).WriteToLog()
+		errs = append(errs, err)
+		if err != context.Canceled && err != context.DeadlineExceeded {
+			return nil, err
+		}
+	}
+
+	return nil, newError("returning nil for domain ", domain).Base(errors.Combine(errs...))
+}
+
+func toIPResources(domain string, qType dnsmessage.Type, ips []net.IP) ([]dnsmessage.Resource, error) {
+	name, err := dnsmessage.NewName(Fqdn(domain))
+	if err != nil {
+		return nil, err
+	}
+	header := dnsmessage.ResourceHeader{Name: name, Type: qType, Class: dnsmessage.ClassINET, TTL: 600}
+	answers := make([]dnsmessage.Resource, 0, len(ips))
+	for _, ip := range ips {
+		if qType == dnsmessage.TypeA {
+			var r dnsmessage.AResource
+			copy(r.A[:], ip.To4())
+			answers = append(answers, dnsmessage.Resource{Header: header, Body: &r})
+		} else {
+			var r dnsmessage.AAAAResource
+			copy(r.AAAA[:], ip.To16())
+			answers = append(answers, dnsmessage.Resource{Header: header, Body: &r})
+		}
+	}
+	return answers, nil
+}
+
 // LookupHosts implements dns.HostsLookup.
 func (s *DNS) LookupHosts(domain string) *net.Address {
 	domain = strings.TrimSuffix(domain, ".")
@@ -230,7 +398,9 @@ func (s *DNS) LookupHosts(domain string) *net.Address {
 		return nil
 	}
 	// Normalize the FQDN form query
+	s.RLock()
 	addrs := s.hosts.Lookup(domain, *s.ipOption)
+	s.RUnlock()
 	if len(addrs) > 0 {
 		newError("domain replaced: ", domain, " -> ", addrs[0].String()).AtInfo().WriteToLog()
 		return &addrs[0]
@@ -241,17 +411,26 @@ func (s *DNS) LookupHosts(domain string) *net.Address {
 
 // GetIPOption implements ClientWithIPOption.
 func (s *DNS) GetIPOption() *dns.IPOption {
+	s.RLock()
+	defer s.RUnlock()
+
 	return s.ipOption
 }
 
 // SetQueryOption implements ClientWithIPOption.
 func (s *DNS) SetQueryOption(isIPv4Enable, isIPv6Enable bool) {
+	s.Lock()
+	defer s.Unlock()
+
 	s.ipOption.IPv4Enable = isIPv4Enable
 	s.ipOption.IPv6Enable = isIPv6Enable
 }
 
 // SetFakeDNSOption implements ClientWithIPOption.
 func (s *DNS) SetFakeDNSOption(isFakeEnable bool) {
+	s.Lock()
+	defer s.Unlock()
+
 	s.ipOption.FakeEnable = isFakeEnable
 }
 
@@ -268,7 +447,7 @@ func (s *DNS) sortClients(domain string) []*Client {
 		client := s.clients[info.clientIdx]
 		domainRule := client.domains[info.domainRuleIdx]
 		domainRules = append(domainRules, fmt.Sprintf("%s(DNS idx:%d)", domainRule, info.clientIdx))
-		if clientUsed[info.clientIdx] {
+		if clientUsed[info.clientIdx] || client.isExcluded(domain) {
 			continue
 		}
 		clientUsed[info.clientIdx] = true
@@ -277,10 +456,25 @@ func (s *DNS) sortClients(domain string) []*Client {
 		hasMatch = true
 	}
 
+	// Priority rule set matching
+	for idx, client := range s.clients {
+		if clientUsed[idx] || !client.matchRuleSets(domain) {
+			continue
+		}
+		domainRules = append(domainRules, fmt.Sprintf("rule set(DNS idx:%d)", idx))
+		if client.isExcluded(domain) {
+			continue
+		}
+		clientUsed[idx] = true
+		clients = append(clients, client)
+		clientNames = append(clientNames, client.Name())
+		hasMatch = true
+	}
+
 	if !(s.disableFallback || s.disableFallbackIfMatch && hasMatch) {
 		// Default round-robin query
 		for idx, client := range s.clients {
-			if clientUsed[idx] || client.skipFallback {
+			if clientUsed[idx] || client.skipFallback || client.isExcluded(domain) {
 				continue
 			}
 			clientUsed[idx] = true
@@ -296,7 +490,7 @@ func (s *DNS) sortClients(domain string) []*Client {
 		newError("domain ", domain, " will use DNS in order: ", clientNames).AtDebug().WriteToLog()
 	}
 
-	if len(clients) == 0 {
+	if len(clients) == 0 && !s.clients[0].isExcluded(domain) {
 		clients = append(clients, s.clients[0])
 		clientNames = append(clientNames, s.clients[0].Name())
 		newError("domain ", domain, " will use the first DNS: ", clientNames).AtDebug().WriteToLog()
@@ -11,6 +11,7 @@ import (
 	"github.com/xtls/xray-core/common/log"
 	"github.com/xtls/xray-core/common/net"
 	"github.com/xtls/xray-core/common/session"
+	"github.com/xtls/xray-core/common/signal/pubsub"
 	"github.com/xtls/xray-core/core"
 	dns_feature "github.com/xtls/xray-core/features/dns"
 	"golang.org/x/net/dns/dnsmessage"
@@ -57,6 +58,34 @@ func isNewer(baseRec *IPRecord, newRec *IPRecord) bool {
 	return baseRec.Expire.Before(newRec.Expire)
 }
 
+// RRRecord is a cacheable item for the resource records of a domain, of types other than A and AAAA
+type RRRecord struct {
+	ReqID   uint16
+	Answers []dnsmessage.Resource
+	Expire  time.Time
+	RCode   dnsmessage.RCode
+}
+
+func (r *RRRecord) getAnswers(now time.Time) ([]dnsmessage.Resource, error) {
+	if r.RCode != dnsmessage.RCodeSuccess {
+		return nil, dns_feature.RCodeError(r.RCode)
+	}
+	if len(r.Answers) == 0 {
+		return nil, dns_feature.ErrEmptyResponse
+	}
+	// the TTL of cached answers counts down
+	var ttl uint32
+	if r.Expire.After(now) {
+		ttl = uint32(r.Expire.Sub(now) / time.Second)
+	}
+	answers := make([]dnsmessage.Resource, len(r.Answers))
+	for i, answer := range r.Answers {
+		answers[i] = answer
+		answers[i].Header.TTL = ttl
+	}
+	return answers, nil
+}
+
 var errRecordNotFound = errors.New("record not found")
 
 type dnsRequest struct {
@@ -115,57 +144,44 @@ func genEDNS0Options(clientIP net.IP) *dnsmessage.Resource {
 	return opt
 }
 
-func buildReqMsgs(domain string, option dns_feature.IPOption, reqIDGen func() uint16, reqOpts *dnsmessage.Resource) []*dnsRequest {
-	qA := dnsmessage.Question{
+func buildReqMsg(domain string, qType dnsmessage.Type, reqIDGen func() uint16, reqOpts *dnsmessage.Resource) *dnsRequest {
+	msg := new(dnsmessage.Message)
+	msg.Header.ID = reqIDGen()
+	msg.Header.RecursionDesired = true
+	msg.Questions = []dnsmessage.Question{{
 		Name:  dnsmessage.MustNewName(domain),
-		Type:  dnsmessage.TypeA,
+		Type:  qType,
 		Class: dnsmessage.ClassINET,
+	}}
+	if reqOpts != nil {
+		msg.Additionals = append(msg.Additionals, *reqOpts)
 	}
-
-	qAAAA := dnsmessage.Question{
-		Name:  dnsmessage.MustNewName(domain),
-		Type:  dnsmessage.TypeAAAA,
-		Class: dnsmessage.ClassINET,
+	return &dnsRequest{
+		reqType: qType,
+		domain:  domain,
+		start:   time.Now(),
+		msg:     msg,
 	}
+}
 
+func buildReqMsgs(domain string, option dns_feature.IPOption, reqIDGen func() uint16, reqOpts *dnsmessage.Resource) []*dnsRequest {
 	var reqs []*dnsRequest
-	now := time.Now()
 
 	if option.IPv4Enable {
-		msg := new(dnsmessage.Message)
-		msg.Header.ID = reqIDGen()
-		msg.Header.RecursionDesired = true
-		msg.Questions = []dnsmessage.Question{qA}
-		if reqOpts != nil {
-			msg.Additionals = append(msg.Additionals, *reqOpts)
-		}
-		reqs = append(reqs, &dnsRequest{
-			reqType: dnsmessage.TypeA,
-			domain:  domain,
-			start:   now,
-			msg:     msg,
-		})
+		reqs = append(reqs, buildReqMsg(domain, dnsmessage.TypeA, reqIDGen, reqOpts))
 	}
 
 	if option.IPv6Enable {
-		msg := new(dnsmessage.Message)
-		msg.Header.ID = reqIDGen()
-		msg.Header.RecursionDesired = true
-		msg.Questions = []dnsmessage.Question{qAAAA}
-		if reqOpts != nil {
-			msg.Additionals = append(msg.Additionals, *reqOpts)
-		}
-		reqs = append(reqs, &dnsRequest{
-			reqType: dnsmessage.TypeAAAA,
-			domain:  domain,
-			start:   now,
-			msg:     msg,
-		})
+		reqs = append(reqs, buildReqMsg(domain, dnsmessage.TypeAAAA, reqIDGen, reqOpts))
 	}
 
 	return reqs
 }
 
+func isIPQuery(qType dnsmessage.Type) bool {
+	return qType == dnsmessage.TypeA || qType == dnsmessage.TypeAAAA
+}
+
 // parseResponse parses DNS answers from the returned payload
 func parseResponse(payload []byte) (*IPRecord, error) {
 	var parser dnsmessage.Parser
@@ -230,6 +246,73 @@ L:
 	return ipRecord, nil
 }
 
+// parseRecordResponse parses DNS answers of any type from the returned payload
+func parseRecordResponse(payload []byte) (*RRRecord, error) {
+	var parser dnsmessage.Parser
+	h, err := parser.Start(payload)
+	if err != nil {
+		return nil, newError("failed to parse DNS response").Base(err).AtWarning()
+	}
+	if err := parser.SkipAllQuestions(); err != nil {
+		return nil, newError("failed to skip questions in DNS response").Base(err).AtWarning()
+	}
+	answers, err := parser.AllAnswers()
+	if err != nil {
+		return nil, newError("failed to parse answers in DNS response").Base(err).AtWarning()
+	}
+
+	now := time.Now()
+	rec := &RRRecord{
+		ReqID:   h.ID,
+		RCode:   h.RCode,
+		Answers: answers,
+		Expire:  now.Add(time.Second * 600),
+	}
+	for _, answer := range answers {
+		ttl := answer.Header.TTL
+		if ttl == 0 {
+			ttl = 600
+		}
+		if expire := now.Add(time.Duration(ttl) * time.Second); rec.Expire.After(expire) {
+			rec.Expire = expire
+		}
+	}
+	return rec, nil
+}
+
+// queryRecords implements Server.QueryRecords for the name servers that cache the answers. send sends the query of
+// the type, and the answer is published to fqdn+qType by the name server once it is cached.
+func queryRecords(ctx context.Context, name string, cache *Cache, pub *pubsub.Service, domain string, qType dnsmessage.Type, disableCache bool, send func(ctx context.Context, fqdn string)) ([]dnsmessage.Resource, error) {
+	fqdn := Fqdn(domain)
+
+	if disableCache {
+		newError("DNS cache is disabled. Querying ", qType, " for ", domain, " at ", name).AtDebug().WriteToLog()
+	} else {
+		answers, err := cache.getRecords(ctx, name, fqdn, qType, send)
+		if err != errRecordNotFound {
+			newError(name, " cache HIT ", domain, " ", qType, " -> ", len(answers), " answers").Base(err).AtDebug().WriteToLog()
+			return answers, err
+		}
+	}
+
+	sub := pub.Subscribe(fqdn + qType.String())
+	defer sub.Close()
+	send(ctx, fqdn)
+
+	for {
+		answers, _, err := cache.lookupRecords(name, fqdn, qType, false)
+		if err != errRecordNotFound {
+			return answers, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-sub.Wait():
+		}
+	}
+}
+
 // toDnsContext create a new background context with parent inbound, session and dns log
 func toDnsContext(ctx context.Context, addr string) context.Context {
 	dnsCtx := core.ToBackgroundDetachedContext(ctx)
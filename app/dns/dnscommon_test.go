@@ -95,6 +95,50 @@ func Test_parseResponse(t *testing.T) {
 	}
 }
 
+func Test_parseRecordResponse(t *testing.T) {
+	ans := new(dns.Msg)
+	ans.Id = 1
+	ans.Answer = append(ans.Answer,
+		common.Must2(dns.NewRR("example.com. 300 IN MX 10 mail.example.com.")).(dns.RR),
+		common.Must2(dns.NewRR("example.com. 60 IN MX 20 mail2.example.com.")).(dns.RR),
+	)
+	payload := common.Must2(ans.Pack()).([]byte)
+
+	rec, err := parseRecordResponse(payload)
+	common.Must(err)
+	if rec.ReqID != 1 || rec.RCode != dnsmessage.RCodeSuccess {
+		t.Error("unexpected header: ", rec.ReqID, " ", rec.RCode)
+	}
+	if len(rec.Answers) != 2 {
+		t.Fatal("len(answers): ", len(rec.Answers))
+	}
+	mx, ok := rec.Answers[1].Body.(*dnsmessage.MXResource)
+	if !ok {
+		t.Fatal("not MX record")
+	}
+	if mx.Pref != 20 || mx.MX.String() != "mail2.example.com." {
+		t.Error("unexpected MX record: ", mx.GoString())
+	}
+	if ttl := time.Until(rec.Expire); ttl > time.Minute || ttl < 50*time.Second {
+		t.Error("expect expire by min TTL, but got ", ttl)
+	}
+
+	answers, err := rec.getAnswers(time.Now())
+	common.Must(err)
+	if answers[0].Header.TTL > 60 {
+		t.Error("expect TTL counted down, but got ", answers[0].Header.TTL)
+	}
+
+	ans = new(dns.Msg)
+	ans.Id = 2
+	ans.Rcode = dns.RcodeNameError
+	rec, err = parseRecordResponse(common.Must2(ans.Pack()).([]byte))
+	common.Must(err)
+	if _, err := rec.getAnswers(time.Now()); dns_feature.RCodeFromError(err) != uint16(dnsmessage.RCodeNameError) {
+		t.Error("expect NXDOMAIN, but got ", err)
+	}
+}
+
 func Test_buildReqMsgs(t *testing.T) {
 	stubID := func() uint16 {
 		return uint16(rand.Uint32())
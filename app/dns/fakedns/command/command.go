@@ -0,0 +1,124 @@
+package command
+
+//go:generate go run github.com/xtls/xray-core/common/errors/errorgen
+
+import (
+	"context"
+
+	"github.com/xtls/xray-core/app/dns/fakedns"
+	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/common/net"
+	"github.com/xtls/xray-core/core"
+	"github.com/xtls/xray-core/features/dns"
+	grpc "google.golang.org/grpc"
+)
+
+// fakeDNSManager is implemented by the fake DNS engines that can be inspected and flushed.
+type fakeDNSManager interface {
+	GetDomainFromFakeDNS(ip net.Address) string
+	LookupFakeIP(domain string) []net.Address
+	Stats() []fakedns.PoolStats
+	Flush()
+}
+
+// fakeDNSServer is an implementation of FakeDNSService.
+type fakeDNSServer struct {
+	// engine returns the fake DNS engine in effect, or nil if fake DNS is not enabled.
+	engine func() dns.FakeDNSEngine
+}
+
+func NewFakeDNSServer(engine dns.FakeDNSEngine) FakeDNSServiceServer {
+	return &fakeDNSServer{
+		engine: func() dns.FakeDNSEngine {
+			return engine
+		},
+	}
+}
+
+func (s *fakeDNSServer) getManager() (fakeDNSManager, error) {
+	engine := s.engine()
+	if engine == nil {
+		return nil, newError("fake DNS is not enabled")
+	}
+	m, ok := engine.(fakeDNSManager)
+	if !ok {
+		return nil, newError("fake DNS engine does not support management")
+	}
+	return m, nil
+}
+
+func (s *fakeDNSServer) LookupDomain(ctx context.Context, request *LookupDomainRequest) (*LookupDomainResponse, error) {
+	m, err := s.getManager()
+	if err != nil {
+		return nil, err
+	}
+	ip := net.ParseAddress(request.Ip)
+	if !ip.Family().IsIP() {
+		return nil, newError("invalid IP: ", request.Ip)
+	}
+	return &LookupDomainResponse{
+		Domain: m.GetDomainFromFakeDNS(ip),
+	}, nil
+}
+
+func (s *fakeDNSServer) LookupIP(ctx context.Context, request *LookupIPRequest) (*LookupIPResponse, error) {
+	m, err := s.getManager()
+	if err != nil {
+		return nil, err
+	}
+	response := &LookupIPResponse{}
+	for _, ip := range m.LookupFakeIP(request.Domain) {
+		response.Ips = append(response.Ips, ip.String())
+	}
+	return response, nil
+}
+
+func (s *fakeDNSServer) GetPoolStats(ctx context.Context, request *GetPoolStatsRequest) (*GetPoolStatsResponse, error) {
+	m, err := s.getManager()
+	if err != nil {
+		return nil, err
+	}
+	response := &GetPoolStatsResponse{}
+	for _, stats := range m.Stats() {
+		response.Pools = append(response.Pools, &PoolStats{
+			IpPool: stats.IPPool,
+			Size:   int64(stats.Size),
+			Used:   int64(stats.Used),
+		})
+	}
+	return response, nil
+}
+
+func (s *fakeDNSServer) Flush(ctx context.Context, request *FlushRequest) (*FlushResponse, error) {
+	m, err := s.getManager()
+	if err != nil {
+		return nil, err
+	}
+	m.Flush()
+	return &FlushResponse{}, nil
+}
+
+func (s *fakeDNSServer) mustEmbedUnimplementedFakeDNSServiceServer() {}
+
+type service struct {
+	v *core.Instance
+}
+
+func (s *service) Register(server *grpc.Server) {
+	RegisterFakeDNSServiceServer(server, &fakeDNSServer{
+		engine: s.engine,
+	})
+}
+
+// engine looks up the fake DNS engine of the instance on each call, as fake DNS is optional and its feature may be
+// replaced after the service is registered.
+func (s *service) engine() dns.FakeDNSEngine {
+	engine, _ := s.v.GetFeature((*dns.FakeDNSEngine)(nil)).(dns.FakeDNSEngine)
+	return engine
+}
+
+func init() {
+	common.Must(common.RegisterConfig((*Config)(nil), func(ctx context.Context, cfg interface{}) (interface{}, error) {
+		return &service{v: core.MustFromContext(ctx)}, nil
+	}))
+}
@@ -0,0 +1,213 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.21.12
+// source: app/dns/fakedns/command/command.proto
+
+package command
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// FakeDNSServiceClient is the client API for FakeDNSService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type FakeDNSServiceClient interface {
+	LookupDomain(ctx context.Context, in *LookupDomainRequest, opts ...grpc.CallOption) (*LookupDomainResponse, error)
+	LookupIP(ctx context.Context, in *LookupIPRequest, opts ...grpc.CallOption) (*LookupIPResponse, error)
+	GetPoolStats(ctx context.Context, in *GetPoolStatsRequest, opts ...grpc.CallOption) (*GetPoolStatsResponse, error)
+	Flush(ctx context.Context, in *FlushRequest, opts ...grpc.CallOption) (*FlushResponse, error)
+}
+
+type fakeDNSServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewFakeDNSServiceClient(cc grpc.ClientConnInterface) FakeDNSServiceClient {
+	return &fakeDNSServiceClient{cc}
+}
+
+func (c *fakeDNSServiceClient) LookupDomain(ctx context.Context, in *LookupDomainRequest, opts ...grpc.CallOption) (*LookupDomainResponse, error) {
+	out := new(LookupDomainResponse)
+	err := c.cc.Invoke(ctx, "/xray.app.dns.fakedns.command.FakeDNSService/LookupDomain", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fakeDNSServiceClient) LookupIP(ctx context.Context, in *LookupIPRequest, opts ...grpc.CallOption) (*LookupIPResponse, error) {
+	out := new(LookupIPResponse)
+	err := c.cc.Invoke(ctx, "/xray.app.dns.fakedns.command.FakeDNSService/LookupIP", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fakeDNSServiceClient) GetPoolStats(ctx context.Context, in *GetPoolStatsRequest, opts ...grpc.CallOption) (*GetPoolStatsResponse, error) {
+	out := new(GetPoolStatsResponse)
+	err := c.cc.Invoke(ctx, "/xray.app.dns.fakedns.command.FakeDNSService/GetPoolStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fakeDNSServiceClient) Flush(ctx context.Context, in *FlushRequest, opts ...grpc.CallOption) (*FlushResponse, error) {
+	out := new(FlushResponse)
+	err := c.cc.Invoke(ctx, "/xray.app.dns.fakedns.command.FakeDNSService/Flush", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// FakeDNSServiceServer is the server API for FakeDNSService service.
+// All implementations must embed UnimplementedFakeDNSServiceServer
+// for forward compatibility
+type FakeDNSServiceServer interface {
+	LookupDomain(context.Context, *LookupDomainRequest) (*LookupDomainResponse, error)
+	LookupIP(context.Context, *LookupIPRequest) (*LookupIPResponse, error)
+	GetPoolStats(context.Context, *GetPoolStatsRequest) (*GetPoolStatsResponse, error)
+	Flush(context.Context, *FlushRequest) (*FlushResponse, error)
+	mustEmbedUnimplementedFakeDNSServiceServer()
+}
+
+// UnimplementedFakeDNSServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedFakeDNSServiceServer struct {
+}
+
+func (UnimplementedFakeDNSServiceServer) LookupDomain(context.Context, *LookupDomainRequest) (*LookupDomainResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LookupDomain not implemented")
+}
+func (UnimplementedFakeDNSServiceServer) LookupIP(context.Context, *LookupIPRequest) (*LookupIPResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LookupIP not implemented")
+}
+func (UnimplementedFakeDNSServiceServer) GetPoolStats(context.Context, *GetPoolStatsRequest) (*GetPoolStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPoolStats not implemented")
+}
+func (UnimplementedFakeDNSServiceServer) Flush(context.Context, *FlushRequest) (*FlushResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Flush not implemented")
+}
+func (UnimplementedFakeDNSServiceServer) mustEmbedUnimplementedFakeDNSServiceServer() {}
+
+// UnsafeFakeDNSServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to FakeDNSServiceServer will
+// result in compilation errors.
+type UnsafeFakeDNSServiceServer interface {
+	mustEmbedUnimplementedFakeDNSServiceServer()
+}
+
+func RegisterFakeDNSServiceServer(s grpc.ServiceRegistrar, srv FakeDNSServiceServer) {
+	s.RegisterService(&FakeDNSService_ServiceDesc, srv)
+}
+
+func _FakeDNSService_LookupDomain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LookupDomainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FakeDNSServiceServer).LookupDomain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/xray.app.dns.fakedns.command.FakeDNSService/LookupDomain",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FakeDNSServiceServer).LookupDomain(ctx, req.(*LookupDomainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FakeDNSService_LookupIP_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LookupIPRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FakeDNSServiceServer).LookupIP(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/xray.app.dns.fakedns.command.FakeDNSService/LookupIP",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FakeDNSServiceServer).LookupIP(ctx, req.(*LookupIPRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FakeDNSService_GetPoolStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPoolStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FakeDNSServiceServer).GetPoolStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/xray.app.dns.fakedns.command.FakeDNSService/GetPoolStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FakeDNSServiceServer).GetPoolStats(ctx, req.(*GetPoolStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FakeDNSService_Flush_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FlushRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FakeDNSServiceServer).Flush(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/xray.app.dns.fakedns.command.FakeDNSService/Flush",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FakeDNSServiceServer).Flush(ctx, req.(*FlushRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// FakeDNSService_ServiceDesc is the grpc.ServiceDesc for FakeDNSService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var FakeDNSService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "xray.app.dns.fakedns.command.FakeDNSService",
+	HandlerType: (*FakeDNSServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "LookupDomain",
+			Handler:    _FakeDNSService_LookupDomain_Handler,
+		},
+		{
+			MethodName: "LookupIP",
+			Handler:    _FakeDNSService_LookupIP_Handler,
+		},
+		{
+			MethodName: "GetPoolStats",
+			Handler:    _FakeDNSService_GetPoolStats_Handler,
+		},
+		{
+			MethodName: "Flush",
+			Handler:    _FakeDNSService_Flush_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "app/dns/fakedns/command/command.proto",
+}
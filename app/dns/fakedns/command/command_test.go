@@ -0,0 +1,57 @@
+package command_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/xtls/xray-core/app/dns/fakedns"
+	. "github.com/xtls/xray-core/app/dns/fakedns/command"
+	"github.com/xtls/xray-core/common"
+)
+
+func TestFakeDNSServer(t *testing.T) {
+	engine, err := fakedns.NewFakeDNSHolderMulti(&fakedns.FakeDnsPoolMulti{
+		Pools: []*fakedns.FakeDnsPool{{
+			IpPool:  "240.0.0.0/12",
+			LruSize: 256,
+		}},
+	})
+	common.Must(err)
+	common.Must(engine.Start())
+	defer engine.Close()
+
+	ip := engine.GetFakeIPForDomain("example.com")[0].String()
+	server := NewFakeDNSServer(engine)
+	ctx := context.Background()
+
+	domain, err := server.LookupDomain(ctx, &LookupDomainRequest{Ip: ip})
+	common.Must(err)
+	if domain.Domain != "example.com" {
+		t.Error("unexpected domain: ", domain.Domain)
+	}
+
+	ips, err := server.LookupIP(ctx, &LookupIPRequest{Domain: "example.com"})
+	common.Must(err)
+	if r := cmp.Diff(ips.Ips, []string{ip}); r != "" {
+		t.Error(r)
+	}
+
+	stats, err := server.GetPoolStats(ctx, &GetPoolStatsRequest{})
+	common.Must(err)
+	if len(stats.Pools) != 1 || stats.Pools[0].IpPool != "240.0.0.0/12" || stats.Pools[0].Size != 256 || stats.Pools[0].Used != 1 {
+		t.Error("unexpected stats: ", stats.Pools)
+	}
+
+	_, err = server.Flush(ctx, &FlushRequest{})
+	common.Must(err)
+	ips, err = server.LookupIP(ctx, &LookupIPRequest{Domain: "example.com"})
+	common.Must(err)
+	if len(ips.Ips) != 0 {
+		t.Error("expect no fake IP after flush, but got ", ips.Ips)
+	}
+
+	if _, err := NewFakeDNSServer(nil).LookupIP(ctx, &LookupIPRequest{Domain: "example.com"}); err == nil {
+		t.Error("expect error without fake DNS")
+	}
+}
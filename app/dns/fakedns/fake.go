@@ -2,15 +2,18 @@ package fakedns
 
 import (
 	"context"
+	"encoding/json"
 	"math"
 	"math/big"
 	gonet "net"
+	"os"
 	"sync"
 	"time"
 
 	"github.com/xtls/xray-core/common"
 	"github.com/xtls/xray-core/common/cache"
 	"github.com/xtls/xray-core/common/net"
+	"github.com/xtls/xray-core/common/task"
 	"github.com/xtls/xray-core/features/dns"
 )
 
@@ -18,10 +21,20 @@ type Holder struct {
 	domainToIP cache.Lru
 	ipRange    *gonet.IPNet
 	mu         *sync.Mutex
+	size       int
+	dirty      bool
+	saver      *task.Periodic
 
 	config *FakeDnsPool
 }
 
+// PoolStats is the usage of a fake IP pool.
+type PoolStats struct {
+	IPPool string
+	Size   int
+	Used   int
+}
+
 func (fkdns *Holder) IsIPInIPPool(ip net.Address) bool {
 	if ip.Family().IsDomain() {
 		return false
@@ -43,18 +56,109 @@ func (*Holder) Type() interface{} {
 
 func (fkdns *Holder) Start() error {
 	if fkdns.config != nil && fkdns.config.IpPool != "" && fkdns.config.LruSize != 0 {
-		return fkdns.initializeFromConfig()
+		if err := fkdns.initializeFromConfig(); err != nil {
+			return err
+		}
+		if fkdns.config.PersistFile != "" {
+			if err := fkdns.Load(); err != nil {
+				newError("failed to load fake DNS pool from ", fkdns.config.PersistFile).Base(err).AtWarning().WriteToLog()
+			}
+			fkdns.saver = &task.Periodic{
+				Interval: time.Minute,
+				Execute: func() error {
+					if err := fkdns.Save(); err != nil {
+						newError("failed to save fake DNS pool").Base(err).AtWarning().WriteToLog()
+					}
+					return nil
+				},
+			}
+			return fkdns.saver.Start()
+		}
+		return nil
 	}
 	return newError("invalid fakeDNS setting")
 }
 
 func (fkdns *Holder) Close() error {
+	if fkdns.saver != nil {
+		fkdns.saver.Close()
+		if err := fkdns.Save(); err != nil {
+			newError("failed to save fake DNS pool").Base(err).AtWarning().WriteToLog()
+		}
+	}
 	fkdns.domainToIP = nil
 	fkdns.ipRange = nil
 	fkdns.mu = nil
 	return nil
 }
 
+type persistedRecord struct {
+	Domain string `json:"domain"`
+	IP     string `json:"ip"`
+}
+
+// Save writes the domain to IP mapping to the persist file, if it has changed.
+func (fkdns *Holder) Save() error {
+	if fkdns.config == nil || fkdns.config.PersistFile == "" {
+		return nil
+	}
+
+	fkdns.mu.Lock()
+	if !fkdns.dirty {
+		fkdns.mu.Unlock()
+		return nil
+	}
+	records := make([]persistedRecord, 0, fkdns.domainToIP.Len())
+	fkdns.domainToIP.Range(func(key, value interface{}) bool {
+		records = append(records, persistedRecord{Domain: key.(string), IP: value.(net.Address).String()})
+		return true
+	})
+	fkdns.dirty = false
+	fkdns.mu.Unlock()
+
+	content, err := json.Marshal(records)
+	if err != nil {
+		return err
+	}
+	tmp := fkdns.config.PersistFile + ".tmp"
+	if err := os.WriteFile(tmp, content, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, fkdns.config.PersistFile)
+}
+
+// Load reads the domain to IP mapping from the persist file, skipping the IPs out of the pool.
+func (fkdns *Holder) Load() error {
+	content, err := os.ReadFile(fkdns.config.PersistFile)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var records []persistedRecord
+	if err := json.Unmarshal(content, &records); err != nil {
+		return err
+	}
+
+	count := 0
+	fkdns.mu.Lock()
+	for _, r := range records {
+		ip := net.ParseAddress(r.IP)
+		if !ip.Family().IsIP() || !fkdns.ipRange.Contains(ip.IP()) {
+			continue
+		}
+		if _, ok := fkdns.domainToIP.PeekKeyFromValue(ip); ok {
+			continue
+		}
+		fkdns.domainToIP.Put(r.Domain, ip)
+		count++
+	}
+	fkdns.mu.Unlock()
+	newError("loaded ", count, " fake IPs from ", fkdns.config.PersistFile).AtInfo().WriteToLog()
+	return nil
+}
+
 func NewFakeDNSHolder() (*Holder, error) {
 	var fkdns *Holder
 	var err error
@@ -70,7 +174,7 @@ func NewFakeDNSHolder() (*Holder, error) {
 }
 
 func NewFakeDNSHolderConfigOnly(conf *FakeDnsPool) (*Holder, error) {
-	return &Holder{nil, nil, nil, conf}, nil
+	return &Holder{config: conf}, nil
 }
 
 func (fkdns *Holder) initializeFromConfig() error {
@@ -91,6 +195,7 @@ func (fkdns *Holder) initialize(ipPoolCidr string, lruSize int) error {
 		return newError("LRU size is bigger than subnet size").AtError()
 	}
 	fkdns.domainToIP = cache.NewLru(lruSize)
+	fkdns.size = lruSize
 	fkdns.ipRange = ipRange
 	fkdns.mu = new(sync.Mutex)
 	return nil
@@ -126,9 +231,44 @@ func (fkdns *Holder) GetFakeIPForDomain(domain string) []net.Address {
 		}
 	}
 	fkdns.domainToIP.Put(domain, ip)
+	fkdns.dirty = true
 	return []net.Address{ip}
 }
 
+// LookupFakeIP returns the fake IP assigned to the domain, without assigning one if there is none.
+func (fkdns *Holder) LookupFakeIP(domain string) []net.Address {
+	if fkdns.domainToIP == nil {
+		return nil
+	}
+	if v, ok := fkdns.domainToIP.Peek(domain); ok {
+		return []net.Address{v.(net.Address)}
+	}
+	return nil
+}
+
+// Stats returns the usage of the pool, or nothing if the pool is not initialized.
+func (fkdns *Holder) Stats() []PoolStats {
+	if fkdns.domainToIP == nil {
+		return nil
+	}
+	return []PoolStats{{
+		IPPool: fkdns.ipRange.String(),
+		Size:   fkdns.size,
+		Used:   fkdns.domainToIP.Len(),
+	}}
+}
+
+// Flush forgets all the fake IPs assigned.
+func (fkdns *Holder) Flush() {
+	if fkdns.domainToIP == nil {
+		return
+	}
+	fkdns.mu.Lock()
+	fkdns.domainToIP.Clear()
+	fkdns.dirty = true
+	fkdns.mu.Unlock()
+}
+
 // GetDomainFromFakeDNS checks if an IP is a fake IP and have corresponding domain name
 func (fkdns *Holder) GetDomainFromFakeDNS(ip net.Address) string {
 	if !ip.Family().IsIP() || !fkdns.ipRange.Contains(ip.IP()) {
@@ -184,6 +324,28 @@ func (h *HolderMulti) GetDomainFromFakeDNS(ip net.Address) string {
 	return ""
 }
 
+func (h *HolderMulti) LookupFakeIP(domain string) []net.Address {
+	var ret []net.Address
+	for _, v := range h.holders {
+		ret = append(ret, v.LookupFakeIP(domain)...)
+	}
+	return ret
+}
+
+func (h *HolderMulti) Stats() []PoolStats {
+	var ret []PoolStats
+	for _, v := range h.holders {
+		ret = append(ret, v.Stats()...)
+	}
+	return ret
+}
+
+func (h *HolderMulti) Flush() {
+	for _, v := range h.holders {
+		v.Flush()
+	}
+}
+
 func (h *HolderMulti) Type() interface{} {
 	return (*dns.FakeDNSEngine)(nil)
 }
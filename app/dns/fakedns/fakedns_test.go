@@ -2,6 +2,7 @@ package fakedns
 
 import (
 	gonet "net"
+	"path/filepath"
 	"strconv"
 	"testing"
 
@@ -204,3 +205,43 @@ func TestFakeDNSMulti(t *testing.T) {
 		})
 	})
 }
+
+func TestFakeDNSPersistence(t *testing.T) {
+	config := &FakeDnsPool{
+		IpPool:      "240.0.0.0/12",
+		LruSize:     256,
+		PersistFile: filepath.Join(t.TempDir(), "fakedns.json"),
+	}
+
+	fkdns, err := NewFakeDNSHolderConfigOnly(config)
+	common.Must(err)
+	common.Must(fkdns.Start())
+	addr := fkdns.GetFakeIPForDomain("fakednstest.example.com")
+	fkdns.GetFakeIPForDomain("fakednstest2.example.com")
+	common.Must(fkdns.Close())
+
+	fkdns, err = NewFakeDNSHolderConfigOnly(config)
+	common.Must(err)
+	common.Must(fkdns.Start())
+	defer fkdns.Close()
+
+	assert.Equal(t, "fakednstest.example.com", fkdns.GetDomainFromFakeDNS(addr[0]))
+	assert.Equal(t, addr, fkdns.LookupFakeIP("fakednstest.example.com"))
+	assert.Equal(t, []PoolStats{{IPPool: "240.0.0.0/12", Size: 256, Used: 2}}, fkdns.Stats())
+
+	fkdns.Flush()
+	assert.Equal(t, "", fkdns.GetDomainFromFakeDNS(addr[0]))
+	assert.Nil(t, fkdns.LookupFakeIP("fakednstest.example.com"))
+}
+
+func TestFakeDNSHolderNotStarted(t *testing.T) {
+	fkdns, err := NewFakeDNSHolderConfigOnly(&FakeDnsPool{
+		IpPool:  dns.FakeIPv4Pool,
+		LruSize: 256,
+	})
+	common.Must(err)
+
+	assert.Empty(t, fkdns.Stats())
+	assert.Empty(t, fkdns.LookupFakeIP("fakednstest.example.com"))
+	fkdns.Flush()
+}
@@ -7,12 +7,15 @@ import (
 	"time"
 
 	"github.com/xtls/xray-core/app/router"
+	"github.com/xtls/xray-core/common"
 	"github.com/xtls/xray-core/common/errors"
 	"github.com/xtls/xray-core/common/net"
 	"github.com/xtls/xray-core/common/strmatcher"
 	"github.com/xtls/xray-core/core"
 	"github.com/xtls/xray-core/features/dns"
 	"github.com/xtls/xray-core/features/routing"
+	"github.com/xtls/xray-core/transport/internet/tls"
+	"golang.org/x/net/dns/dnsmessage"
 )
 
 // Server is the interface for Name Server.
@@ -21,21 +24,32 @@ type Server interface {
 	Name() string
 	// QueryIP sends IP queries to its configured server.
 	QueryIP(ctx context.Context, domain string, clientIP net.IP, option dns.IPOption, disableCache bool) ([]net.IP, error)
+	// QueryRecords sends a query of the type to its configured server.
+	QueryRecords(ctx context.Context, domain string, clientIP net.IP, qType dnsmessage.Type, disableCache bool) ([]dnsmessage.Resource, error)
 }
 
 // Client is the interface for DNS client.
 type Client struct {
-	server       Server
-	clientIP     net.IP
-	skipFallback bool
-	domains      []string
-	expectIPs    []*router.GeoIPMatcher
+	server           Server
+	clientIP         net.IP
+	skipFallback     bool
+	domains          []string
+	expectIPs        []*router.GeoIPMatcher
+	rejectUnexpected bool
+	queryStrategy    QueryStrategy
+	timeout          time.Duration
+	excludedDomains  *strmatcher.MatcherGroup
+	domainRuleSets   []*router.DomainRuleSet
 }
 
+// defaultQueryTimeout is the timeout of queries to name servers without their own.
+const defaultQueryTimeout = 4 * time.Second
+
 var errExpectedIPNonMatch = errors.New("expectIPs not match")
 
-// NewServer creates a name server object according to the network destination url.
-func NewServer(dest net.Destination, dispatcher routing.Dispatcher) (Server, error) {
+// NewServer creates a name server object according to the network destination url. tlsSettings is used by DNS over
+// TLS servers, and may be nil.
+func NewServer(dest net.Destination, dispatcher routing.Dispatcher, tlsSettings *tls.Config) (Server, error) {
 	if address := dest.Address; address.Family().IsDomain() {
 		u, err := url.Parse(address.Domain())
 		if err != nil {
@@ -54,6 +68,10 @@ func NewServer(dest net.Destination, dispatcher routing.Dispatcher) (Server, err
 			return NewTCPNameServer(u, dispatcher)
 		case strings.EqualFold(u.Scheme, "tcp+local"): // DNS-over-TCP Local mode
 			return NewTCPLocalNameServer(u)
+		case strings.EqualFold(u.Scheme, "tls"): // DNS-over-TLS Remote mode
+			return NewTLSNameServer(u, dispatcher, tlsSettings)
+		case strings.EqualFold(u.Scheme, "tls+local"): // DNS-over-TLS Local mode
+			return NewTLSLocalNameServer(u, tlsSettings)
 		case strings.EqualFold(u.String(), "fakedns"):
 			return NewFakeDNSServer(), nil
 		}
@@ -73,7 +91,7 @@ func NewClient(ctx context.Context, ns *NameServer, clientIP net.IP, container r
 
 	err := core.RequireFeatures(ctx, func(dispatcher routing.Dispatcher) error {
 		// Create a new server for each client for now
-		server, err := NewServer(ns.Address.AsDestination(), dispatcher)
+		server, err := NewServer(ns.Address.AsDestination(), dispatcher, ns.TlsSettings)
 		if err != nil {
 			return newError("failed to create nameserver").Base(err).AtWarning()
 		}
@@ -137,6 +155,29 @@ func NewClient(ctx context.Context, ns *NameServer, clientIP net.IP, container r
 			matchers = append(matchers, matcher)
 		}
 
+		// Establish excluded domains
+		var excludedDomains *strmatcher.MatcherGroup
+		for _, domain := range ns.ExcludedDomain {
+			domainRule, err := toStrMatcher(domain.Type, domain.Domain)
+			if err != nil {
+				return newError("failed to create excluded domain").Base(err).AtWarning()
+			}
+			if excludedDomains == nil {
+				excludedDomains = &strmatcher.MatcherGroup{}
+			}
+			excludedDomains.Add(domainRule)
+		}
+
+		// Establish domain rule sets
+		var domainRuleSets []*router.DomainRuleSet
+		for _, config := range ns.DomainRuleSet {
+			ruleSet, err := router.NewDomainRuleSet(config)
+			if err != nil {
+				return newError("failed to create domain rule set").Base(err).AtWarning()
+			}
+			domainRuleSets = append(domainRuleSets, ruleSet)
+		}
+
 		if len(clientIP) > 0 {
 			switch ns.Address.Address.GetAddress().(type) {
 			case *net.IPOrDomain_Domain:
@@ -151,6 +192,11 @@ func NewClient(ctx context.Context, ns *NameServer, clientIP net.IP, container r
 		client.skipFallback = ns.SkipFallback
 		client.domains = rules
 		client.expectIPs = matchers
+		client.rejectUnexpected = ns.RejectUnexpectedIps
+		client.queryStrategy = ns.QueryStrategy
+		client.timeout = time.Duration(ns.TimeoutMs) * time.Millisecond
+		client.excludedDomains = excludedDomains
+		client.domainRuleSets = domainRuleSets
 		return nil
 	})
 	return client, err
@@ -160,7 +206,7 @@ func NewClient(ctx context.Context, ns *NameServer, clientIP net.IP, container r
 func NewSimpleClient(ctx context.Context, endpoint *net.Endpoint, clientIP net.IP) (*Client, error) {
 	client := &Client{}
 	err := core.RequireFeatures(ctx, func(dispatcher routing.Dispatcher) error {
-		server, err := NewServer(endpoint.AsDestination(), dispatcher)
+		server, err := NewServer(endpoint.AsDestination(), dispatcher, nil)
 		if err != nil {
 			return newError("failed to create nameserver").Base(err).AtWarning()
 		}
@@ -186,9 +232,48 @@ func (c *Client) Name() string {
 	return c.server.Name()
 }
 
-// QueryIP sends DNS query to the name server with the client's IP.
+// Close closes the name server the client manages.
+func (c *Client) Close() error {
+	return common.Close(c.server)
+}
+
+// getTimeout returns the timeout of queries to the name server.
+func (c *Client) getTimeout() time.Duration {
+	if c.timeout > 0 {
+		return c.timeout
+	}
+	return defaultQueryTimeout
+}
+
+// isExcluded returns true if the domain is never resolved by the name server.
+func (c *Client) isExcluded(domain string) bool {
+	return c.excludedDomains != nil && len(c.excludedDomains.Match(domain)) > 0
+}
+
+// matchRuleSets returns true if the domain is in any of the domain rule sets of the client.
+func (c *Client) matchRuleSets(domain string) bool {
+	for _, ruleSet := range c.domainRuleSets {
+		if ruleSet.Match(domain) {
+			return true
+		}
+	}
+	return false
+}
+
+// QueryIP sends DNS query to the name server with the client's IP. IP versions disabled by the query strategy of the
+// client are not queried.
 func (c *Client) QueryIP(ctx context.Context, domain string, option dns.IPOption, disableCache bool) ([]net.IP, error) {
-	ctx, cancel := context.WithTimeout(ctx, 4*time.Second)
+	switch c.queryStrategy {
+	case QueryStrategy_USE_IP4:
+		option.IPv6Enable = false
+	case QueryStrategy_USE_IP6:
+		option.IPv4Enable = false
+	}
+	if !option.IPv4Enable && !option.IPv6Enable {
+		return nil, dns.ErrEmptyResponse
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.getTimeout())
 	ips, err := c.server.QueryIP(ctx, domain, c.clientIP, option, disableCache)
 	cancel()
 
@@ -198,19 +283,33 @@ func (c *Client) QueryIP(ctx context.Context, domain string, option dns.IPOption
 	return c.MatchExpectedIPs(domain, ips)
 }
 
-// MatchExpectedIPs matches queried domain IPs with expected IPs and returns matched ones.
+// QueryRecords sends DNS query of the type to the name server with the client's IP.
+func (c *Client) QueryRecords(ctx context.Context, domain string, qType dnsmessage.Type, disableCache bool) ([]dnsmessage.Resource, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.getTimeout())
+	defer cancel()
+	return c.server.QueryRecords(ctx, domain, c.clientIP, qType, disableCache)
+}
+
+// MatchExpectedIPs matches queried domain IPs with expected IPs and returns matched ones. If the client rejects
+// unexpected IPs, the IPs are returned only if all of them are matched.
 func (c *Client) MatchExpectedIPs(domain string, ips []net.IP) ([]net.IP, error) {
 	if len(c.expectIPs) == 0 {
 		return ips, nil
 	}
 	newIps := []net.IP{}
 	for _, ip := range ips {
+		matched := false
 		for _, matcher := range c.expectIPs {
 			if matcher.Match(ip) {
 				newIps = append(newIps, ip)
+				matched = true
 				break
 			}
 		}
+		if !matched && c.rejectUnexpected {
+			newError("domain ", domain, " answer ", ip, " rejected at server ", c.Name()).AtDebug().WriteToLog()
+			return nil, errExpectedIPNonMatch
+		}
 	}
 	if len(newIps) == 0 {
 		return nil, errExpectedIPNonMatch
@@ -18,7 +18,6 @@ import (
 	"github.com/xtls/xray-core/common/protocol/dns"
 	"github.com/xtls/xray-core/common/session"
 	"github.com/xtls/xray-core/common/signal/pubsub"
-	"github.com/xtls/xray-core/common/task"
 	dns_feature "github.com/xtls/xray-core/features/dns"
 	"github.com/xtls/xray-core/features/routing"
 	"github.com/xtls/xray-core/transport/internet"
@@ -31,9 +30,8 @@ import (
 type DoHNameServer struct {
 	dispatcher routing.Dispatcher
 	sync.RWMutex
-	ips        map[string]*record
+	cache      *Cache
 	pub        *pubsub.Service
-	cleanup    *task.Periodic
 	reqID      uint32
 	httpClient *http.Client
 	dohURL     string
@@ -124,15 +122,11 @@ func NewDoHLocalNameServer(url *url.URL) *DoHNameServer {
 
 func baseDOHNameServer(url *url.URL, prefix string) *DoHNameServer {
 	s := &DoHNameServer{
-		ips:    make(map[string]*record),
+		cache:  NewCache(0, false, ""),
 		pub:    pubsub.NewService(),
 		name:   prefix + "//" + url.Host,
 		dohURL: url.String(),
 	}
-	s.cleanup = &task.Periodic{
-		Interval: time.Minute,
-		Execute:  s.Cleanup,
-	}
 	return s
 }
 
@@ -141,55 +135,24 @@ func (s *DoHNameServer) Name() string {
 	return s.name
 }
 
-// Cleanup clears expired items from cache
-func (s *DoHNameServer) Cleanup() error {
-	now := time.Now()
-	s.Lock()
-	defer s.Unlock()
-
-	if len(s.ips) == 0 {
-		return newError("nothing to do. stopping...")
-	}
-
-	for domain, record := range s.ips {
-		if record.A != nil && record.A.Expire.Before(now) {
-			record.A = nil
-		}
-		if record.AAAA != nil && record.AAAA.Expire.Before(now) {
-			record.AAAA = nil
-		}
-
-		if record.A == nil && record.AAAA == nil {
-			newError(s.name, " cleanup ", domain).AtDebug().WriteToLog()
-			delete(s.ips, domain)
-		} else {
-			s.ips[domain] = record
-		}
-	}
-
-	if len(s.ips) == 0 {
-		s.ips = make(map[string]*record)
-	}
-
+// Close implements common.Closable.
+func (s *DoHNameServer) Close() error {
+	s.httpClient.CloseIdleConnections()
 	return nil
 }
 
+// setCache implements cacheSetter.
+func (s *DoHNameServer) setCache(cache *Cache) {
+	s.cache = cache
+}
+
 func (s *DoHNameServer) updateIP(req *dnsRequest, ipRec *IPRecord) {
 	elapsed := time.Since(req.start)
 
-	s.Lock()
-	rec, found := s.ips[req.domain]
-	if !found {
-		rec = &record{}
-	}
-	updated := false
-
+	var rec record
 	switch req.reqType {
 	case dnsmessage.TypeA:
-		if isNewer(rec.A, ipRec) {
-			rec.A = ipRec
-			updated = true
-		}
+		rec.A = ipRec
 	case dnsmessage.TypeAAAA:
 		addr := make([]net.Address, 0, len(ipRec.IP))
 		for _, ip := range ipRec.IP {
@@ -198,24 +161,24 @@ func (s *DoHNameServer) updateIP(req *dnsRequest, ipRec *IPRecord) {
 			}
 		}
 		ipRec.IP = addr
-		if isNewer(rec.AAAA, ipRec) {
-			rec.AAAA = ipRec
-			updated = true
-		}
+		rec.AAAA = ipRec
 	}
 	newError(s.name, " got answer: ", req.domain, " ", req.reqType, " -> ", ipRec.IP, " ", elapsed).AtInfo().WriteToLog()
 
-	if updated {
-		s.ips[req.domain] = rec
-	}
+	s.cache.update(s.name, req.domain, &rec)
 	switch req.reqType {
 	case dnsmessage.TypeA:
 		s.pub.Publish(req.domain+"4", nil)
 	case dnsmessage.TypeAAAA:
 		s.pub.Publish(req.domain+"6", nil)
 	}
-	s.Unlock()
-	common.Must(s.cleanup.Start())
+}
+
+func (s *DoHNameServer) updateRecords(req *dnsRequest, rec *RRRecord) {
+	elapsed := time.Since(req.start)
+	newError(s.name, " got answer: ", req.domain, " ", req.reqType, " -> ", len(rec.Answers), " answers ", elapsed).AtInfo().WriteToLog()
+	s.cache.updateRecords(s.name, req.domain, req.reqType, rec)
+	s.pub.Publish(req.domain+req.reqType.String(), nil)
 }
 
 func (s *DoHNameServer) newReqID() uint16 {
@@ -223,6 +186,10 @@ func (s *DoHNameServer) newReqID() uint16 {
 }
 
 func (s *DoHNameServer) sendQuery(ctx context.Context, domain string, clientIP net.IP, option dns_feature.IPOption) {
+	s.sendRequests(ctx, domain, buildReqMsgs(domain, option, s.newReqID, genEDNS0Options(clientIP)))
+}
+
+func (s *DoHNameServer) sendRequests(ctx context.Context, domain string, reqs []*dnsRequest) {
 	newError(s.name, " querying: ", domain).AtInfo().WriteToLog(session.ExportIDToError(ctx))
 
 	if s.name+"." == "DOH//"+domain {
@@ -230,8 +197,6 @@ func (s *DoHNameServer) sendQuery(ctx context.Context, domain string, clientIP n
 		return
 	}
 
-	reqs := buildReqMsgs(domain, option, s.newReqID, genEDNS0Options(clientIP))
-
 	var deadline time.Time
 	if d, ok := ctx.Deadline(); ok {
 		deadline = d
@@ -272,6 +237,16 @@ func (s *DoHNameServer) sendQuery(ctx context.Context, domain string, clientIP n
 				newError("failed to retrieve response for ", domain).Base(err).AtError().WriteToLog()
 				return
 			}
+			if !isIPQuery(r.reqType) {
+				rec, err := parseRecordResponse(resp)
+				if err != nil {
+					newError("failed to parse response for ", domain).Base(err).AtError().WriteToLog()
+					return
+				}
+				s.updateRecords(r, rec)
+				return
+			}
+
 			rec, err := parseResponse(resp)
 			if err != nil {
 				newError("failed to handle DOH response for ", domain).Base(err).AtError().WriteToLog()
@@ -309,45 +284,15 @@ func (s *DoHNameServer) dohHTTPSContext(ctx context.Context, b []byte) ([]byte,
 }
 
 func (s *DoHNameServer) findIPsForDomain(domain string, option dns_feature.IPOption) ([]net.IP, error) {
-	s.RLock()
-	record, found := s.ips[domain]
-	s.RUnlock()
-
-	if !found {
-		return nil, errRecordNotFound
-	}
-
-	var err4 error
-	var err6 error
-	var ips []net.Address
-	var ip6 []net.Address
-
-	if option.IPv4Enable {
-		ips, err4 = record.A.getIPs()
-	}
-
-	if option.IPv6Enable {
-		ip6, err6 = record.AAAA.getIPs()
-		ips = append(ips, ip6...)
-	}
-
-	if len(ips) > 0 {
-		return toNetIP(ips)
-	}
-
-	if err4 != nil {
-		return nil, err4
-	}
-
-	if err6 != nil {
-		return nil, err6
-	}
-
-	if (option.IPv4Enable && record.A != nil) || (option.IPv6Enable && record.AAAA != nil) {
-		return nil, dns_feature.ErrEmptyResponse
-	}
+	ips, _, err := s.cache.lookup(s.name, domain, option, false)
+	return ips, err
+}
 
-	return nil, errRecordNotFound
+// QueryRecords implements Server.
+func (s *DoHNameServer) QueryRecords(ctx context.Context, domain string, clientIP net.IP, qType dnsmessage.Type, disableCache bool) ([]dnsmessage.Resource, error) {
+	return queryRecords(ctx, s.name, s.cache, s.pub, domain, qType, disableCache, func(ctx context.Context, fqdn string) {
+		s.sendRequests(ctx, fqdn, []*dnsRequest{buildReqMsg(fqdn, qType, s.newReqID, genEDNS0Options(clientIP))})
+	})
 }
 
 // QueryIP implements Server.
@@ -357,7 +302,9 @@ func (s *DoHNameServer) QueryIP(ctx context.Context, domain string, clientIP net
 	if disableCache {
 		newError("DNS cache is disabled. Querying IP for ", domain, " at ", s.name).AtDebug().WriteToLog()
 	} else {
-		ips, err := s.findIPsForDomain(fqdn, option)
+		ips, err := s.cache.get(ctx, s.name, fqdn, option, func(ctx context.Context) {
+			s.sendQuery(ctx, fqdn, clientIP, option)
+		})
 		if err != errRecordNotFound {
 			newError(s.name, " cache HIT ", domain, " -> ", ips).Base(err).AtDebug().WriteToLog()
 			log.Record(&log.DNSLog{Server: s.name, Domain: domain, Result: ips, Status: log.DNSCacheHit, Elapsed: 0, Error: err})
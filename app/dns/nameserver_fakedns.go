@@ -6,6 +6,7 @@ import (
 	"github.com/xtls/xray-core/common/net"
 	"github.com/xtls/xray-core/core"
 	"github.com/xtls/xray-core/features/dns"
+	"golang.org/x/net/dns/dnsmessage"
 )
 
 type FakeDNSServer struct {
@@ -47,3 +48,10 @@ func (f *FakeDNSServer) QueryIP(ctx context.Context, domain string, _ net.IP, op
 	}
 	return nil, dns.ErrEmptyResponse
 }
+
+// QueryRecords implements Server. FakeDNS has no records other than A and AAAA, so that the records such as HTTPS
+// don't reveal the real IPs of the domains.
+func (f *FakeDNSServer) QueryRecords(_ context.Context, domain string, _ net.IP, qType dnsmessage.Type, _ bool) ([]dnsmessage.Resource, error) {
+	newError(f.Name(), " got empty answer: ", domain, " ", qType).AtInfo().WriteToLog()
+	return nil, dns.ErrEmptyResponse
+}
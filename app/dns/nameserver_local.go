@@ -9,6 +9,7 @@ import (
 	"github.com/xtls/xray-core/common/net"
 	"github.com/xtls/xray-core/features/dns"
 	"github.com/xtls/xray-core/features/dns/localdns"
+	"golang.org/x/net/dns/dnsmessage"
 )
 
 // LocalNameServer is an wrapper over local DNS feature.
@@ -35,6 +36,18 @@ func (s *LocalNameServer) QueryIP(_ context.Context, domain string, _ net.IP, op
 	return
 }
 
+// QueryRecords implements Server.
+func (s *LocalNameServer) QueryRecords(ctx context.Context, domain string, _ net.IP, qType dnsmessage.Type, _ bool) ([]dnsmessage.Resource, error) {
+	answers, err := s.client.Query(ctx, domain, qType)
+	if err != nil && strings.HasSuffix(err.Error(), errEmptyResponse) {
+		err = dns.ErrEmptyResponse
+	}
+	if len(answers) > 0 {
+		newError("Localhost got answer: ", domain, " ", qType, " -> ", len(answers), " answers").AtInfo().WriteToLog()
+	}
+	return answers, err
+}
+
 // Name implements Server.
 func (s *LocalNameServer) Name() string {
 	return "localhost"
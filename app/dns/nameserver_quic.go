@@ -8,14 +8,12 @@ import (
 	"time"
 
 	"github.com/quic-go/quic-go"
-	"github.com/xtls/xray-core/common"
 	"github.com/xtls/xray-core/common/buf"
 	"github.com/xtls/xray-core/common/log"
 	"github.com/xtls/xray-core/common/net"
 	"github.com/xtls/xray-core/common/protocol/dns"
 	"github.com/xtls/xray-core/common/session"
 	"github.com/xtls/xray-core/common/signal/pubsub"
-	"github.com/xtls/xray-core/common/task"
 	dns_feature "github.com/xtls/xray-core/features/dns"
 	"github.com/xtls/xray-core/transport/internet/tls"
 	"golang.org/x/net/dns/dnsmessage"
@@ -31,9 +29,8 @@ const handshakeTimeout = time.Second * 8
 // QUICNameServer implemented DNS over QUIC
 type QUICNameServer struct {
 	sync.RWMutex
-	ips         map[string]*record
+	cache       *Cache
 	pub         *pubsub.Service
-	cleanup     *task.Periodic
 	reqID       uint32
 	name        string
 	destination *net.Destination
@@ -55,15 +52,11 @@ func NewQUICNameServer(url *url.URL) (*QUICNameServer, error) {
 	dest := net.UDPDestination(net.ParseAddress(url.Hostname()), port)
 
 	s := &QUICNameServer{
-		ips:         make(map[string]*record),
+		cache:       NewCache(0, false, ""),
 		pub:         pubsub.NewService(),
 		name:        url.String(),
 		destination: &dest,
 	}
-	s.cleanup = &task.Periodic{
-		Interval: time.Minute,
-		Execute:  s.Cleanup,
-	}
 
 	return s, nil
 }
@@ -73,55 +66,18 @@ func (s *QUICNameServer) Name() string {
 	return s.name
 }
 
-// Cleanup clears expired items from cache
-func (s *QUICNameServer) Cleanup() error {
-	now := time.Now()
-	s.Lock()
-	defer s.Unlock()
-
-	if len(s.ips) == 0 {
-		return newError("nothing to do. stopping...")
-	}
-
-	for domain, record := range s.ips {
-		if record.A != nil && record.A.Expire.Before(now) {
-			record.A = nil
-		}
-		if record.AAAA != nil && record.AAAA.Expire.Before(now) {
-			record.AAAA = nil
-		}
-
-		if record.A == nil && record.AAAA == nil {
-			newError(s.name, " cleanup ", domain).AtDebug().WriteToLog()
-			delete(s.ips, domain)
-		} else {
-			s.ips[domain] = record
-		}
-	}
-
-	if len(s.ips) == 0 {
-		s.ips = make(map[string]*record)
-	}
-
-	return nil
+// setCache implements cacheSetter.
+func (s *QUICNameServer) setCache(cache *Cache) {
+	s.cache = cache
 }
 
 func (s *QUICNameServer) updateIP(req *dnsRequest, ipRec *IPRecord) {
 	elapsed := time.Since(req.start)
 
-	s.Lock()
-	rec, found := s.ips[req.domain]
-	if !found {
-		rec = &record{}
-	}
-	updated := false
-
+	var rec record
 	switch req.reqType {
 	case dnsmessage.TypeA:
-		if isNewer(rec.A, ipRec) {
-			rec.A = ipRec
-			updated = true
-		}
+		rec.A = ipRec
 	case dnsmessage.TypeAAAA:
 		addr := make([]net.Address, 0)
 		for _, ip := range ipRec.IP {
@@ -130,24 +86,24 @@ func (s *QUICNameServer) updateIP(req *dnsRequest, ipRec *IPRecord) {
 			}
 		}
 		ipRec.IP = addr
-		if isNewer(rec.AAAA, ipRec) {
-			rec.AAAA = ipRec
-			updated = true
-		}
+		rec.AAAA = ipRec
 	}
 	newError(s.name, " got answer: ", req.domain, " ", req.reqType, " -> ", ipRec.IP, " ", elapsed).AtInfo().WriteToLog()
 
-	if updated {
-		s.ips[req.domain] = rec
-	}
+	s.cache.update(s.name, req.domain, &rec)
 	switch req.reqType {
 	case dnsmessage.TypeA:
 		s.pub.Publish(req.domain+"4", nil)
 	case dnsmessage.TypeAAAA:
 		s.pub.Publish(req.domain+"6", nil)
 	}
-	s.Unlock()
-	common.Must(s.cleanup.Start())
+}
+
+func (s *QUICNameServer) updateRecords(req *dnsRequest, rec *RRRecord) {
+	elapsed := time.Since(req.start)
+	newError(s.name, " got answer: ", req.domain, " ", req.reqType, " -> ", len(rec.Answers), " answers ", elapsed).AtInfo().WriteToLog()
+	s.cache.updateRecords(s.name, req.domain, req.reqType, rec)
+	s.pub.Publish(req.domain+req.reqType.String(), nil)
 }
 
 func (s *QUICNameServer) newReqID() uint16 {
@@ -155,9 +111,11 @@ func (s *QUICNameServer) newReqID() uint16 {
 }
 
 func (s *QUICNameServer) sendQuery(ctx context.Context, domain string, clientIP net.IP, option dns_feature.IPOption) {
-	newError(s.name, " querying: ", domain).AtInfo().WriteToLog(session.ExportIDToError(ctx))
+	s.sendRequests(ctx, domain, buildReqMsgs(domain, option, s.newReqID, genEDNS0Options(clientIP)))
+}
 
-	reqs := buildReqMsgs(domain, option, s.newReqID, genEDNS0Options(clientIP))
+func (s *QUICNameServer) sendRequests(ctx context.Context, domain string, reqs []*dnsRequest) {
+	newError(s.name, " querying: ", domain).AtInfo().WriteToLog(session.ExportIDToError(ctx))
 
 	var deadline time.Time
 	if d, ok := ctx.Deadline(); ok {
@@ -214,6 +172,16 @@ func (s *QUICNameServer) sendQuery(ctx context.Context, domain string, clientIP
 				return
 			}
 
+			if !isIPQuery(r.reqType) {
+				rec, err := parseRecordResponse(respBuf.Bytes())
+				if err != nil {
+					newError("failed to parse response for ", domain).Base(err).AtError().WriteToLog()
+					return
+				}
+				s.updateRecords(r, rec)
+				return
+			}
+
 			rec, err := parseResponse(respBuf.Bytes())
 			if err != nil {
 				newError("failed to handle response").Base(err).AtError().WriteToLog()
@@ -225,45 +193,15 @@ func (s *QUICNameServer) sendQuery(ctx context.Context, domain string, clientIP
 }
 
 func (s *QUICNameServer) findIPsForDomain(domain string, option dns_feature.IPOption) ([]net.IP, error) {
-	s.RLock()
-	record, found := s.ips[domain]
-	s.RUnlock()
-
-	if !found {
-		return nil, errRecordNotFound
-	}
-
-	var err4 error
-	var err6 error
-	var ips []net.Address
-	var ip6 []net.Address
-
-	if option.IPv4Enable {
-		ips, err4 = record.A.getIPs()
-	}
-
-	if option.IPv6Enable {
-		ip6, err6 = record.AAAA.getIPs()
-		ips = append(ips, ip6...)
-	}
-
-	if len(ips) > 0 {
-		return toNetIP(ips)
-	}
-
-	if err4 != nil {
-		return nil, err4
-	}
-
-	if err6 != nil {
-		return nil, err6
-	}
-
-	if (option.IPv4Enable && record.A != nil) || (option.IPv6Enable && record.AAAA != nil) {
-		return nil, dns_feature.ErrEmptyResponse
-	}
+	ips, _, err := s.cache.lookup(s.name, domain, option, false)
+	return ips, err
+}
 
-	return nil, errRecordNotFound
+// QueryRecords implements Server.
+func (s *QUICNameServer) QueryRecords(ctx context.Context, domain string, clientIP net.IP, qType dnsmessage.Type, disableCache bool) ([]dnsmessage.Resource, error) {
+	return queryRecords(ctx, s.name, s.cache, s.pub, domain, qType, disableCache, func(ctx context.Context, fqdn string) {
+		s.sendRequests(ctx, fqdn, []*dnsRequest{buildReqMsg(fqdn, qType, s.newReqID, genEDNS0Options(clientIP))})
+	})
 }
 
 // QueryIP is called from dns.Server->queryIPTimeout
@@ -273,7 +211,9 @@ func (s *QUICNameServer) QueryIP(ctx context.Context, domain string, clientIP ne
 	if disableCache {
 		newError("DNS cache is disabled. Querying IP for ", domain, " at ", s.name).AtDebug().WriteToLog()
 	} else {
-		ips, err := s.findIPsForDomain(fqdn, option)
+		ips, err := s.cache.get(ctx, s.name, fqdn, option, func(ctx context.Context) {
+			s.sendQuery(ctx, fqdn, clientIP, option)
+		})
 		if err != errRecordNotFound {
 			newError(s.name, " cache HIT ", domain, " -> ", ips).Base(err).AtDebug().WriteToLog()
 			log.Record(&log.DNSLog{Server: s.name, Domain: domain, Result: ips, Status: log.DNSCacheHit, Elapsed: 0, Error: err})
@@ -334,6 +274,18 @@ func isActive(s quic.Connection) bool {
 	}
 }
 
+// Close implements common.Closable.
+func (s *QUICNameServer) Close() error {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.connection != nil {
+		_ = s.connection.CloseWithError(0, "")
+		s.connection = nil
+	}
+	return nil
+}
+
 func (s *QUICNameServer) getConnection() (quic.Connection, error) {
 	var conn quic.Connection
 	s.RLock()
@@ -9,7 +9,6 @@ import (
 	"sync/atomic"
 	"time"
 
-	"github.com/xtls/xray-core/common"
 	"github.com/xtls/xray-core/common/buf"
 	"github.com/xtls/xray-core/common/log"
 	"github.com/xtls/xray-core/common/net"
@@ -17,7 +16,6 @@ import (
 	"github.com/xtls/xray-core/common/protocol/dns"
 	"github.com/xtls/xray-core/common/session"
 	"github.com/xtls/xray-core/common/signal/pubsub"
-	"github.com/xtls/xray-core/common/task"
 	dns_feature "github.com/xtls/xray-core/features/dns"
 	"github.com/xtls/xray-core/features/routing"
 	"github.com/xtls/xray-core/transport/internet"
@@ -29,9 +27,8 @@ type TCPNameServer struct {
 	sync.RWMutex
 	name        string
 	destination *net.Destination
-	ips         map[string]*record
+	cache       *Cache
 	pub         *pubsub.Service
-	cleanup     *task.Periodic
 	reqID       uint32
 	dial        func(context.Context) (net.Conn, error)
 }
@@ -85,14 +82,10 @@ func baseTCPNameServer(url *url.URL, prefix string) (*TCPNameServer, error) {
 
 	s := &TCPNameServer{
 		destination: &dest,
-		ips:         make(map[string]*record),
+		cache:       NewCache(0, false, ""),
 		pub:         pubsub.NewService(),
 		name:        prefix + "//" + dest.NetAddr(),
 	}
-	s.cleanup = &task.Periodic{
-		Interval: time.Minute,
-		Execute:  s.Cleanup,
-	}
 
 	return s, nil
 }
@@ -102,55 +95,18 @@ func (s *TCPNameServer) Name() string {
 	return s.name
 }
 
-// Cleanup clears expired items from cache
-func (s *TCPNameServer) Cleanup() error {
-	now := time.Now()
-	s.Lock()
-	defer s.Unlock()
-
-	if len(s.ips) == 0 {
-		return newError("nothing to do. stopping...")
-	}
-
-	for domain, record := range s.ips {
-		if record.A != nil && record.A.Expire.Before(now) {
-			record.A = nil
-		}
-		if record.AAAA != nil && record.AAAA.Expire.Before(now) {
-			record.AAAA = nil
-		}
-
-		if record.A == nil && record.AAAA == nil {
-			newError(s.name, " cleanup ", domain).AtDebug().WriteToLog()
-			delete(s.ips, domain)
-		} else {
-			s.ips[domain] = record
-		}
-	}
-
-	if len(s.ips) == 0 {
-		s.ips = make(map[string]*record)
-	}
-
-	return nil
+// setCache implements cacheSetter.
+func (s *TCPNameServer) setCache(cache *Cache) {
+	s.cache = cache
 }
 
 func (s *TCPNameServer) updateIP(req *dnsRequest, ipRec *IPRecord) {
 	elapsed := time.Since(req.start)
 
-	s.Lock()
-	rec, found := s.ips[req.domain]
-	if !found {
-		rec = &record{}
-	}
-	updated := false
-
+	var rec record
 	switch req.reqType {
 	case dnsmessage.TypeA:
-		if isNewer(rec.A, ipRec) {
-			rec.A = ipRec
-			updated = true
-		}
+		rec.A = ipRec
 	case dnsmessage.TypeAAAA:
 		addr := make([]net.Address, 0)
 		for _, ip := range ipRec.IP {
@@ -159,24 +115,24 @@ func (s *TCPNameServer) updateIP(req *dnsRequest, ipRec *IPRecord) {
 			}
 		}
 		ipRec.IP = addr
-		if isNewer(rec.AAAA, ipRec) {
-			rec.AAAA = ipRec
-			updated = true
-		}
+		rec.AAAA = ipRec
 	}
 	newError(s.name, " got answer: ", req.domain, " ", req.reqType, " -> ", ipRec.IP, " ", elapsed).AtInfo().WriteToLog()
 
-	if updated {
-		s.ips[req.domain] = rec
-	}
+	s.cache.update(s.name, req.domain, &rec)
 	switch req.reqType {
 	case dnsmessage.TypeA:
 		s.pub.Publish(req.domain+"4", nil)
 	case dnsmessage.TypeAAAA:
 		s.pub.Publish(req.domain+"6", nil)
 	}
-	s.Unlock()
-	common.Must(s.cleanup.Start())
+}
+
+func (s *TCPNameServer) updateRecords(req *dnsRequest, rec *RRRecord) {
+	elapsed := time.Since(req.start)
+	newError(s.name, " got answer: ", req.domain, " ", req.reqType, " -> ", len(rec.Answers), " answers ", elapsed).AtInfo().WriteToLog()
+	s.cache.updateRecords(s.name, req.domain, req.reqType, rec)
+	s.pub.Publish(req.domain+req.reqType.String(), nil)
 }
 
 func (s *TCPNameServer) newReqID() uint16 {
@@ -184,9 +140,11 @@ func (s *TCPNameServer) newReqID() uint16 {
 }
 
 func (s *TCPNameServer) sendQuery(ctx context.Context, domain string, clientIP net.IP, option dns_feature.IPOption) {
-	newError(s.name, " querying DNS for: ", domain).AtDebug().WriteToLog(session.ExportIDToError(ctx))
+	s.sendRequests(ctx, domain, buildReqMsgs(domain, option, s.newReqID, genEDNS0Options(clientIP)))
+}
 
-	reqs := buildReqMsgs(domain, option, s.newReqID, genEDNS0Options(clientIP))
+func (s *TCPNameServer) sendRequests(ctx context.Context, domain string, reqs []*dnsRequest) {
+	newError(s.name, " querying DNS for: ", domain).AtDebug().WriteToLog(session.ExportIDToError(ctx))
 
 	var deadline time.Time
 	if d, ok := ctx.Deadline(); ok {
@@ -256,6 +214,16 @@ func (s *TCPNameServer) sendQuery(ctx context.Context, domain string, clientIP n
 				return
 			}
 
+			if !isIPQuery(r.reqType) {
+				rec, err := parseRecordResponse(respBuf.Bytes())
+				if err != nil {
+					newError("failed to parse response for ", domain).Base(err).AtError().WriteToLog()
+					return
+				}
+				s.updateRecords(r, rec)
+				return
+			}
+
 			rec, err := parseResponse(respBuf.Bytes())
 			if err != nil {
 				newError("failed to parse DNS over TCP response").Base(err).AtError().WriteToLog()
@@ -268,41 +236,15 @@ func (s *TCPNameServer) sendQuery(ctx context.Context, domain string, clientIP n
 }
 
 func (s *TCPNameServer) findIPsForDomain(domain string, option dns_feature.IPOption) ([]net.IP, error) {
-	s.RLock()
-	record, found := s.ips[domain]
-	s.RUnlock()
-
-	if !found {
-		return nil, errRecordNotFound
-	}
-
-	var err4 error
-	var err6 error
-	var ips []net.Address
-	var ip6 []net.Address
-
-	if option.IPv4Enable {
-		ips, err4 = record.A.getIPs()
-	}
-
-	if option.IPv6Enable {
-		ip6, err6 = record.AAAA.getIPs()
-		ips = append(ips, ip6...)
-	}
-
-	if len(ips) > 0 {
-		return toNetIP(ips)
-	}
-
-	if err4 != nil {
-		return nil, err4
-	}
-
-	if err6 != nil {
-		return nil, err6
-	}
+	ips, _, err := s.cache.lookup(s.name, domain, option, false)
+	return ips, err
+}
 
-	return nil, dns_feature.ErrEmptyResponse
+// QueryRecords implements Server.
+func (s *TCPNameServer) QueryRecords(ctx context.Context, domain string, clientIP net.IP, qType dnsmessage.Type, disableCache bool) ([]dnsmessage.Resource, error) {
+	return queryRecords(ctx, s.name, s.cache, s.pub, domain, qType, disableCache, func(ctx context.Context, fqdn string) {
+		s.sendRequests(ctx, fqdn, []*dnsRequest{buildReqMsg(fqdn, qType, s.newReqID, genEDNS0Options(clientIP))})
+	})
 }
 
 // QueryIP implements Server.
@@ -312,7 +254,9 @@ func (s *TCPNameServer) QueryIP(ctx context.Context, domain string, clientIP net
 	if disableCache {
 		newError("DNS cache is disabled. Querying IP for ", domain, " at ", s.name).AtDebug().WriteToLog()
 	} else {
-		ips, err := s.findIPsForDomain(fqdn, option)
+		ips, err := s.cache.get(ctx, s.name, fqdn, option, func(ctx context.Context) {
+			s.sendQuery(ctx, fqdn, clientIP, option)
+		})
 		if err != errRecordNotFound {
 			newError(s.name, " cache HIT ", domain, " -> ", ips).Base(err).AtDebug().WriteToLog()
 			log.Record(&log.DNSLog{Server: s.name, Domain: domain, Result: ips, Status: log.DNSCacheHit, Elapsed: 0, Error: err})
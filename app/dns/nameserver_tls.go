@@ -0,0 +1,409 @@
+package dns
+
+import (
+	"context"
+	gotls "crypto/tls"
+	"encoding/binary"
+	"io"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/xtls/xray-core/common/buf"
+	"github.com/xtls/xray-core/common/log"
+	"github.com/xtls/xray-core/common/net"
+	"github.com/xtls/xray-core/common/net/cnc"
+	"github.com/xtls/xray-core/common/protocol/dns"
+	"github.com/xtls/xray-core/common/session"
+	"github.com/xtls/xray-core/common/signal/pubsub"
+	dns_feature "github.com/xtls/xray-core/features/dns"
+	"github.com/xtls/xray-core/features/routing"
+	"github.com/xtls/xray-core/transport/internet"
+	"github.com/xtls/xray-core/transport/internet/tls"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// A DNS over TLS connection is closed if it has been idle for dotIdleTimeout.
+const dotIdleTimeout = 30 * time.Second
+
+// TLSNameServer implemented DNS over TLS (RFC7858). Queries are pipelined on a reused connection.
+type TLSNameServer struct {
+	sync.Mutex
+	name        string
+	destination *net.Destination
+	cache       *Cache
+	pub         *pubsub.Service
+	reqID       uint32
+	tlsConfig   *gotls.Config
+	dial        func(context.Context) (net.Conn, error)
+	conn        *dotConn
+}
+
+// dotConn is a DNS over TLS connection with the queries waiting for answers on it.
+type dotConn struct {
+	net.Conn
+	access   sync.Mutex
+	requests map[uint16]*dnsRequest
+	closed   bool
+}
+
+// NewTLSNameServer creates DNS over TLS server object for remote resolving.
+func NewTLSNameServer(url *url.URL, dispatcher routing.Dispatcher, tlsSettings *tls.Config) (*TLSNameServer, error) {
+	s, err := baseTLSNameServer(url, "TLS", tlsSettings)
+	if err != nil {
+		return nil, err
+	}
+
+	s.dial = func(ctx context.Context) (net.Conn, error) {
+		link, err := dispatcher.Dispatch(toDnsContext(ctx, s.destination.String()), *s.destination)
+		if err != nil {
+			return nil, err
+		}
+
+		return cnc.NewConnection(
+			cnc.ConnectionInputMulti(link.Writer),
+			cnc.ConnectionOutputMulti(link.Reader),
+		), nil
+	}
+
+	return s, nil
+}
+
+// NewTLSLocalNameServer creates DNS over TLS client object for local resolving
+func NewTLSLocalNameServer(url *url.URL, tlsSettings *tls.Config) (*TLSNameServer, error) {
+	s, err := baseTLSNameServer(url, "TLSL", tlsSettings)
+	if err != nil {
+		return nil, err
+	}
+
+	s.dial = func(ctx context.Context) (net.Conn, error) {
+		return internet.DialSystem(ctx, *s.destination, nil)
+	}
+
+	return s, nil
+}
+
+func baseTLSNameServer(url *url.URL, prefix string, tlsSettings *tls.Config) (*TLSNameServer, error) {
+	var err error
+	port := net.Port(853)
+	if url.Port() != "" {
+		port, err = net.PortFromString(url.Port())
+		if err != nil {
+			return nil, err
+		}
+	}
+	dest := net.TCPDestination(net.ParseAddress(url.Hostname()), port)
+
+	tlsConfig := tlsSettings.GetTLSConfig(tls.WithDestination(dest))
+	if tlsSettings == nil || len(tlsSettings.NextProtocol) == 0 {
+		tlsConfig.NextProtos = []string{"dot"}
+	}
+	if tlsConfig.ServerName == "" {
+		// certificates of servers given by IP are verified against their IP
+		tlsConfig.ServerName = url.Hostname()
+	}
+
+	s := &TLSNameServer{
+		destination: &dest,
+		cache:       NewCache(0, false, ""),
+		pub:         pubsub.NewService(),
+		name:        prefix + "//" + dest.NetAddr(),
+		tlsConfig:   tlsConfig,
+	}
+
+	return s, nil
+}
+
+// Name implements Server.
+func (s *TLSNameServer) Name() string {
+	return s.name
+}
+
+// setCache implements cacheSetter.
+func (s *TLSNameServer) setCache(cache *Cache) {
+	s.cache = cache
+}
+
+func (s *TLSNameServer) updateIP(req *dnsRequest, ipRec *IPRecord) {
+	elapsed := time.Since(req.start)
+
+	var rec record
+	switch req.reqType {
+	case dnsmessage.TypeA:
+		rec.A = ipRec
+	case dnsmessage.TypeAAAA:
+		rec.AAAA = ipRec
+	}
+	newError(s.name, " got answer: ", req.domain, " ", req.reqType, " -> ", ipRec.IP, " ", elapsed).AtInfo().WriteToLog()
+
+	s.cache.update(s.name, req.domain, &rec)
+	switch req.reqType {
+	case dnsmessage.TypeA:
+		s.pub.Publish(req.domain+"4", nil)
+	case dnsmessage.TypeAAAA:
+		s.pub.Publish(req.domain+"6", nil)
+	}
+}
+
+func (s *TLSNameServer) updateRecords(req *dnsRequest, rec *RRRecord) {
+	elapsed := time.Since(req.start)
+	newError(s.name, " got answer: ", req.domain, " ", req.reqType, " -> ", len(rec.Answers), " answers ", elapsed).AtInfo().WriteToLog()
+	s.cache.updateRecords(s.name, req.domain, req.reqType, rec)
+	s.pub.Publish(req.domain+req.reqType.String(), nil)
+}
+
+func (s *TLSNameServer) newReqID() uint16 {
+	return uint16(atomic.AddUint32(&s.reqID, 1))
+}
+
+// getConnection returns the connection to reuse, or establishes a new one.
+func (s *TLSNameServer) getConnection(ctx context.Context) (*dotConn, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.conn != nil && !s.conn.isClosed() {
+		return s.conn, nil
+	}
+
+	rawConn, err := s.dial(ctx)
+	if err != nil {
+		return nil, newError("failed to dial namesever").Base(err)
+	}
+	tlsConn := gotls.Client(rawConn, s.tlsConfig)
+	handshakeCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	if err := tlsConn.HandshakeContext(handshakeCtx); err != nil {
+		rawConn.Close()
+		return nil, newError("failed to handshake with namesever").Base(err)
+	}
+
+	conn := &dotConn{
+		Conn:     tlsConn,
+		requests: make(map[uint16]*dnsRequest),
+	}
+	s.conn = conn
+	go s.handleResponses(conn)
+	return conn, nil
+}
+
+// Close implements common.Closable.
+func (s *TLSNameServer) Close() error {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.conn != nil {
+		s.conn.close()
+		s.conn = nil
+	}
+	return nil
+}
+
+func (c *dotConn) isClosed() bool {
+	c.access.Lock()
+	defer c.access.Unlock()
+
+	return c.closed
+}
+
+// close closes the connection. The queries waiting on it are given up, and will be retried by the later queries.
+func (c *dotConn) close() {
+	c.access.Lock()
+	defer c.access.Unlock()
+
+	if !c.closed {
+		c.closed = true
+		c.requests = nil
+		c.Conn.Close()
+	}
+}
+
+// send writes the request to the connection, and adds it to the pending requests.
+func (c *dotConn) send(req *dnsRequest, b []byte) error {
+	c.access.Lock()
+	defer c.access.Unlock()
+
+	if c.closed {
+		return io.ErrClosedPipe
+	}
+	c.requests[req.msg.ID] = req
+	c.Conn.SetReadDeadline(time.Now().Add(dotIdleTimeout))
+	if _, err := c.Conn.Write(b); err != nil {
+		delete(c.requests, req.msg.ID)
+		return err
+	}
+	return nil
+}
+
+func (c *dotConn) removeRequest(id uint16) *dnsRequest {
+	c.access.Lock()
+	defer c.access.Unlock()
+
+	req, found := c.requests[id]
+	if found {
+		delete(c.requests, id)
+	}
+	return req
+}
+
+// handleResponses reads the responses from the connection until it fails or is idle.
+func (s *TLSNameServer) handleResponses(conn *dotConn) {
+	defer conn.close()
+
+	var length [2]byte
+	for {
+		if _, err := io.ReadFull(conn, length[:]); err != nil {
+			if !conn.isClosed() {
+				newError(s.name, " connection closed").Base(err).AtDebug().WriteToLog()
+			}
+			return
+		}
+		respBuf := buf.New()
+		if _, err := respBuf.ReadFullFrom(conn, int32(binary.BigEndian.Uint16(length[:]))); err != nil {
+			respBuf.Release()
+			newError(s.name, " failed to read response").Base(err).AtError().WriteToLog()
+			return
+		}
+		s.handleResponse(conn, respBuf.Bytes())
+		respBuf.Release()
+	}
+}
+
+func (s *TLSNameServer) handleResponse(conn *dotConn, resp []byte) {
+	if len(resp) < 2 {
+		newError(s.name, " invalid response").AtError().WriteToLog()
+		return
+	}
+	req := conn.removeRequest(binary.BigEndian.Uint16(resp))
+	if req == nil {
+		newError(s.name, " cannot find the pending request").AtError().WriteToLog()
+		return
+	}
+
+	if !isIPQuery(req.reqType) {
+		rec, err := parseRecordResponse(resp)
+		if err != nil {
+			newError("failed to parse response for ", req.domain).Base(err).AtError().WriteToLog()
+			return
+		}
+		s.updateRecords(req, rec)
+		return
+	}
+
+	rec, err := parseResponse(resp)
+	if err != nil {
+		newError("failed to parse DNS over TLS response").Base(err).AtError().WriteToLog()
+		return
+	}
+	s.updateIP(req, rec)
+}
+
+func (s *TLSNameServer) sendQuery(ctx context.Context, domain string, clientIP net.IP, option dns_feature.IPOption) {
+	s.sendRequests(ctx, domain, buildReqMsgs(domain, option, s.newReqID, genEDNS0Options(clientIP)))
+}
+
+func (s *TLSNameServer) sendRequests(ctx context.Context, domain string, reqs []*dnsRequest) {
+	newError(s.name, " querying DNS for: ", domain).AtDebug().WriteToLog(session.ExportIDToError(ctx))
+
+	for _, req := range reqs {
+		go func(r *dnsRequest) {
+			b, err := dns.PackMessage(r.msg)
+			if err != nil {
+				newError("failed to pack dns query").Base(err).AtError().WriteToLog()
+				return
+			}
+			defer b.Release()
+			dnsReqBuf := buf.New()
+			defer dnsReqBuf.Release()
+			binary.Write(dnsReqBuf, binary.BigEndian, uint16(b.Len()))
+			dnsReqBuf.Write(b.Bytes())
+
+			// a reused connection may have been closed by the server, so the query is retried once on a new one
+			for i := 0; i < 2; i++ {
+				conn, err := s.getConnection(ctx)
+				if err != nil {
+					newError(s.name, " failed to connect").Base(err).AtError().WriteToLog()
+					return
+				}
+				if err = conn.send(r, dnsReqBuf.Bytes()); err == nil {
+					return
+				}
+				conn.close()
+				newError(s.name, " failed to send query").Base(err).AtDebug().WriteToLog()
+			}
+		}(req)
+	}
+}
+
+func (s *TLSNameServer) findIPsForDomain(domain string, option dns_feature.IPOption) ([]net.IP, error) {
+	ips, _, err := s.cache.lookup(s.name, domain, option, false)
+	return ips, err
+}
+
+// QueryRecords implements Server.
+func (s *TLSNameServer) QueryRecords(ctx context.Context, domain string, clientIP net.IP, qType dnsmessage.Type, disableCache bool) ([]dnsmessage.Resource, error) {
+	return queryRecords(ctx, s.name, s.cache, s.pub, domain, qType, disableCache, func(ctx context.Context, fqdn string) {
+		s.sendRequests(ctx, fqdn, []*dnsRequest{buildReqMsg(fqdn, qType, s.newReqID, genEDNS0Options(clientIP))})
+	})
+}
+
+// QueryIP implements Server.
+func (s *TLSNameServer) QueryIP(ctx context.Context, domain string, clientIP net.IP, option dns_feature.IPOption, disableCache bool) ([]net.IP, error) {
+	fqdn := Fqdn(domain)
+
+	if disableCache {
+		newError("DNS cache is disabled. Querying IP for ", domain, " at ", s.name).AtDebug().WriteToLog()
+	} else {
+		ips, err := s.cache.get(ctx, s.name, fqdn, option, func(ctx context.Context) {
+			s.sendQuery(ctx, fqdn, clientIP, option)
+		})
+		if err != errRecordNotFound {
+			newError(s.name, " cache HIT ", domain, " -> ", ips).Base(err).AtDebug().WriteToLog()
+			log.Record(&log.DNSLog{Server: s.name, Domain: domain, Result: ips, Status: log.DNSCacheHit, Elapsed: 0, Error: err})
+			return ips, err
+		}
+	}
+
+	// ipv4 and ipv6 belong to different subscription groups
+	var sub4, sub6 *pubsub.Subscriber
+	if option.IPv4Enable {
+		sub4 = s.pub.Subscribe(fqdn + "4")
+		defer sub4.Close()
+	}
+	if option.IPv6Enable {
+		sub6 = s.pub.Subscribe(fqdn + "6")
+		defer sub6.Close()
+	}
+	done := make(chan interface{})
+	go func() {
+		if sub4 != nil {
+			select {
+			case <-sub4.Wait():
+			case <-ctx.Done():
+			}
+		}
+		if sub6 != nil {
+			select {
+			case <-sub6.Wait():
+			case <-ctx.Done():
+			}
+		}
+		close(done)
+	}()
+	s.sendQuery(ctx, fqdn, clientIP, option)
+	start := time.Now()
+
+	for {
+		ips, err := s.findIPsForDomain(fqdn, option)
+		if err != errRecordNotFound {
+			log.Record(&log.DNSLog{Server: s.name, Domain: domain, Result: ips, Status: log.DNSQueried, Elapsed: time.Since(start), Error: err})
+			return ips, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-done:
+		}
+	}
+}
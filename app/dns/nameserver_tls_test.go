@@ -0,0 +1,102 @@
+package dns_test
+
+import (
+	"context"
+	gotls "crypto/tls"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/miekg/dns"
+	. "github.com/xtls/xray-core/app/dns"
+	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/common/net"
+	"github.com/xtls/xray-core/common/protocol/tls/cert"
+	dns_feature "github.com/xtls/xray-core/features/dns"
+	"github.com/xtls/xray-core/testing/servers/tcp"
+	"github.com/xtls/xray-core/transport/internet/tls"
+)
+
+type dotHandler struct {
+	sync.Mutex
+	conns map[string]bool
+}
+
+func (h *dotHandler) ServeDNS(w dns.ResponseWriter, r *dns.Msg) {
+	h.Lock()
+	h.conns[w.RemoteAddr().String()] = true
+	h.Unlock()
+
+	ans := new(dns.Msg)
+	ans.SetReply(r)
+	for _, q := range r.Question {
+		if q.Qtype == dns.TypeA {
+			rr, err := dns.NewRR(q.Name + " IN A 1.2.3.4")
+			common.Must(err)
+			ans.Answer = append(ans.Answer, rr)
+		}
+	}
+	w.WriteMsg(ans)
+}
+
+func TestTLSLocalNameServer(t *testing.T) {
+	certificate := cert.MustGenerate(nil, cert.DNSNames("dns.example.com"))
+	certPEM, keyPEM := certificate.ToPEM()
+	keyPair, err := gotls.X509KeyPair(certPEM, keyPEM)
+	common.Must(err)
+
+	port := tcp.PickPort()
+	handler := &dotHandler{conns: make(map[string]bool)}
+	dnsServer := dns.Server{
+		Addr:      "127.0.0.1:" + port.String(),
+		Net:       "tcp-tls",
+		Handler:   handler,
+		TLSConfig: &gotls.Config{Certificates: []gotls.Certificate{keyPair}},
+	}
+	defer dnsServer.Shutdown()
+
+	go dnsServer.ListenAndServe()
+	time.Sleep(time.Second)
+
+	url, err := url.Parse("tls+local://127.0.0.1:" + port.String())
+	common.Must(err)
+	s, err := NewTLSLocalNameServer(url, &tls.Config{
+		AllowInsecure:                    true,
+		ServerName:                       "dns.example.com",
+		PinnedPeerCertificateChainSha256: [][]byte{tls.GenerateCertChainHash([][]byte{keyPair.Certificate[0]})},
+	})
+	common.Must(err)
+
+	for _, domain := range []string{"example.com", "example.org"} {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+		ips, err := s.QueryIP(ctx, domain, net.IP(nil), dns_feature.IPOption{
+			IPv4Enable: true,
+			IPv6Enable: true,
+		}, false)
+		cancel()
+		common.Must(err)
+		if r := cmp.Diff(ips, []net.IP{{1, 2, 3, 4}}); r != "" {
+			t.Error(r)
+		}
+	}
+
+	handler.Lock()
+	if len(handler.conns) != 1 {
+		t.Error("expect queries on one connection, but got ", len(handler.conns))
+	}
+	handler.Unlock()
+
+	s, err = NewTLSLocalNameServer(url, &tls.Config{
+		AllowInsecure:                    true,
+		PinnedPeerCertificateChainSha256: [][]byte{{1, 2, 3}},
+	})
+	common.Must(err)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*2)
+	_, err = s.QueryIP(ctx, "example.com", net.IP(nil), dns_feature.IPOption{IPv4Enable: true}, false)
+	cancel()
+	if err == nil {
+		t.Error("expect query to fail with unrecognized certificate")
+	}
+}
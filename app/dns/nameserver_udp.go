@@ -26,7 +26,7 @@ type ClassicNameServer struct {
 	sync.RWMutex
 	name      string
 	address   *net.Destination
-	ips       map[string]*record
+	cache     *Cache
 	requests  map[uint16]*dnsRequest
 	pub       *pubsub.Service
 	udpServer *udp.Dispatcher
@@ -43,7 +43,7 @@ func NewClassicNameServer(address net.Destination, dispatcher routing.Dispatcher
 
 	s := &ClassicNameServer{
 		address:  &address,
-		ips:      make(map[string]*record),
+		cache:    NewCache(0, false, ""),
 		requests: make(map[uint16]*dnsRequest),
 		pub:      pubsub.NewService(),
 		name:     strings.ToUpper(address.String()),
@@ -62,36 +62,27 @@ func (s *ClassicNameServer) Name() string {
 	return s.name
 }
 
-// Cleanup clears expired items from cache
+// setCache implements cacheSetter.
+func (s *ClassicNameServer) setCache(cache *Cache) {
+	s.cache = cache
+}
+
+// Close implements common.Closable.
+func (s *ClassicNameServer) Close() error {
+	s.cleanup.Close()
+	return s.udpServer.Close()
+}
+
+// Cleanup clears expired pending requests
 func (s *ClassicNameServer) Cleanup() error {
 	now := time.Now()
 	s.Lock()
 	defer s.Unlock()
 
-	if len(s.ips) == 0 && len(s.requests) == 0 {
+	if len(s.requests) == 0 {
 		return newError(s.name, " nothing to do. stopping...")
 	}
 
-	for domain, record := range s.ips {
-		if record.A != nil && record.A.Expire.Before(now) {
-			record.A = nil
-		}
-		if record.AAAA != nil && record.AAAA.Expire.Before(now) {
-			record.AAAA = nil
-		}
-
-		if record.A == nil && record.AAAA == nil {
-			newError(s.name, " cleanup ", domain).AtDebug().WriteToLog()
-			delete(s.ips, domain)
-		} else {
-			s.ips[domain] = record
-		}
-	}
-
-	if len(s.ips) == 0 {
-		s.ips = make(map[string]*record)
-	}
-
 	for id, req := range s.requests {
 		if req.expire.Before(now) {
 			delete(s.requests, id)
@@ -132,6 +123,14 @@ func (s *ClassicNameServer) HandleResponse(ctx context.Context, packet *udp_prot
 		rec.A = ipRec
 	case dnsmessage.TypeAAAA:
 		rec.AAAA = ipRec
+	default:
+		rrRec, err := parseRecordResponse(packet.Payload.Bytes())
+		if err != nil {
+			newError(s.name, " fail to parse responded DNS udp").Base(err).AtError().WriteToLog()
+			return
+		}
+		s.updateRecords(req, rrRec)
+		return
 	}
 
 	elapsed := time.Since(req.start)
@@ -142,35 +141,21 @@ func (s *ClassicNameServer) HandleResponse(ctx context.Context, packet *udp_prot
 }
 
 func (s *ClassicNameServer) updateIP(domain string, newRec *record) {
-	s.Lock()
-
-	rec, found := s.ips[domain]
-	if !found {
-		rec = &record{}
-	}
-
-	updated := false
-	if isNewer(rec.A, newRec.A) {
-		rec.A = newRec.A
-		updated = true
-	}
-	if isNewer(rec.AAAA, newRec.AAAA) {
-		rec.AAAA = newRec.AAAA
-		updated = true
-	}
-
-	if updated {
-		newError(s.name, " updating IP records for domain:", domain).AtDebug().WriteToLog()
-		s.ips[domain] = rec
-	}
+	newError(s.name, " updating IP records for domain:", domain).AtDebug().WriteToLog()
+	s.cache.update(s.name, domain, newRec)
 	if newRec.A != nil {
 		s.pub.Publish(domain+"4", nil)
 	}
 	if newRec.AAAA != nil {
 		s.pub.Publish(domain+"6", nil)
 	}
-	s.Unlock()
-	common.Must(s.cleanup.Start())
+}
+
+func (s *ClassicNameServer) updateRecords(req *dnsRequest, rec *RRRecord) {
+	elapsed := time.Since(req.start)
+	newError(s.name, " got answer: ", req.domain, " ", req.reqType, " -> ", len(rec.Answers), " answers ", elapsed).AtInfo().WriteToLog()
+	s.cache.updateRecords(s.name, req.domain, req.reqType, rec)
+	s.pub.Publish(req.domain+req.reqType.String(), nil)
 }
 
 func (s *ClassicNameServer) newReqID() uint16 {
@@ -179,17 +164,19 @@ func (s *ClassicNameServer) newReqID() uint16 {
 
 func (s *ClassicNameServer) addPendingRequest(req *dnsRequest) {
 	s.Lock()
-	defer s.Unlock()
-
 	id := req.msg.ID
 	req.expire = time.Now().Add(time.Second * 8)
 	s.requests[id] = req
+	s.Unlock()
+	common.Must(s.cleanup.Start())
 }
 
 func (s *ClassicNameServer) sendQuery(ctx context.Context, domain string, clientIP net.IP, option dns_feature.IPOption) {
-	newError(s.name, " querying DNS for: ", domain).AtDebug().WriteToLog(session.ExportIDToError(ctx))
+	s.sendRequests(ctx, domain, buildReqMsgs(domain, option, s.newReqID, genEDNS0Options(clientIP)))
+}
 
-	reqs := buildReqMsgs(domain, option, s.newReqID, genEDNS0Options(clientIP))
+func (s *ClassicNameServer) sendRequests(ctx context.Context, domain string, reqs []*dnsRequest) {
+	newError(s.name, " querying DNS for: ", domain).AtDebug().WriteToLog(session.ExportIDToError(ctx))
 
 	for _, req := range reqs {
 		s.addPendingRequest(req)
@@ -199,41 +186,15 @@ func (s *ClassicNameServer) sendQuery(ctx context.Context, domain string, client
 }
 
 func (s *ClassicNameServer) findIPsForDomain(domain string, option dns_feature.IPOption) ([]net.IP, error) {
-	s.RLock()
-	record, found := s.ips[domain]
-	s.RUnlock()
-
-	if !found {
-		return nil, errRecordNotFound
-	}
-
-	var err4 error
-	var err6 error
-	var ips []net.Address
-	var ip6 []net.Address
-
-	if option.IPv4Enable {
-		ips, err4 = record.A.getIPs()
-	}
-
-	if option.IPv6Enable {
-		ip6, err6 = record.AAAA.getIPs()
-		ips = append(ips, ip6...)
-	}
-
-	if len(ips) > 0 {
-		return toNetIP(ips)
-	}
-
-	if err4 != nil {
-		return nil, err4
-	}
-
-	if err6 != nil {
-		return nil, err6
-	}
+	ips, _, err := s.cache.lookup(s.name, domain, option, false)
+	return ips, err
+}
 
-	return nil, dns_feature.ErrEmptyResponse
+// QueryRecords implements Server.
+func (s *ClassicNameServer) QueryRecords(ctx context.Context, domain string, clientIP net.IP, qType dnsmessage.Type, disableCache bool) ([]dnsmessage.Resource, error) {
+	return queryRecords(ctx, s.name, s.cache, s.pub, domain, qType, disableCache, func(ctx context.Context, fqdn string) {
+		s.sendRequests(ctx, fqdn, []*dnsRequest{buildReqMsg(fqdn, qType, s.newReqID, genEDNS0Options(clientIP))})
+	})
 }
 
 // QueryIP implements Server.
@@ -243,7 +204,9 @@ func (s *ClassicNameServer) QueryIP(ctx context.Context, domain string, clientIP
 	if disableCache {
 		newError("DNS cache is disabled. Querying IP for ", domain, " at ", s.name).AtDebug().WriteToLog()
 	} else {
-		ips, err := s.findIPsForDomain(fqdn, option)
+		ips, err := s.cache.get(ctx, s.name, fqdn, option, func(ctx context.Context) {
+			s.sendQuery(ctx, fqdn, clientIP, option)
+		})
 		if err != errRecordNotFound {
 			newError(s.name, " cache HIT ", domain, " -> ", ips).Base(err).AtDebug().WriteToLog()
 			log.Record(&log.DNSLog{Server: s.name, Domain: domain, Result: ips, Status: log.DNSCacheHit, Elapsed: 0, Error: err})
@@ -8,6 +8,7 @@ import (
 
 	"github.com/xtls/xray-core/common"
 	"github.com/xtls/xray-core/common/log"
+	"github.com/xtls/xray-core/features"
 )
 
 // Instance is a log.Handler that handles logs.
@@ -41,7 +42,9 @@ func New(ctx context.Context, config *Config) (*Instance, error) {
 
 func (g *Instance) initAccessLogger() error {
 	handler, err := createHandler(g.config.AccessLogType, HandlerCreatorOptions{
-		Path: g.config.AccessLogPath,
+		Path:     g.config.AccessLogPath,
+		Format:   g.config.AccessLogFormat,
+		Rotation: g.config.Rotation,
 	})
 	if err != nil {
 		return err
@@ -52,7 +55,9 @@ func (g *Instance) initAccessLogger() error {
 
 func (g *Instance) initErrorLogger() error {
 	handler, err := createHandler(g.config.ErrorLogType, HandlerCreatorOptions{
-		Path: g.config.ErrorLogPath,
+		Path:     g.config.ErrorLogPath,
+		Format:   g.config.ErrorLogFormat,
+		Rotation: g.config.Rotation,
 	})
 	if err != nil {
 		return err
@@ -102,6 +107,15 @@ func (g *Instance) Handle(msg log.Message) {
 
 	switch msg := msg.(type) {
 	case *log.AccessMessage:
+		// Text logs are written when the session is accepted, while JSON logs are written when it is closed,
+		// with the traffic of the session.
+		if g.config.AccessLogFormat == LogFormat_JSON {
+			if msg.Deferred {
+				return
+			}
+		} else if msg.Status == log.AccessClosed {
+			return
+		}
 		if g.accessLogger != nil {
 			g.accessLogger.Handle(msg)
 		}
@@ -118,6 +132,35 @@ func (g *Instance) Handle(msg log.Message) {
 	}
 }
 
+// Reload implements features.Reloadable.
+func (g *Instance) Reload(f features.Feature) error {
+	n, ok := f.(*Instance)
+	if !ok {
+		return newError("not a logger")
+	}
+
+	// The new logger registered itself as the log handler on creation, take over its handlers.
+	n.Lock()
+	config, accessLogger, errorLogger, dns := n.config, n.accessLogger, n.errorLogger, n.dns
+	n.active = false
+	n.accessLogger = nil
+	n.errorLogger = nil
+	n.Unlock()
+
+	g.Lock()
+	common.Close(g.accessLogger)
+	common.Close(g.errorLogger)
+	g.config = config
+	g.accessLogger = accessLogger
+	g.errorLogger = errorLogger
+	g.dns = dns
+	g.active = true
+	g.Unlock()
+
+	log.RegisterHandler(g)
+	return nil
+}
+
 // Close implements common.Closable.Close().
 func (g *Instance) Close() error {
 	newError("Logger closing").AtDebug().WriteToLog()
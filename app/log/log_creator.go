@@ -2,13 +2,34 @@ package log
 
 import (
 	"sync"
+	"time"
 
 	"github.com/xtls/xray-core/common"
 	"github.com/xtls/xray-core/common/log"
 )
 
 type HandlerCreatorOptions struct {
-	Path string
+	Path     string
+	Format   LogFormat
+	Rotation *Rotation
+}
+
+func (o HandlerCreatorOptions) writerOptions() []log.WriterOption {
+	var opts []log.WriterOption
+	if o.Format == LogFormat_JSON {
+		opts = append(opts, log.WithoutTimestamp())
+	}
+	if o.Rotation != nil {
+		opts = append(opts, log.WithRotation(o.Rotation.MaxSize, time.Duration(o.Rotation.MaxAge)*time.Second, int(o.Rotation.MaxBackups)))
+	}
+	return opts
+}
+
+func (o HandlerCreatorOptions) format() log.Format {
+	if o.Format == LogFormat_JSON {
+		return log.FormatJSON
+	}
+	return log.FormatText
 }
 
 type HandlerCreator func(LogType, HandlerCreatorOptions) (log.Handler, error)
@@ -42,15 +63,15 @@ func createHandler(logType LogType, options HandlerCreatorOptions) (log.Handler,
 
 func init() {
 	common.Must(RegisterHandlerCreator(LogType_Console, func(lt LogType, options HandlerCreatorOptions) (log.Handler, error) {
-		return log.NewLogger(log.CreateStdoutLogWriter()), nil
+		return log.NewFormattedLogger(log.CreateStdoutLogWriter(options.writerOptions()...), options.format()), nil
 	}))
 
 	common.Must(RegisterHandlerCreator(LogType_File, func(lt LogType, options HandlerCreatorOptions) (log.Handler, error) {
-		creator, err := log.CreateFileLogWriter(options.Path)
+		creator, err := log.CreateFileLogWriter(options.Path, options.writerOptions()...)
 		if err != nil {
 			return nil, err
 		}
-		return log.NewLogger(creator), nil
+		return log.NewFormattedLogger(creator, options.format()), nil
 	}))
 
 	common.Must(RegisterHandlerCreator(LogType_None, func(lt LogType, options HandlerCreatorOptions) (log.Handler, error) {
@@ -3,9 +3,12 @@ package metrics
 import (
 	"context"
 	"expvar"
+	"fmt"
 	"net/http"
-	_ "net/http/pprof"
+	"net/http/pprof"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/xtls/xray-core/app/observatory"
 	"github.com/xtls/xray-core/app/stats"
@@ -14,71 +17,122 @@ import (
 	"github.com/xtls/xray-core/common/signal/done"
 	"github.com/xtls/xray-core/core"
 	"github.com/xtls/xray-core/features/extension"
+	"github.com/xtls/xray-core/features/inbound"
 	"github.com/xtls/xray-core/features/outbound"
 	feature_stats "github.com/xtls/xray-core/features/stats"
 )
 
 type MetricsHandler struct {
-	ohm          outbound.Manager
-	statsManager feature_stats.Manager
-	observatory  extension.Observatory
-	tag          string
+	ctx             context.Context
+	ihm             inbound.Manager
+	ohm             outbound.Manager
+	statsManager    feature_stats.Manager
+	observatory     extension.Observatory
+	observatoryOnce sync.Once
+	tag             string
+	startTime       time.Time
+	mux             *http.ServeMux
 }
 
 // NewMetricsHandler creates a new MetricsHandler based on the given config.
 func NewMetricsHandler(ctx context.Context, config *Config) (*MetricsHandler, error) {
 	c := &MetricsHandler{
-		tag: config.Tag,
+		ctx:       ctx,
+		tag:       config.Tag,
+		startTime: time.Now(),
 	}
-	common.Must(core.RequireFeatures(ctx, func(om outbound.Manager, sm feature_stats.Manager) {
+	common.Must(core.RequireFeatures(ctx, func(im inbound.Manager, om outbound.Manager, sm feature_stats.Manager) {
 		c.statsManager = sm
+		c.ihm = im
 		c.ohm = om
 	}))
-	expvar.Publish("stats", expvar.Func(func() interface{} {
-		manager, ok := c.statsManager.(*stats.Manager)
-		if !ok {
-			return nil
-		}
-		resp := map[string]map[string]map[string]int64{
-			"inbound":  {},
-			"outbound": {},
-			"user":     {},
-		}
-		manager.VisitCounters(func(name string, counter feature_stats.Counter) bool {
-			nameSplit := strings.Split(name, ">>>")
-			typeName, tagOrUser, direction := nameSplit[0], nameSplit[1], nameSplit[3]
-			if item, found := resp[typeName][tagOrUser]; found {
-				item[direction] = counter.Value()
-			} else {
-				resp[typeName][tagOrUser] = map[string]int64{
-					direction: counter.Value(),
-				}
-			}
-			return true
-		})
-		return resp
-	}))
-	expvar.Publish("observatory", expvar.Func(func() interface{} {
-		if c.observatory == nil {
-			common.Must(core.RequireFeatures(ctx, func(observatory extension.Observatory) error {
-				c.observatory = observatory
-				return nil
-			}))
-			if c.observatory == nil {
-				return nil
-			}
+
+	// The handlers are registered on a mux of each instance rather than http.DefaultServeMux, so that instances don't
+	// conflict, and other servers using http.DefaultServeMux don't expose them.
+	c.mux = http.NewServeMux()
+	c.mux.Handle("/metrics", c)
+	c.mux.HandleFunc("/debug/vars", c.serveVars)
+	c.mux.HandleFunc("/debug/pprof/", pprof.Index)
+	c.mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	c.mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	c.mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	c.mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	return c, nil
+}
+
+// serveVars serves the published expvar variables, along with the stats and observatory of the instance, in the
+// same format as expvar.Handler.
+func (p *MetricsHandler) serveVars(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	fmt.Fprintf(w, "{\n")
+	first := true
+	write := func(key string, value expvar.Var) {
+		if !first {
+			fmt.Fprintf(w, ",\n")
 		}
-		resp := map[string]*observatory.OutboundStatus{}
-		if o, err := c.observatory.GetObservation(context.Background()); err != nil {
-			return err
+		first = false
+		fmt.Fprintf(w, "%q: %s", key, value)
+	}
+	expvar.Do(func(kv expvar.KeyValue) {
+		write(kv.Key, kv.Value)
+	})
+	write("stats", expvar.Func(p.statsVar))
+	write("observatory", expvar.Func(p.observatoryVar))
+	fmt.Fprintf(w, "\n}\n")
+}
+
+func (p *MetricsHandler) statsVar() interface{} {
+	manager, ok := p.statsManager.(*stats.Manager)
+	if !ok {
+		return nil
+	}
+	resp := map[string]map[string]map[string]int64{
+		"inbound":  {},
+		"outbound": {},
+		"user":     {},
+	}
+	manager.VisitCounters(func(name string, counter feature_stats.Counter) bool {
+		nameSplit := strings.Split(name, ">>>")
+		typeName, tagOrUser, direction := nameSplit[0], nameSplit[1], nameSplit[3]
+		if item, found := resp[typeName][tagOrUser]; found {
+			item[direction] = counter.Value()
 		} else {
-			for _, x := range o.(*observatory.ObservationResult).GetStatus() {
-				resp[x.OutboundTag] = x
+			resp[typeName][tagOrUser] = map[string]int64{
+				direction: counter.Value(),
 			}
 		}
-		return resp
-	}))
-	return c, nil
+		return true
+	})
+	return resp
+}
+
+func (p *MetricsHandler) observatoryVar() interface{} {
+	o := p.getObservatory()
+	if o == nil {
+		return nil
+	}
+	resp := map[string]*observatory.OutboundStatus{}
+	if o, err := o.GetObservation(context.Background()); err != nil {
+		return err
+	} else {
+		for _, x := range o.(*observatory.ObservationResult).GetStatus() {
+			resp[x.OutboundTag] = x
+		}
+	}
+	return resp
+}
+
+// getObservatory returns the observatory, which is looked up once, as the observatory feature is optional.
+func (p *MetricsHandler) getObservatory() extension.Observatory {
+	p.observatoryOnce.Do(func() {
+		if p.observatory != nil {
+			return
+		}
+		if o, ok := core.MustFromContext(p.ctx).GetFeature(extension.ObservatoryType()).(extension.Observatory); ok {
+			p.observatory = o
+		}
+	})
+	return p.observatory
 }
 
 func (p *MetricsHandler) Type() interface{} {
@@ -92,7 +146,7 @@ func (p *MetricsHandler) Start() error {
 	}
 
 	go func() {
-		if err := http.Serve(listener, http.DefaultServeMux); err != nil {
+		if err := http.Serve(listener, p.mux); err != nil {
 			newError("failed to start metrics server").Base(err).AtError().WriteToLog()
 		}
 	}()
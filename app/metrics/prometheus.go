@@ -0,0 +1,188 @@
+package metrics
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/xtls/xray-core/app/observatory"
+	"github.com/xtls/xray-core/app/stats"
+	feature_stats "github.com/xtls/xray-core/features/stats"
+)
+
+// prometheusContentType is the content type of the Prometheus text exposition format.
+const prometheusContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// connectionCounter is implemented by inbound handlers that count their live connections.
+type connectionCounter interface {
+	ConnectionCount() int64
+}
+
+// metricFamily is a group of samples with the same metric name.
+type metricFamily struct {
+	name    string
+	help    string
+	typ     string
+	samples []sample
+}
+
+type sample struct {
+	labels [][2]string
+	value  float64
+}
+
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func (f *metricFamily) add(value float64, labels ...string) {
+	s := sample{value: value}
+	for i := 0; i+1 < len(labels); i += 2 {
+		s.labels = append(s.labels, [2]string{labels[i], labels[i+1]})
+	}
+	f.samples = append(f.samples, s)
+}
+
+func (f *metricFamily) writeTo(w *bufio.Writer) {
+	if len(f.samples) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "# HELP %s %s\n", f.name, f.help)
+	fmt.Fprintf(w, "# TYPE %s %s\n", f.name, f.typ)
+	for _, s := range f.samples {
+		w.WriteString(f.name)
+		if len(s.labels) > 0 {
+			w.WriteByte('{')
+			for i, l := range s.labels {
+				if i > 0 {
+					w.WriteByte(',')
+				}
+				fmt.Fprintf(w, `%s="%s"`, l[0], labelValueEscaper.Replace(l[1]))
+			}
+			w.WriteByte('}')
+		}
+		fmt.Fprintf(w, " %v\n", s.value)
+	}
+}
+
+// ServeHTTP serves the metrics in the Prometheus text exposition format.
+func (p *MetricsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var families []*metricFamily
+	families = append(families, p.collectStats()...)
+	families = append(families, p.collectObservatory()...)
+	families = append(families, p.collectConnections()...)
+	families = append(families, p.collectSys()...)
+
+	w.Header().Set("Content-Type", prometheusContentType)
+	bw := bufio.NewWriter(w)
+	for _, f := range families {
+		sort.SliceStable(f.samples, func(i, j int) bool {
+			return fmt.Sprint(f.samples[i].labels) < fmt.Sprint(f.samples[j].labels)
+		})
+		f.writeTo(bw)
+	}
+	if err := bw.Flush(); err != nil {
+		newError("failed to write metrics").Base(err).WriteToLog()
+	}
+}
+
+// collectStats exports the counters of the stats manager. Counters named like "inbound>>>tag>>>traffic>>>uplink"
+// are exported as traffic counters labelled by dimension and target, others by their names.
+func (p *MetricsHandler) collectStats() []*metricFamily {
+	manager, ok := p.statsManager.(*stats.Manager)
+	if !ok {
+		return nil
+	}
+	uplink := &metricFamily{name: "xray_traffic_uplink_bytes_total", help: "Number of bytes of uplink traffic, in the direction from the client towards the destination.", typ: "counter"}
+	downlink := &metricFamily{name: "xray_traffic_downlink_bytes_total", help: "Number of bytes of downlink traffic, in the direction from the destination towards the client.", typ: "counter"}
+	others := &metricFamily{name: "xray_stats_counter", help: "Value of other counters of the stats manager.", typ: "gauge"}
+	manager.VisitCounters(func(name string, counter feature_stats.Counter) bool {
+		parts := strings.Split(name, ">>>")
+		value := float64(counter.Value())
+		switch {
+		case len(parts) == 4 && parts[2] == "traffic" && parts[3] == "uplink":
+			uplink.add(value, "dimension", parts[0], "target", parts[1])
+		case len(parts) == 4 && parts[2] == "traffic" && parts[3] == "downlink":
+			downlink.add(value, "dimension", parts[0], "target", parts[1])
+		default:
+			others.add(value, "name", name)
+		}
+		return true
+	})
+	return []*metricFamily{uplink, downlink, others}
+}
+
+// collectObservatory exports the outbound status of the observatory, if there is one.
+func (p *MetricsHandler) collectObservatory() []*metricFamily {
+	o := p.getObservatory()
+	if o == nil {
+		return nil
+	}
+	result, err := o.GetObservation(context.Background())
+	if err != nil {
+		newError("cannot get observe report").Base(err).WriteToLog()
+		return nil
+	}
+	r, ok := result.(*observatory.ObservationResult)
+	if !ok {
+		return nil
+	}
+
+	alive := &metricFamily{name: "xray_observatory_alive", help: "Whether the outbound is alive, 1 or 0.", typ: "gauge"}
+	delay := &metricFamily{name: "xray_observatory_delay_seconds", help: "Delay of the probe requests through the outbound.", typ: "gauge"}
+	for _, status := range r.GetStatus() {
+		if status.Alive {
+			alive.add(1, "outbound", status.OutboundTag)
+			delay.add((time.Duration(status.Delay) * time.Millisecond).Seconds(), "outbound", status.OutboundTag)
+		} else {
+			alive.add(0, "outbound", status.OutboundTag)
+		}
+	}
+	return []*metricFamily{alive, delay}
+}
+
+// collectConnections exports the live connections of tagged inbounds.
+func (p *MetricsHandler) collectConnections() []*metricFamily {
+	if p.ihm == nil {
+		return nil
+	}
+	connections := &metricFamily{name: "xray_inbound_connections", help: "Number of live connections of the inbound.", typ: "gauge"}
+	for _, handler := range p.ihm.ListHandlers(context.Background()) {
+		if c, ok := handler.(connectionCounter); ok && handler.Tag() != "" {
+			connections.add(float64(c.ConnectionCount()), "inbound", handler.Tag())
+		}
+	}
+	return []*metricFamily{connections}
+}
+
+// collectSys exports the runtime stats, the same as the GetSysStats API of the stats service.
+func (p *MetricsHandler) collectSys() []*metricFamily {
+	var rtm runtime.MemStats
+	runtime.ReadMemStats(&rtm)
+
+	gauge := func(name, help string, value float64) *metricFamily {
+		f := &metricFamily{name: name, help: help, typ: "gauge"}
+		f.add(value)
+		return f
+	}
+	counter := func(name, help string, value float64) *metricFamily {
+		f := &metricFamily{name: name, help: help, typ: "counter"}
+		f.add(value)
+		return f
+	}
+	return []*metricFamily{
+		gauge("xray_sys_uptime_seconds", "Time since Xray started.", time.Since(p.startTime).Seconds()),
+		gauge("xray_sys_goroutines", "Number of goroutines.", float64(runtime.NumGoroutine())),
+		gauge("xray_sys_alloc_bytes", "Bytes of allocated heap objects.", float64(rtm.Alloc)),
+		counter("xray_sys_total_alloc_bytes_total", "Cumulative bytes allocated for heap objects.", float64(rtm.TotalAlloc)),
+		gauge("xray_sys_sys_bytes", "Bytes of memory obtained from the OS.", float64(rtm.Sys)),
+		counter("xray_sys_mallocs_total", "Cumulative count of heap objects allocated.", float64(rtm.Mallocs)),
+		counter("xray_sys_frees_total", "Cumulative count of heap objects freed.", float64(rtm.Frees)),
+		gauge("xray_sys_live_objects", "Number of live heap objects.", float64(rtm.Mallocs-rtm.Frees)),
+		counter("xray_sys_gc_total", "Number of completed GC cycles.", float64(rtm.NumGC)),
+		counter("xray_sys_gc_pause_seconds_total", "Cumulative time of GC pauses.", time.Duration(rtm.PauseTotalNs).Seconds()),
+	}
+}
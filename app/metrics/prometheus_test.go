@@ -0,0 +1,109 @@
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/xtls/xray-core/app/observatory"
+	"github.com/xtls/xray-core/app/proxyman"
+	_ "github.com/xtls/xray-core/app/proxyman/inbound"
+	_ "github.com/xtls/xray-core/app/proxyman/outbound"
+	"github.com/xtls/xray-core/app/stats"
+	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/common/serial"
+	"github.com/xtls/xray-core/core"
+)
+
+type fakeObservatory struct{}
+
+func (fakeObservatory) Type() interface{} { return nil }
+func (fakeObservatory) Start() error      { return nil }
+func (fakeObservatory) Close() error      { return nil }
+
+func (fakeObservatory) GetObservation(ctx context.Context) (proto.Message, error) {
+	return &observatory.ObservationResult{Status: []*observatory.OutboundStatus{
+		{OutboundTag: "proxy", Alive: true, Delay: 250},
+		{OutboundTag: "dead", Alive: false, Delay: 99999999},
+	}}, nil
+}
+
+func TestPrometheusMetrics(t *testing.T) {
+	m, err := stats.NewManager(context.Background(), &stats.Config{})
+	common.Must(err)
+	c, err := m.RegisterCounter("user>>>a\"b>>>traffic>>>uplink")
+	common.Must(err)
+	c.Set(1024)
+	_, err = m.RegisterCounter("inbound>>>api>>>traffic>>>downlink")
+	common.Must(err)
+
+	h := &MetricsHandler{
+		statsManager: m,
+		observatory:  fakeObservatory{},
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+
+	if ct := rec.Header().Get("Content-Type"); ct != prometheusContentType {
+		t.Error("unexpected content type: ", ct)
+	}
+	body := rec.Body.String()
+	for _, line := range []string{
+		"# TYPE xray_traffic_uplink_bytes_total counter",
+		`xray_traffic_uplink_bytes_total{dimension="user",target="a\"b"} 1024`,
+		`xray_traffic_downlink_bytes_total{dimension="inbound",target="api"} 0`,
+		`xray_observatory_alive{outbound="dead"} 0`,
+		`xray_observatory_alive{outbound="proxy"} 1`,
+		`xray_observatory_delay_seconds{outbound="proxy"} 0.25`,
+		"# TYPE xray_sys_goroutines gauge",
+	} {
+		if !strings.Contains(body, line+"\n") {
+			t.Error("missing line: ", line, "\n", body)
+		}
+	}
+	if strings.Contains(body, `xray_observatory_delay_seconds{outbound="dead"}`) {
+		t.Error("unexpected delay of dead outbound")
+	}
+}
+
+func TestMetricsHandlerInstances(t *testing.T) {
+	config := &core.Config{
+		App: []*serial.TypedMessage{
+			serial.ToTypedMessage(&proxyman.InboundConfig{}),
+			serial.ToTypedMessage(&proxyman.OutboundConfig{}),
+			serial.ToTypedMessage(&stats.Config{}),
+			serial.ToTypedMessage(&Config{Tag: "metrics"}),
+		},
+	}
+
+	// Instances in the same process must not conflict on global registrations.
+	for i := 0; i < 2; i++ {
+		server, err := core.New(config)
+		common.Must(err)
+		h := server.GetFeature((*MetricsHandler)(nil)).(*MetricsHandler)
+
+		rec := httptest.NewRecorder()
+		h.mux.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+		if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "xray_sys_goroutines") {
+			t.Error("unexpected /metrics response: ", rec.Code)
+		}
+
+		rec = httptest.NewRecorder()
+		h.mux.ServeHTTP(rec, httptest.NewRequest("GET", "/debug/vars", nil))
+		var vars map[string]json.RawMessage
+		if err := json.Unmarshal(rec.Body.Bytes(), &vars); err != nil {
+			t.Error("invalid /debug/vars response: ", err)
+		}
+		if _, found := vars["stats"]; !found {
+			t.Error("missing stats in /debug/vars")
+		}
+	}
+
+	if _, pattern := http.DefaultServeMux.Handler(httptest.NewRequest("GET", "/metrics", nil)); pattern != "" {
+		t.Error("expect /metrics not registered on http.DefaultServeMux")
+	}
+}
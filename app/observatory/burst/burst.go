@@ -0,0 +1,15 @@
+// Package burst is an observatory that pings outbounds several times per round, and reports the statistics of the
+// recent results.
+package burst
+
+//go:generate go run github.com/xtls/xray-core/common/errors/errorgen
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	rttFailed = time.Duration(math.MaxInt64 - iota)
+	rttUntested
+)
@@ -0,0 +1,111 @@
+package burst
+
+import (
+	"context"
+	"sort"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/xtls/xray-core/app/observatory"
+	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/common/signal/done"
+	"github.com/xtls/xray-core/core"
+	"github.com/xtls/xray-core/features/extension"
+	"github.com/xtls/xray-core/features/outbound"
+)
+
+type Observer struct {
+	config *Config
+	ctx    context.Context
+
+	hp *HealthPing
+
+	finished *done.Instance
+
+	ohm outbound.Manager
+}
+
+func (o *Observer) GetObservation(ctx context.Context) (proto.Message, error) {
+	return &observatory.ObservationResult{Status: o.createResult()}, nil
+}
+
+func (o *Observer) createResult() []*observatory.OutboundStatus {
+	o.hp.access.Lock()
+	defer o.hp.access.Unlock()
+
+	result := make([]*observatory.OutboundStatus, 0, len(o.hp.Results))
+	for name, value := range o.hp.Results {
+		stat := value.Get()
+		status := &observatory.OutboundStatus{
+			Alive:       stat.All != stat.Fail,
+			Delay:       stat.Average.Milliseconds(),
+			OutboundTag: name,
+			HealthPing: &observatory.HealthPingMeasurementResult{
+				All:       int64(stat.All),
+				Fail:      int64(stat.Fail),
+				Deviation: int64(stat.Deviation),
+				Average:   int64(stat.Average),
+				Max:       int64(stat.Max),
+				Min:       int64(stat.Min),
+			},
+		}
+		if !status.Alive {
+			status.Delay = 99999999
+		}
+		result = append(result, status)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].OutboundTag < result[j].OutboundTag
+	})
+	return result
+}
+
+func (o *Observer) Type() interface{} {
+	return extension.ObservatoryType()
+}
+
+func (o *Observer) Start() error {
+	if o.config != nil && len(o.config.SubjectSelector) != 0 {
+		o.finished = done.New()
+		o.hp.StartScheduler(func() ([]string, error) {
+			hs, ok := o.ohm.(outbound.HandlerSelector)
+			if !ok {
+				return nil, newError("outbound.Manager is not a HandlerSelector")
+			}
+
+			outbounds := hs.Select(o.config.SubjectSelector)
+			return outbounds, nil
+		})
+	}
+	return nil
+}
+
+func (o *Observer) Close() error {
+	if o.finished != nil {
+		o.hp.StopScheduler()
+		return o.finished.Close()
+	}
+	return nil
+}
+
+func New(ctx context.Context, config *Config) (*Observer, error) {
+	var outboundManager outbound.Manager
+	err := core.RequireFeatures(ctx, func(om outbound.Manager) {
+		outboundManager = om
+	})
+	if err != nil {
+		return nil, newError("Cannot get depended features").Base(err)
+	}
+	hp := NewHealthPing(ctx, config.PingConfig)
+	return &Observer{
+		config: config,
+		ctx:    ctx,
+		ohm:    outboundManager,
+		hp:     hp,
+	}, nil
+}
+
+func init() {
+	common.Must(common.RegisterConfig((*Config)(nil), func(ctx context.Context, config interface{}) (interface{}, error) {
+		return New(ctx, config.(*Config))
+	}))
+}
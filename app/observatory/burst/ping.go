@@ -0,0 +1,76 @@
+package burst
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"time"
+
+	v2net "github.com/xtls/xray-core/common/net"
+	"github.com/xtls/xray-core/transport/internet/tagged"
+)
+
+type pingClient struct {
+	destination    string
+	expectedStatus int
+	httpClient     *http.Client
+}
+
+func newPingClient(ctx context.Context, destination string, timeout time.Duration, handler string, expectedStatus int) *pingClient {
+	return &pingClient{
+		destination:    destination,
+		expectedStatus: expectedStatus,
+		httpClient:     newHTTPClient(ctx, handler, timeout),
+	}
+}
+
+func newDirectPingClient(destination string, timeout time.Duration) *pingClient {
+	return &pingClient{
+		destination: destination,
+		httpClient:  &http.Client{Timeout: timeout},
+	}
+}
+
+func newHTTPClient(ctx context.Context, handler string, timeout time.Duration) *http.Client {
+	tr := &http.Transport{
+		DisableKeepAlives: true,
+		DialContext: func(_ context.Context, network, addr string) (net.Conn, error) {
+			dest, err := v2net.ParseDestination(network + ":" + addr)
+			if err != nil {
+				return nil, err
+			}
+			return tagged.Dialer(ctx, dest, handler)
+		},
+	}
+	return &http.Client{
+		Transport: tr,
+		Timeout:   timeout,
+		// don't follow redirect
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// MeasureDelay returns the time of a HEAD request to the destination, or rttFailed on failure.
+func (s *pingClient) MeasureDelay() (time.Duration, error) {
+	if s.httpClient == nil {
+		return rttFailed, newError("pingClient not initialized")
+	}
+	req, err := http.NewRequest(http.MethodHead, s.destination, nil)
+	if err != nil {
+		return rttFailed, err
+	}
+
+	start := time.Now()
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return rttFailed, err
+	}
+	// don't wait for body
+	resp.Body.Close()
+	if s.expectedStatus != 0 && resp.StatusCode != s.expectedStatus {
+		return rttFailed, newError("unexpected status ", resp.StatusCode, ", expecting ", s.expectedStatus)
+	}
+	return time.Since(start), nil
+}
@@ -58,6 +58,22 @@ func (p *Policy) overrideWith(another *Policy) {
 			Connection: another.Buffer.Connection,
 		}
 	}
+	if another.Bandwidth != nil {
+		p.Bandwidth = another.Bandwidth
+	}
+	if another.Limit != nil {
+		p.Limit = another.Limit
+	}
+}
+
+// ToCoreBandwidth converts this Policy_Bandwidth to policy.Bandwidth.
+func (b *Policy_Bandwidth) ToCoreBandwidth() policy.Bandwidth {
+	return policy.Bandwidth{
+		UplinkRate:    int64(b.GetUplinkRate()),
+		DownlinkRate:  int64(b.GetDownlinkRate()),
+		UplinkBurst:   int64(b.GetUplinkBurst()),
+		DownlinkBurst: int64(b.GetDownlinkBurst()),
+	}
 }
 
 // ToCorePolicy converts this Policy to policy.Session.
@@ -77,17 +93,35 @@ func (p *Policy) ToCorePolicy() policy.Session {
 	if p.Buffer != nil {
 		cp.Buffer.PerConnection = p.Buffer.Connection
 	}
+	if p.Bandwidth != nil {
+		cp.Bandwidth = p.Bandwidth.ToCoreBandwidth()
+	}
+	if p.Limit != nil {
+		cp.Limit = policy.Limit{
+			MaxConnections:       p.Limit.MaxConnections,
+			MaxSourceIPs:         p.Limit.MaxSourceIps,
+			Quota:                int64(p.Limit.Quota),
+			CloseOnQuotaExceeded: p.Limit.CloseOnQuotaExceeded,
+		}
+	}
 	return cp
 }
 
 // ToCorePolicy converts this SystemPolicy to policy.System.
 func (p *SystemPolicy) ToCorePolicy() policy.System {
-	return policy.System{
+	system := policy.System{
 		Stats: policy.SystemStats{
-			InboundUplink:    p.Stats.InboundUplink,
-			InboundDownlink:  p.Stats.InboundDownlink,
-			OutboundUplink:   p.Stats.OutboundUplink,
-			OutboundDownlink: p.Stats.OutboundDownlink,
+			InboundUplink:    p.Stats.GetInboundUplink(),
+			InboundDownlink:  p.Stats.GetInboundDownlink(),
+			OutboundUplink:   p.Stats.GetOutboundUplink(),
+			OutboundDownlink: p.Stats.GetOutboundDownlink(),
 		},
 	}
+	if len(p.InboundBandwidth) > 0 {
+		system.InboundBandwidth = make(map[string]policy.Bandwidth, len(p.InboundBandwidth))
+		for tag, b := range p.InboundBandwidth {
+			system.InboundBandwidth[tag] = b.ToCoreBandwidth()
+		}
+	}
+	return system
 }
@@ -2,15 +2,21 @@ package policy
 
 import (
 	"context"
+	"sync"
 
 	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/features"
 	"github.com/xtls/xray-core/features/policy"
 )
 
 // Instance is an instance of Policy manager.
 type Instance struct {
+	access sync.RWMutex
 	levels map[uint32]*Policy
 	system *SystemPolicy
+	quotas map[string]uint64
+
+	users userTracker
 }
 
 // New creates new Policy manager instance.
@@ -18,6 +24,7 @@ func New(ctx context.Context, config *Config) (*Instance, error) {
 	m := &Instance{
 		levels: make(map[uint32]*Policy),
 		system: config.System,
+		quotas: config.UserQuota,
 	}
 	if len(config.Level) > 0 {
 		for lv, p := range config.Level {
@@ -37,6 +44,9 @@ func (*Instance) Type() interface{} {
 
 // ForLevel implements policy.Manager.
 func (m *Instance) ForLevel(level uint32) policy.Session {
+	m.access.RLock()
+	defer m.access.RUnlock()
+
 	if p, ok := m.levels[level]; ok {
 		return p.ToCorePolicy()
 	}
@@ -45,12 +55,31 @@ func (m *Instance) ForLevel(level uint32) policy.Session {
 
 // ForSystem implements policy.Manager.
 func (m *Instance) ForSystem() policy.System {
+	m.access.RLock()
+	defer m.access.RUnlock()
+
 	if m.system == nil {
 		return policy.System{}
 	}
 	return m.system.ToCorePolicy()
 }
 
+// Reload implements features.Reloadable.
+func (m *Instance) Reload(f features.Feature) error {
+	n, ok := f.(*Instance)
+	if !ok {
+		return newError("not a policy manager")
+	}
+
+	m.access.Lock()
+	defer m.access.Unlock()
+
+	m.levels = n.levels
+	m.system = n.system
+	m.quotas = n.quotas
+	return nil
+}
+
 // Start implements common.Runnable.Start().
 func (m *Instance) Start() error {
 	return nil
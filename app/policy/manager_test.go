@@ -7,6 +7,7 @@ import (
 
 	. "github.com/xtls/xray-core/app/policy"
 	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/common/net"
 	"github.com/xtls/xray-core/features/policy"
 )
 
@@ -43,3 +44,102 @@ func TestPolicy(t *testing.T) {
 		}
 	}
 }
+
+func TestUserLimits(t *testing.T) {
+	manager, err := New(context.Background(), &Config{
+		Level: map[uint32]*Policy{
+			0: {
+				Limit: &Policy_Limit{
+					MaxConnections:       2,
+					MaxSourceIps:         1,
+					Quota:                1000,
+					CloseOnQuotaExceeded: true,
+				},
+			},
+		},
+		UserQuota: map[string]uint64{
+			"vip": 0,
+		},
+	})
+	common.Must(err)
+
+	ip1 := net.ParseAddress("10.0.0.1")
+	ip2 := net.ParseAddress("10.0.0.2")
+
+	s1, err := manager.OpenSession("user", 0, ip1, 1)
+	common.Must(err)
+	if _, err := manager.OpenSession("user", 0, ip2, 2); err == nil {
+		t.Error("expect too many source IPs")
+	}
+	s2, err := manager.OpenSession("user", 0, ip1, 3)
+	common.Must(err)
+	if _, err := manager.OpenSession("user", 0, ip1, 4); err == nil {
+		t.Error("expect too many connections")
+	}
+
+	usage, found := manager.GetUserUsage("user", false)
+	if !found || usage.Connections != 2 || len(usage.SourceIPs) != 1 || usage.Quota != 1000 {
+		t.Error("unexpected usage ", usage)
+	}
+
+	common.Must(s1.AddTraffic(600))
+	if err := s2.AddTraffic(600); err == nil {
+		t.Error("expect quota exceeded")
+	}
+	common.Must(s1.Close())
+	common.Must(s2.Close())
+	if _, err := manager.OpenSession("user", 0, ip2, 5); err == nil {
+		t.Error("expect quota exceeded for new session")
+	}
+
+	usage, _ = manager.GetUserUsage("user", true)
+	if usage.Traffic != 1200 || usage.Connections != 0 {
+		t.Error("unexpected usage ", usage)
+	}
+	s3, err := manager.OpenSession("user", 0, ip2, 6)
+	common.Must(err)
+	common.Must(s3.Close())
+
+	s4, err := manager.OpenSession("vip", 0, ip1, 7)
+	common.Must(err)
+	common.Must(s4.AddTraffic(2000))
+	if usages := manager.QueryUserUsage(func(string) bool { return true }, false); len(usages) != 1 || usages[0].Email != "vip" || usages[0].Traffic != 2000 {
+		t.Error("unexpected usages ", usages)
+	}
+}
+
+func TestUserLimitsConnection(t *testing.T) {
+	manager, err := New(context.Background(), &Config{
+		Level: map[uint32]*Policy{
+			0: {
+				Limit: &Policy_Limit{
+					MaxConnections: 1,
+				},
+			},
+		},
+	})
+	common.Must(err)
+
+	ip := net.ParseAddress("10.0.0.1")
+
+	// Sessions on the same inbound connection, such as mux sub-connections, count as one connection.
+	s1, err := manager.OpenSession("user", 0, ip, 1)
+	common.Must(err)
+	s2, err := manager.OpenSession("user", 0, ip, 1)
+	common.Must(err)
+	if _, err := manager.OpenSession("user", 0, ip, 2); err == nil {
+		t.Error("expect too many connections")
+	}
+	if usage, _ := manager.GetUserUsage("user", false); usage.Connections != 1 {
+		t.Error("unexpected usage ", usage)
+	}
+
+	common.Must(s1.Close())
+	if _, err := manager.OpenSession("user", 0, ip, 2); err == nil {
+		t.Error("expect connection alive until all its sessions are closed")
+	}
+	common.Must(s2.Close())
+	s3, err := manager.OpenSession("user", 0, ip, 2)
+	common.Must(err)
+	common.Must(s3.Close())
+}
@@ -0,0 +1,181 @@
+package policy
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/xtls/xray-core/common/net"
+	"github.com/xtls/xray-core/common/session"
+	"github.com/xtls/xray-core/features/policy"
+)
+
+// userState is the usage of a user.
+type userState struct {
+	// Number of sessions per inbound connection. Sessions without a connection ID are counted in anonymous.
+	conns     map[session.ID]int
+	anonymous int
+	sources   map[string]int
+	traffic   int64 // atomic
+	quota     int64 // atomic
+}
+
+// userTracker tracks the sessions and traffic of users.
+type userTracker struct {
+	access sync.Mutex
+	users  map[string]*userState
+}
+
+// connections returns the number of live inbound connections of the user.
+func (s *userState) connections() int {
+	return len(s.conns) + s.anonymous
+}
+
+type userSession struct {
+	tracker *userTracker
+	email   string
+	conn    session.ID
+	source  string
+	state   *userState
+	close   bool
+	once    sync.Once
+}
+
+// AddTraffic implements policy.UserSession.
+func (s *userSession) AddTraffic(n int64) error {
+	traffic := atomic.AddInt64(&s.state.traffic, n)
+	if quota := atomic.LoadInt64(&s.state.quota); s.close && quota > 0 && traffic > quota {
+		return newError("traffic quota of user ", s.email, " exceeded")
+	}
+	return nil
+}
+
+// Close implements policy.UserSession.
+func (s *userSession) Close() error {
+	s.once.Do(func() {
+		t := s.tracker
+		t.access.Lock()
+		defer t.access.Unlock()
+
+		if s.conn == 0 {
+			s.state.anonymous--
+		} else if s.state.conns[s.conn]--; s.state.conns[s.conn] <= 0 {
+			delete(s.state.conns, s.conn)
+		}
+		if s.source != "" {
+			s.state.sources[s.source]--
+			if s.state.sources[s.source] <= 0 {
+				delete(s.state.sources, s.source)
+			}
+		}
+		if s.state.connections() == 0 && atomic.LoadInt64(&s.state.traffic) == 0 && t.users[s.email] == s.state {
+			delete(t.users, s.email)
+		}
+	})
+	return nil
+}
+
+// OpenSession implements policy.UserTracker.
+func (m *Instance) OpenSession(email string, level uint32, source net.Address, conn session.ID) (policy.UserSession, error) {
+	limit := m.ForLevel(level).Limit
+	m.access.RLock()
+	if q, found := m.quotas[email]; found {
+		limit.Quota = int64(q)
+	}
+	m.access.RUnlock()
+
+	t := &m.users
+	t.access.Lock()
+	defer t.access.Unlock()
+
+	if t.users == nil {
+		t.users = make(map[string]*userState)
+	}
+	state, found := t.users[email]
+	if !found {
+		state = &userState{conns: make(map[session.ID]int), sources: make(map[string]int)}
+	}
+	atomic.StoreInt64(&state.quota, limit.Quota)
+
+	if limit.Quota > 0 && atomic.LoadInt64(&state.traffic) >= limit.Quota {
+		return nil, newError("traffic quota of user ", email, " exceeded")
+	}
+	// Sessions of a connection already counted, such as mux sub-connections or UDP flows, are always accepted.
+	if _, connected := state.conns[conn]; limit.MaxConnections > 0 && (conn == 0 || !connected) && state.connections() >= int(limit.MaxConnections) {
+		return nil, newError("too many connections of user ", email)
+	}
+	var ip string
+	if source != nil && source.Family().IsIP() {
+		ip = source.IP().String()
+	}
+	if _, connected := state.sources[ip]; limit.MaxSourceIPs > 0 && ip != "" && !connected && len(state.sources) >= int(limit.MaxSourceIPs) {
+		return nil, newError("too many source IPs of user ", email)
+	}
+
+	if conn == 0 {
+		state.anonymous++
+	} else {
+		state.conns[conn]++
+	}
+	if ip != "" {
+		state.sources[ip]++
+	}
+	t.users[email] = state
+	return &userSession{
+		tracker: t,
+		email:   email,
+		conn:    conn,
+		source:  ip,
+		state:   state,
+		close:   limit.CloseOnQuotaExceeded,
+	}, nil
+}
+
+// GetUserUsage returns the usage of the user with the email, and resets its traffic if reset is true.
+func (m *Instance) GetUserUsage(email string, reset bool) (policy.UserUsage, bool) {
+	t := &m.users
+	t.access.Lock()
+	defer t.access.Unlock()
+
+	state, found := t.users[email]
+	if !found {
+		return policy.UserUsage{}, false
+	}
+	return state.usage(email, reset), true
+}
+
+// QueryUserUsage returns the usage of the users whose email matches, and resets their traffic if reset is true.
+func (m *Instance) QueryUserUsage(match func(email string) bool, reset bool) []policy.UserUsage {
+	t := &m.users
+	t.access.Lock()
+	defer t.access.Unlock()
+
+	var result []policy.UserUsage
+	for email, state := range t.users {
+		if match(email) {
+			result = append(result, state.usage(email, reset))
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Email < result[j].Email
+	})
+	return result
+}
+
+func (s *userState) usage(email string, reset bool) policy.UserUsage {
+	usage := policy.UserUsage{
+		Email:       email,
+		Connections: s.connections(),
+		Quota:       atomic.LoadInt64(&s.quota),
+	}
+	if reset {
+		usage.Traffic = atomic.SwapInt64(&s.traffic, 0)
+	} else {
+		usage.Traffic = atomic.LoadInt64(&s.traffic)
+	}
+	for ip := range s.sources {
+		usage.SourceIPs = append(usage.SourceIPs, ip)
+	}
+	sort.Strings(usage.SourceIPs)
+	return usage
+}
@@ -122,6 +122,13 @@ func (s *handlerServer) AlterOutbound(ctx context.Context, request *AlterOutboun
 	return &AlterOutboundResponse{}, operation.ApplyOutbound(ctx, handler)
 }
 
+func (s *handlerServer) ReloadConfig(ctx context.Context, request *ReloadConfigRequest) (*ReloadConfigResponse, error) {
+	if err := s.s.Reload(request.Config); err != nil {
+		return nil, err
+	}
+	return &ReloadConfigResponse{}, nil
+}
+
 func (s *handlerServer) mustEmbedUnimplementedHandlerServiceServer() {}
 
 type service struct {
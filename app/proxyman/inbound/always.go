@@ -42,10 +42,11 @@ func getStatCounter(v *core.Instance, tag string) (stats.Counter, stats.Counter)
 }
 
 type AlwaysOnInboundHandler struct {
-	proxy   proxy.Inbound
-	workers []worker
-	mux     *mux.Server
-	tag     string
+	proxy       proxy.Inbound
+	workers     []worker
+	mux         *mux.Server
+	tag         string
+	connections connectionCounter
 }
 
 func NewAlwaysOnInboundHandler(ctx context.Context, tag string, receiverConfig *proxyman.ReceiverConfig, proxyConfig interface{}) (*AlwaysOnInboundHandler, error) {
@@ -100,6 +101,7 @@ func NewAlwaysOnInboundHandler(ctx context.Context, tag string, receiverConfig *
 				sniffingConfig:  receiverConfig.GetEffectiveSniffingSettings(),
 				uplinkCounter:   uplinkCounter,
 				downlinkCounter: downlinkCounter,
+				connections:     &h.connections,
 				ctx:             ctx,
 			}
 			h.workers = append(h.workers, worker)
@@ -122,6 +124,7 @@ func NewAlwaysOnInboundHandler(ctx context.Context, tag string, receiverConfig *
 						sniffingConfig:  receiverConfig.GetEffectiveSniffingSettings(),
 						uplinkCounter:   uplinkCounter,
 						downlinkCounter: downlinkCounter,
+						connections:     &h.connections,
 						ctx:             ctx,
 					}
 					h.workers = append(h.workers, worker)
@@ -137,6 +140,7 @@ func NewAlwaysOnInboundHandler(ctx context.Context, tag string, receiverConfig *
 						sniffingConfig:  receiverConfig.GetEffectiveSniffingSettings(),
 						uplinkCounter:   uplinkCounter,
 						downlinkCounter: downlinkCounter,
+						connections:     &h.connections,
 						stream:          mss,
 						ctx:             ctx,
 					}
@@ -184,6 +188,11 @@ func (h *AlwaysOnInboundHandler) Tag() string {
 	return h.tag
 }
 
+// ConnectionCount returns the number of live connections of this handler.
+func (h *AlwaysOnInboundHandler) ConnectionCount() int64 {
+	return h.connections.Value()
+}
+
 func (h *AlwaysOnInboundHandler) GetInbound() proxy.Inbound {
 	return h.proxy
 }
@@ -28,6 +28,7 @@ type DynamicInboundHandler struct {
 	lastRefresh    time.Time
 	mux            *mux.Server
 	task           *task.Periodic
+	connections    connectionCounter
 
 	ctx context.Context
 }
@@ -140,6 +141,7 @@ func (h *DynamicInboundHandler) refresh() error {
 				sniffingConfig:  h.receiverConfig.GetEffectiveSniffingSettings(),
 				uplinkCounter:   uplinkCounter,
 				downlinkCounter: downlinkCounter,
+				connections:     &h.connections,
 				ctx:             h.ctx,
 			}
 			if err := worker.Start(); err != nil {
@@ -159,6 +161,7 @@ func (h *DynamicInboundHandler) refresh() error {
 				sniffingConfig:  h.receiverConfig.GetEffectiveSniffingSettings(),
 				uplinkCounter:   uplinkCounter,
 				downlinkCounter: downlinkCounter,
+				connections:     &h.connections,
 				stream:          h.streamSettings,
 				ctx:             h.ctx,
 			}
@@ -204,3 +207,8 @@ func (h *DynamicInboundHandler) GetRandomInboundProxy() (interface{}, net.Port,
 func (h *DynamicInboundHandler) Tag() string {
 	return h.tag
 }
+
+// ConnectionCount returns the number of live connections of this handler.
+func (h *DynamicInboundHandler) ConnectionCount() int64 {
+	return h.connections.Value()
+}
@@ -69,6 +69,21 @@ func (m *Manager) GetHandler(ctx context.Context, tag string) (inbound.Handler,
 	return handler, nil
 }
 
+// ListHandlers implements inbound.Manager.
+func (m *Manager) ListHandlers(ctx context.Context) []inbound.Handler {
+	m.access.RLock()
+	defer m.access.RUnlock()
+
+	response := make([]inbound.Handler, len(m.untaggedHandler))
+	copy(response, m.untaggedHandler)
+
+	for _, v := range m.taggedHandlers {
+		response = append(response, v)
+	}
+
+	return response
+}
+
 // RemoveHandler implements inbound.Manager.
 func (m *Manager) RemoveHandler(ctx context.Context, tag string) error {
 	if tag == "" {
@@ -24,6 +24,31 @@ import (
 	"github.com/xtls/xray-core/transport/pipe"
 )
 
+// connectionCounter counts the live connections of an inbound handler. A nil *connectionCounter counts nothing.
+type connectionCounter struct {
+	count int64
+}
+
+func (c *connectionCounter) inc() {
+	if c != nil {
+		atomic.AddInt64(&c.count, 1)
+	}
+}
+
+func (c *connectionCounter) dec() {
+	if c != nil {
+		atomic.AddInt64(&c.count, -1)
+	}
+}
+
+// Value returns the number of live connections.
+func (c *connectionCounter) Value() int64 {
+	if c == nil {
+		return 0
+	}
+	return atomic.LoadInt64(&c.count)
+}
+
 type worker interface {
 	Start() error
 	Close() error
@@ -42,6 +67,7 @@ type tcpWorker struct {
 	sniffingConfig  *proxyman.SniffingConfig
 	uplinkCounter   stats.Counter
 	downlinkCounter stats.Counter
+	connections     *connectionCounter
 
 	hub internet.Listener
 
@@ -56,6 +82,9 @@ func getTProxyType(s *internet.MemoryStreamConfig) internet.SocketConfig_TProxyM
 }
 
 func (w *tcpWorker) callback(conn stat.Connection) {
+	w.connections.inc()
+	defer w.connections.dec()
+
 	ctx, cancel := context.WithCancel(w.ctx)
 	sid := session.NewID()
 	ctx = session.ContextWithID(ctx, sid)
@@ -244,6 +273,7 @@ type udpWorker struct {
 	sniffingConfig  *proxyman.SniffingConfig
 	uplinkCounter   stats.Counter
 	downlinkCounter stats.Counter
+	connections     *connectionCounter
 
 	checker    *task.Periodic
 	activeConn map[connID]*udpConn
@@ -304,6 +334,9 @@ func (w *udpWorker) callback(b *buf.Buffer, source net.Destination, originalDest
 		common.Must(w.checker.Start())
 
 		go func() {
+			w.connections.inc()
+			defer w.connections.dec()
+
 			ctx := w.ctx
 			sid := session.NewID()
 			ctx = session.ContextWithID(ctx, sid)
@@ -443,6 +476,7 @@ type dsWorker struct {
 	sniffingConfig  *proxyman.SniffingConfig
 	uplinkCounter   stats.Counter
 	downlinkCounter stats.Counter
+	connections     *connectionCounter
 
 	hub internet.Listener
 
@@ -450,6 +484,9 @@ type dsWorker struct {
 }
 
 func (w *dsWorker) callback(conn stat.Connection) {
+	w.connections.inc()
+	defer w.connections.dec()
+
 	ctx, cancel := context.WithCancel(w.ctx)
 	sid := session.NewID()
 	ctx = session.ContextWithID(ctx, sid)
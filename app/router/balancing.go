@@ -24,9 +24,11 @@ func (s *RandomStrategy) PickOutbound(tags []string) string {
 }
 
 type Balancer struct {
-	selectors []string
-	strategy  BalancingStrategy
-	ohm       outbound.Manager
+	selectors   []string
+	strategy    BalancingStrategy
+	ohm         outbound.Manager
+	fallbackTag string
+	config      *BalancingRule
 }
 
 func (b *Balancer) PickOutbound() (string, error) {
@@ -36,10 +38,18 @@ func (b *Balancer) PickOutbound() (string, error) {
 	}
 	tags := hs.Select(b.selectors)
 	if len(tags) == 0 {
+		if b.fallbackTag != "" {
+			newError("no available outbounds selected, use fallback outbound ", b.fallbackTag).AtInfo().WriteToLog()
+			return b.fallbackTag, nil
+		}
 		return "", newError("no available outbounds selected")
 	}
 	tag := b.strategy.PickOutbound(tags)
 	if tag == "" {
+		if b.fallbackTag != "" {
+			newError("balancing strategy returns empty tag, use fallback outbound ", b.fallbackTag).AtInfo().WriteToLog()
+			return b.fallbackTag, nil
+		}
 		return "", newError("balancing strategy returns empty tag")
 	}
 	return tag, nil
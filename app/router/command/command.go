@@ -6,6 +6,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/xtls/xray-core/app/router"
 	"github.com/xtls/xray-core/common"
 	"github.com/xtls/xray-core/core"
 	"github.com/xtls/xray-core/features/routing"
@@ -72,6 +73,85 @@ func (s *routingServer) SubscribeRoutingStats(request *SubscribeRoutingStatsRequ
 	}
 }
 
+// ruleManager is implemented by routers that allow changing rules and balancers at runtime.
+type ruleManager interface {
+	AddRule(config *router.RoutingRule, shouldAppend bool) error
+	RemoveRule(ruleTag string) error
+	ListRules() []*router.RoutingRule
+	AddBalancer(config *router.BalancingRule) error
+	RemoveBalancer(tag string) error
+	ListBalancers() []*router.BalancingRule
+}
+
+func (s *routingServer) ruleManager() (ruleManager, error) {
+	rm, ok := s.router.(ruleManager)
+	if !ok {
+		return nil, newError("router doesn't support rule management")
+	}
+	return rm, nil
+}
+
+func (s *routingServer) AddRule(ctx context.Context, request *AddRuleRequest) (*AddRuleResponse, error) {
+	if request.Rule == nil {
+		return nil, newError("Invalid rule.")
+	}
+	rm, err := s.ruleManager()
+	if err != nil {
+		return nil, err
+	}
+	if err := rm.AddRule(request.Rule, request.ShouldAppend); err != nil {
+		return nil, err
+	}
+	return &AddRuleResponse{}, nil
+}
+
+func (s *routingServer) RemoveRule(ctx context.Context, request *RemoveRuleRequest) (*RemoveRuleResponse, error) {
+	rm, err := s.ruleManager()
+	if err != nil {
+		return nil, err
+	}
+	if err := rm.RemoveRule(request.RuleTag); err != nil {
+		return nil, err
+	}
+	return &RemoveRuleResponse{}, nil
+}
+
+func (s *routingServer) ListRules(ctx context.Context, request *ListRulesRequest) (*ListRulesResponse, error) {
+	rm, err := s.ruleManager()
+	if err != nil {
+		return nil, err
+	}
+	return &ListRulesResponse{
+		Rules:     rm.ListRules(),
+		Balancers: rm.ListBalancers(),
+	}, nil
+}
+
+func (s *routingServer) AddBalancer(ctx context.Context, request *AddBalancerRequest) (*AddBalancerResponse, error) {
+	if request.Balancer == nil {
+		return nil, newError("Invalid balancer.")
+	}
+	rm, err := s.ruleManager()
+	if err != nil {
+		return nil, err
+	}
+	if err := rm.AddBalancer(request.Balancer); err != nil {
+		return nil, err
+	}
+	return &AddBalancerResponse{}, nil
+}
+
+func (s *routingServer) RemoveBalancer(ctx context.Context, request *RemoveBalancerRequest) (*RemoveBalancerResponse, error) {
+	rm, err := s.ruleManager()
+	if err != nil {
+		return nil, err
+	}
+	if err := rm.RemoveBalancer(request.Tag); err != nil {
+		return nil, err
+	}
+	return &RemoveBalancerResponse{}, nil
+}
+
 func (s *routingServer) mustEmbedUnimplementedRoutingServiceServer() {}
 
 type service struct {
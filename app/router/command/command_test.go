@@ -317,6 +317,22 @@ func TestSerivceTestRoute(t *testing.T) {
 				Networks:  []net.Network{net.Network_UDP, net.Network_TCP},
 				TargetTag: &router.RoutingRule_Tag{Tag: "out"},
 			},
+			{
+				Alpn:      []string{"h2"},
+				TargetTag: &router.RoutingRule_Tag{Tag: "alpn"},
+			},
+			{
+				TlsFingerprint: []string{"t13d1516h2_"},
+				TargetTag:      &router.RoutingRule_Tag{Tag: "fingerprint"},
+			},
+			{
+				ProcessName: []string{"curl"},
+				TargetTag:   &router.RoutingRule_Tag{Tag: "process"},
+			},
+			{
+				ProcessUid: []uint32{0},
+				TargetTag:  &router.RoutingRule_Tag{Tag: "root"},
+			},
 		},
 	}, mocks.NewDNSClient(mockCtl), mocks.NewOutboundManager(mockCtl)))
 
@@ -352,6 +368,10 @@ func TestSerivceTestRoute(t *testing.T) {
 			{Network: net.Network_UDP, Protocol: "bittorrent", OutboundTag: "blocked"},
 			{User: "example@example.com", OutboundTag: "out"},
 			{SourceIPs: [][]byte{{127, 0, 0, 1}}, Attributes: map[string]string{"attr": "value"}, OutboundTag: "out"},
+			{ALPN: []string{"http/1.1", "h2"}, OutboundTag: "alpn"},
+			{JA3: "cd08e31494f9531f560d64c695473da9", JA4: "t13d1516h2_8daaf6152771_02713d6af862", OutboundTag: "fingerprint"},
+			{ProcessName: "curl", ProcessUID: 1000, OutboundTag: "process"},
+			{ProcessName: "wget", ProcessUID: 0, OutboundTag: "root"},
 		}
 
 		// Test simple TestRoute
@@ -28,6 +28,12 @@ func (c routingContext) GetTargetPort() net.Port {
 	return net.Port(c.RoutingContext.GetTargetPort())
 }
 
+// GetProcessUID implements routing.Context. The user ID is valid only if the process name is set, as 0 is the ID of
+// root.
+func (c routingContext) GetProcessUID() (uint32, bool) {
+	return c.RoutingContext.GetProcessUID(), len(c.RoutingContext.GetProcessName()) > 0
+}
+
 // GetSkipDNSResolve is a mock implementation here to match the interface,
 // SkipDNSResolve is set from dns module, no use if coming from a protobuf object?
 // TODO: please confirm @Vigilans
@@ -46,18 +52,23 @@ func AsRoutingRoute(r *RoutingContext) routing.Route {
 }
 
 var fieldMap = map[string]func(*RoutingContext, routing.Route){
-	"inbound":        func(s *RoutingContext, r routing.Route) { s.InboundTag = r.GetInboundTag() },
-	"network":        func(s *RoutingContext, r routing.Route) { s.Network = r.GetNetwork() },
-	"ip_source":      func(s *RoutingContext, r routing.Route) { s.SourceIPs = mapIPsToBytes(r.GetSourceIPs()) },
-	"ip_target":      func(s *RoutingContext, r routing.Route) { s.TargetIPs = mapIPsToBytes(r.GetTargetIPs()) },
-	"port_source":    func(s *RoutingContext, r routing.Route) { s.SourcePort = uint32(r.GetSourcePort()) },
-	"port_target":    func(s *RoutingContext, r routing.Route) { s.TargetPort = uint32(r.GetTargetPort()) },
-	"domain":         func(s *RoutingContext, r routing.Route) { s.TargetDomain = r.GetTargetDomain() },
-	"protocol":       func(s *RoutingContext, r routing.Route) { s.Protocol = r.GetProtocol() },
-	"user":           func(s *RoutingContext, r routing.Route) { s.User = r.GetUser() },
-	"attributes":     func(s *RoutingContext, r routing.Route) { s.Attributes = r.GetAttributes() },
-	"outbound_group": func(s *RoutingContext, r routing.Route) { s.OutboundGroupTags = r.GetOutboundGroupTags() },
-	"outbound":       func(s *RoutingContext, r routing.Route) { s.OutboundTag = r.GetOutboundTag() },
+	"inbound":         func(s *RoutingContext, r routing.Route) { s.InboundTag = r.GetInboundTag() },
+	"network":         func(s *RoutingContext, r routing.Route) { s.Network = r.GetNetwork() },
+	"ip_source":       func(s *RoutingContext, r routing.Route) { s.SourceIPs = mapIPsToBytes(r.GetSourceIPs()) },
+	"ip_target":       func(s *RoutingContext, r routing.Route) { s.TargetIPs = mapIPsToBytes(r.GetTargetIPs()) },
+	"port_source":     func(s *RoutingContext, r routing.Route) { s.SourcePort = uint32(r.GetSourcePort()) },
+	"port_target":     func(s *RoutingContext, r routing.Route) { s.TargetPort = uint32(r.GetTargetPort()) },
+	"domain":          func(s *RoutingContext, r routing.Route) { s.TargetDomain = r.GetTargetDomain() },
+	"protocol":        func(s *RoutingContext, r routing.Route) { s.Protocol = r.GetProtocol() },
+	"user":            func(s *RoutingContext, r routing.Route) { s.User = r.GetUser() },
+	"attributes":      func(s *RoutingContext, r routing.Route) { s.Attributes = r.GetAttributes() },
+	"alpn":            func(s *RoutingContext, r routing.Route) { s.ALPN = r.GetALPN() },
+	"fingerprint_ja3": func(s *RoutingContext, r routing.Route) { s.JA3 = r.GetJA3() },
+	"fingerprint_ja4": func(s *RoutingContext, r routing.Route) { s.JA4 = r.GetJA4() },
+	"process_name":    func(s *RoutingContext, r routing.Route) { s.ProcessName = r.GetProcessName() },
+	"process_uid":     func(s *RoutingContext, r routing.Route) { s.ProcessUID, _ = r.GetProcessUID() },
+	"outbound_group":  func(s *RoutingContext, r routing.Route) { s.OutboundGroupTags = r.GetOutboundGroupTags() },
+	"outbound":        func(s *RoutingContext, r routing.Route) { s.OutboundTag = r.GetOutboundTag() },
 }
 
 // AsProtobufMessage takes selectors of fields and returns a function to convert routing.Route to protobuf RoutingContext.
@@ -283,6 +283,117 @@ func (m *ProtocolMatcher) Apply(ctx routing.Context) bool {
 	return false
 }
 
+type ALPNMatcher struct {
+	protocols []string
+}
+
+func NewALPNMatcher(protocols []string) *ALPNMatcher {
+	pCopy := make([]string, 0, len(protocols))
+	for _, p := range protocols {
+		if len(p) > 0 {
+			pCopy = append(pCopy, p)
+		}
+	}
+	return &ALPNMatcher{
+		protocols: pCopy,
+	}
+}
+
+// Apply implements Condition.
+func (m *ALPNMatcher) Apply(ctx routing.Context) bool {
+	for _, alpn := range ctx.GetALPN() {
+		for _, p := range m.protocols {
+			if p == alpn {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+type TLSFingerprintMatcher struct {
+	fingerprints []string
+}
+
+func NewTLSFingerprintMatcher(fingerprints []string) *TLSFingerprintMatcher {
+	fCopy := make([]string, 0, len(fingerprints))
+	for _, f := range fingerprints {
+		if len(f) > 0 {
+			fCopy = append(fCopy, strings.ToLower(f))
+		}
+	}
+	return &TLSFingerprintMatcher{
+		fingerprints: fCopy,
+	}
+}
+
+// Apply implements Condition. A fingerprint matches the JA3 hash, or a prefix of the JA4 fingerprint, as JA4 is
+// designed to be matched in parts.
+func (m *TLSFingerprintMatcher) Apply(ctx routing.Context) bool {
+	ja3 := ctx.GetJA3()
+	ja4 := ctx.GetJA4()
+	for _, f := range m.fingerprints {
+		if (len(ja3) > 0 && f == ja3) || (len(ja4) > 0 && strings.HasPrefix(ja4, f)) {
+			return true
+		}
+	}
+	return false
+}
+
+type ProcessNameMatcher struct {
+	names []string
+}
+
+func NewProcessNameMatcher(names []string) *ProcessNameMatcher {
+	nCopy := make([]string, 0, len(names))
+	for _, n := range names {
+		if len(n) > 0 {
+			nCopy = append(nCopy, n)
+		}
+	}
+	return &ProcessNameMatcher{
+		names: nCopy,
+	}
+}
+
+// Apply implements Condition.
+func (m *ProcessNameMatcher) Apply(ctx routing.Context) bool {
+	name := ctx.GetProcessName()
+	if len(name) == 0 {
+		return false
+	}
+	for _, n := range m.names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+type ProcessUIDMatcher struct {
+	uids []uint32
+}
+
+func NewProcessUIDMatcher(uids []uint32) *ProcessUIDMatcher {
+	return &ProcessUIDMatcher{
+		uids: uids,
+	}
+}
+
+// Apply implements Condition.
+func (m *ProcessUIDMatcher) Apply(ctx routing.Context) bool {
+	uid, found := ctx.GetProcessUID()
+	if !found {
+		return false
+	}
+	for _, u := range m.uids {
+		if u == uid {
+			return true
+		}
+	}
+	return false
+}
+
 type AttributeMatcher struct {
 	program *starlark.Program
 }
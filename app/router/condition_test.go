@@ -234,6 +234,44 @@ func TestRoutingRule(t *testing.T) {
 				},
 			},
 		},
+		{
+			rule: &RoutingRule{
+				Alpn: []string{"h2", "h3"},
+			},
+			test: []ruleTest{
+				{
+					input:  withContent(&session.Content{ALPN: []string{"http/1.1", "h2"}}),
+					output: true,
+				},
+				{
+					input:  withContent(&session.Content{ALPN: []string{"http/1.1"}}),
+					output: false,
+				},
+				{
+					input:  withContent(&session.Content{}),
+					output: false,
+				},
+			},
+		},
+		{
+			rule: &RoutingRule{
+				TlsFingerprint: []string{"cd08e31494f9531f560d64c695473da9", "t13d1516h2_8daaf6152771_"},
+			},
+			test: []ruleTest{
+				{
+					input:  withContent(&session.Content{JA3: "cd08e31494f9531f560d64c695473da9"}),
+					output: true,
+				},
+				{
+					input:  withContent(&session.Content{JA4: "t13d1516h2_8daaf6152771_02713d6af862"}),
+					output: true,
+				},
+				{
+					input:  withContent(&session.Content{JA3: "b32309a26951912be7dba376398abc3b", JA4: "t13d1517h2_8daaf6152771_b1ff8ab2d16f"}),
+					output: false,
+				},
+			},
+		},
 		{
 			rule: &RoutingRule{
 				InboundTag: []string{"test", "test1"},
@@ -1,6 +1,7 @@
 package router
 
 import (
+	"github.com/golang/protobuf/proto"
 	"github.com/xtls/xray-core/common/net"
 	"github.com/xtls/xray-core/features/outbound"
 	"github.com/xtls/xray-core/features/routing"
@@ -47,8 +48,11 @@ func (l *CIDRList) Swap(i int, j int) {
 
 type Rule struct {
 	Tag       string
+	RuleTag   string
 	Balancer  *Balancer
 	Condition Condition
+
+	config *RoutingRule
 }
 
 func (r *Rule) GetTag() (string, error) {
@@ -142,6 +146,46 @@ func (rr *RoutingRule) BuildCondition() (Condition, error) {
 		conds.Add(NewProtocolMatcher(rr.Protocol))
 	}
 
+	if len(rr.DomainRuleSet) > 0 {
+		cond, err := NewDomainRuleSetMatcher(rr.DomainRuleSet)
+		if err != nil {
+			return nil, newError("failed to build domain rule set condition").Base(err)
+		}
+		conds.Add(cond)
+	}
+
+	if len(rr.IpRuleSet) > 0 {
+		cond, err := NewIPRuleSetMatcher(rr.IpRuleSet, false)
+		if err != nil {
+			return nil, newError("failed to build IP rule set condition").Base(err)
+		}
+		conds.Add(cond)
+	}
+
+	if len(rr.SourceIpRuleSet) > 0 {
+		cond, err := NewIPRuleSetMatcher(rr.SourceIpRuleSet, true)
+		if err != nil {
+			return nil, newError("failed to build source IP rule set condition").Base(err)
+		}
+		conds.Add(cond)
+	}
+
+	if len(rr.Alpn) > 0 {
+		conds.Add(NewALPNMatcher(rr.Alpn))
+	}
+
+	if len(rr.TlsFingerprint) > 0 {
+		conds.Add(NewTLSFingerprintMatcher(rr.TlsFingerprint))
+	}
+
+	if len(rr.ProcessName) > 0 {
+		conds.Add(NewProcessNameMatcher(rr.ProcessName))
+	}
+
+	if len(rr.ProcessUid) > 0 {
+		conds.Add(NewProcessUIDMatcher(rr.ProcessUid))
+	}
+
 	if len(rr.Attributes) > 0 {
 		cond, err := NewAttributeMatcher(rr.Attributes)
 		if err != nil {
@@ -158,21 +202,43 @@ func (rr *RoutingRule) BuildCondition() (Condition, error) {
 }
 
 func (br *BalancingRule) Build(ohm outbound.Manager) (*Balancer, error) {
+	var settings proto.Message
+	if br.StrategySettings != nil {
+		var err error
+		settings, err = br.StrategySettings.GetInstance()
+		if err != nil {
+			return nil, newError("failed to parse strategy settings of balancer ", br.Tag).Base(err)
+		}
+	}
+
+	var strategy BalancingStrategy
 	switch br.Strategy {
 	case "leastPing":
-		return &Balancer{
-			selectors: br.OutboundSelector,
-			strategy:  &LeastPingStrategy{},
-			ohm:       ohm,
-		}, nil
+		strategy = &LeastPingStrategy{}
+	case "roundRobin":
+		s, _ := settings.(*StrategyRoundRobinConfig)
+		rr, err := NewRoundRobinStrategy(s)
+		if err != nil {
+			return nil, err
+		}
+		strategy = rr
+	case "leastLoad":
+		s, _ := settings.(*StrategyLeastLoadConfig)
+		ll, err := NewLeastLoadStrategy(s)
+		if err != nil {
+			return nil, err
+		}
+		strategy = ll
 	case "random":
 		fallthrough
 	default:
-		return &Balancer{
-			selectors: br.OutboundSelector,
-			strategy:  &RandomStrategy{},
-			ohm:       ohm,
-		}, nil
-
+		strategy = &RandomStrategy{}
 	}
+
+	return &Balancer{
+		selectors:   br.OutboundSelector,
+		strategy:    strategy,
+		ohm:         ohm,
+		fallbackTag: br.FallbackTag,
+	}, nil
 }
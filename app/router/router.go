@@ -4,9 +4,12 @@ package router
 
 import (
 	"context"
+	"sort"
+	"sync"
 
 	"github.com/xtls/xray-core/common"
 	"github.com/xtls/xray-core/core"
+	"github.com/xtls/xray-core/features"
 	"github.com/xtls/xray-core/features/dns"
 	"github.com/xtls/xray-core/features/outbound"
 	"github.com/xtls/xray-core/features/routing"
@@ -15,10 +18,13 @@ import (
 
 // Router is an implementation of routing.Router.
 type Router struct {
+	access         sync.RWMutex
 	domainStrategy Config_DomainStrategy
 	rules          []*Rule
 	balancers      map[string]*Balancer
 	dns            dns.Client
+	ctx            context.Context
+	ohm            outbound.Manager
 }
 
 // Route is an implementation of routing.Route.
@@ -26,47 +32,216 @@ type Route struct {
 	routing.Context
 	outboundGroupTags []string
 	outboundTag       string
+	ruleTag           string
 }
 
 // Init initializes the Router.
 func (r *Router) Init(ctx context.Context, config *Config, d dns.Client, ohm outbound.Manager) error {
 	r.domainStrategy = config.DomainStrategy
 	r.dns = d
+	r.ctx = ctx
+	r.ohm = ohm
 
 	r.balancers = make(map[string]*Balancer, len(config.BalancingRule))
 	for _, rule := range config.BalancingRule {
-		balancer, err := rule.Build(ohm)
+		balancer, err := r.buildBalancer(rule)
 		if err != nil {
 			return err
 		}
-		balancer.InjectContext(ctx)
 		r.balancers[rule.Tag] = balancer
 	}
 
 	r.rules = make([]*Rule, 0, len(config.Rule))
 	for _, rule := range config.Rule {
-		cond, err := rule.BuildCondition()
+		if rule.RuleTag != "" && r.findRule(r.rules, rule.RuleTag) >= 0 {
+			return newError("duplicate rule tag ", rule.RuleTag)
+		}
+		rr, err := r.buildRule(rule, r.balancers)
 		if err != nil {
 			return err
 		}
-		rr := &Rule{
-			Condition: cond,
-			Tag:       rule.GetTag(),
+		r.rules = append(r.rules, rr)
+	}
+
+	return nil
+}
+
+func (r *Router) buildBalancer(config *BalancingRule) (*Balancer, error) {
+	balancer, err := config.Build(r.ohm)
+	if err != nil {
+		return nil, err
+	}
+	balancer.config = config
+	balancer.InjectContext(r.ctx)
+	return balancer, nil
+}
+
+func (r *Router) buildRule(config *RoutingRule, balancers map[string]*Balancer) (*Rule, error) {
+	cond, err := config.BuildCondition()
+	if err != nil {
+		return nil, err
+	}
+	rr := &Rule{
+		Condition: cond,
+		Tag:       config.GetTag(),
+		RuleTag:   config.RuleTag,
+		config:    config,
+	}
+	btag := config.GetBalancingTag()
+	if len(btag) > 0 {
+		brule, found := balancers[btag]
+		if !found {
+			return nil, newError("balancer ", btag, " not found")
+		}
+		rr.Balancer = brule
+	}
+	return rr, nil
+}
+
+// findRule returns the index of the rule with the given tag, or -1 if there is none.
+func (*Router) findRule(rules []*Rule, ruleTag string) int {
+	for i, rule := range rules {
+		if rule.RuleTag == ruleTag {
+			return i
 		}
-		btag := rule.GetBalancingTag()
-		if len(btag) > 0 {
-			brule, found := r.balancers[btag]
-			if !found {
-				return newError("balancer ", btag, " not found")
+	}
+	return -1
+}
+
+// AddRule adds a routing rule. If a rule with the same rule tag already exists, it is replaced in place.
+// Otherwise the rule is appended to or prepended to the rule list, depending on shouldAppend.
+func (r *Router) AddRule(config *RoutingRule, shouldAppend bool) error {
+	r.access.Lock()
+	defer r.access.Unlock()
+
+	rr, err := r.buildRule(config, r.balancers)
+	if err != nil {
+		return err
+	}
+
+	rules := make([]*Rule, 0, len(r.rules)+1)
+	if idx := r.findRule(r.rules, config.RuleTag); config.RuleTag != "" && idx >= 0 {
+		rules = append(rules, r.rules...)
+		rules[idx] = rr
+	} else if shouldAppend {
+		rules = append(rules, r.rules...)
+		rules = append(rules, rr)
+	} else {
+		rules = append(rules, rr)
+		rules = append(rules, r.rules...)
+	}
+	r.rules = rules
+	return nil
+}
+
+// RemoveRule removes the routing rule with the given rule tag.
+func (r *Router) RemoveRule(ruleTag string) error {
+	if ruleTag == "" {
+		return newError("empty rule tag")
+	}
+
+	r.access.Lock()
+	defer r.access.Unlock()
+
+	idx := r.findRule(r.rules, ruleTag)
+	if idx < 0 {
+		return newError("rule ", ruleTag, " not found")
+	}
+	rules := make([]*Rule, 0, len(r.rules)-1)
+	rules = append(rules, r.rules[:idx]...)
+	rules = append(rules, r.rules[idx+1:]...)
+	r.rules = rules
+	return nil
+}
+
+// ListRules returns the configs of all routing rules, in matching order.
+func (r *Router) ListRules() []*RoutingRule {
+	r.access.RLock()
+	defer r.access.RUnlock()
+
+	configs := make([]*RoutingRule, 0, len(r.rules))
+	for _, rule := range r.rules {
+		configs = append(configs, rule.config)
+	}
+	return configs
+}
+
+// AddBalancer adds a balancer. If a balancer with the same tag already exists, it is replaced,
+// and all rules pointing to it switch to the new one.
+func (r *Router) AddBalancer(config *BalancingRule) error {
+	if config.Tag == "" {
+		return newError("empty balancer tag")
+	}
+
+	r.access.Lock()
+	defer r.access.Unlock()
+
+	balancer, err := r.buildBalancer(config)
+	if err != nil {
+		return err
+	}
+
+	balancers := make(map[string]*Balancer, len(r.balancers)+1)
+	for tag, b := range r.balancers {
+		balancers[tag] = b
+	}
+	balancers[config.Tag] = balancer
+
+	if _, found := r.balancers[config.Tag]; found {
+		rules := make([]*Rule, 0, len(r.rules))
+		for _, rule := range r.rules {
+			if rule.config.GetBalancingTag() == config.Tag {
+				nr := *rule
+				nr.Balancer = balancer
+				rule = &nr
 			}
-			rr.Balancer = brule
+			rules = append(rules, rule)
+		}
+		r.rules = rules
+	}
+	r.balancers = balancers
+	return nil
+}
+
+// RemoveBalancer removes the balancer with the given tag. Balancers still referenced by a rule can't be removed.
+func (r *Router) RemoveBalancer(tag string) error {
+	r.access.Lock()
+	defer r.access.Unlock()
+
+	if _, found := r.balancers[tag]; !found {
+		return newError("balancer ", tag, " not found")
+	}
+	for _, rule := range r.rules {
+		if rule.config.GetBalancingTag() == tag {
+			return newError("balancer ", tag, " is still in use")
 		}
-		r.rules = append(r.rules, rr)
 	}
 
+	balancers := make(map[string]*Balancer, len(r.balancers))
+	for t, b := range r.balancers {
+		if t != tag {
+			balancers[t] = b
+		}
+	}
+	r.balancers = balancers
 	return nil
 }
 
+// ListBalancers returns the configs of all balancers, sorted by tag.
+func (r *Router) ListBalancers() []*BalancingRule {
+	r.access.RLock()
+	defer r.access.RUnlock()
+
+	configs := make([]*BalancingRule, 0, len(r.balancers))
+	for _, balancer := range r.balancers {
+		configs = append(configs, balancer.config)
+	}
+	sort.Slice(configs, func(i, j int) bool {
+		return configs[i].Tag < configs[j].Tag
+	})
+	return configs
+}
+
 // PickRoute implements routing.Router.
 func (r *Router) PickRoute(ctx routing.Context) (routing.Route, error) {
 	rule, ctx, err := r.pickRouteInternal(ctx)
@@ -77,7 +252,7 @@ func (r *Router) PickRoute(ctx routing.Context) (routing.Route, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &Route{Context: ctx, outboundTag: tag}, nil
+	return &Route{Context: ctx, outboundTag: tag, ruleTag: rule.RuleTag}, nil
 }
 
 func (r *Router) pickRouteInternal(ctx routing.Context) (*Rule, routing.Context, error) {
@@ -86,24 +261,30 @@ func (r *Router) pickRouteInternal(ctx routing.Context) (*Rule, routing.Context,
 	// this prevents cycle resolving dead loop
 	skipDNSResolve := ctx.GetSkipDNSResolve()
 
-	if r.domainStrategy == Config_IpOnDemand && !skipDNSResolve {
+	// Rules are replaced as a whole on change, so a snapshot is enough.
+	r.access.RLock()
+	domainStrategy := r.domainStrategy
+	rules := r.rules
+	r.access.RUnlock()
+
+	if domainStrategy == Config_IpOnDemand && !skipDNSResolve {
 		ctx = routing_dns.ContextWithDNSClient(ctx, r.dns)
 	}
 
-	for _, rule := range r.rules {
+	for _, rule := range rules {
 		if rule.Apply(ctx) {
 			return rule, ctx, nil
 		}
 	}
 
-	if r.domainStrategy != Config_IpIfNonMatch || len(ctx.GetTargetDomain()) == 0 || skipDNSResolve {
+	if domainStrategy != Config_IpIfNonMatch || len(ctx.GetTargetDomain()) == 0 || skipDNSResolve {
 		return nil, ctx, common.ErrNoClue
 	}
 
 	ctx = routing_dns.ContextWithDNSClient(ctx, r.dns)
 
 	// Try applying rules again if we have IPs.
-	for _, rule := range r.rules {
+	for _, rule := range rules {
 		if rule.Apply(ctx) {
 			return rule, ctx, nil
 		}
@@ -112,6 +293,24 @@ func (r *Router) pickRouteInternal(ctx routing.Context) (*Rule, routing.Context,
 	return nil, ctx, common.ErrNoClue
 }
 
+// Reload implements features.Reloadable.
+func (r *Router) Reload(f features.Feature) error {
+	nr, ok := f.(*Router)
+	if !ok {
+		return newError("not a router")
+	}
+
+	nr.access.RLock()
+	defer nr.access.RUnlock()
+	r.access.Lock()
+	defer r.access.Unlock()
+
+	r.domainStrategy = nr.domainStrategy
+	r.rules = nr.rules
+	r.balancers = nr.balancers
+	return nil
+}
+
 // Start implements common.Runnable.
 func (*Router) Start() error {
 	return nil
@@ -137,6 +336,11 @@ func (r *Route) GetOutboundTag() string {
 	return r.outboundTag
 }
 
+// GetRuleTag returns the tag of the rule that matched, if any.
+func (r *Route) GetRuleTag() string {
+	return r.ruleTag
+}
+
 func init() {
 	common.Must(common.RegisterConfig((*Config)(nil), func(ctx context.Context, config interface{}) (interface{}, error) {
 		r := new(Router)
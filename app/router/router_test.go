@@ -8,6 +8,7 @@ import (
 	. "github.com/xtls/xray-core/app/router"
 	"github.com/xtls/xray-core/common"
 	"github.com/xtls/xray-core/common/net"
+	"github.com/xtls/xray-core/common/serial"
 	"github.com/xtls/xray-core/common/session"
 	"github.com/xtls/xray-core/features/dns"
 	"github.com/xtls/xray-core/features/outbound"
@@ -94,6 +95,90 @@ func TestSimpleBalancer(t *testing.T) {
 	}
 }
 
+func TestRuleManagement(t *testing.T) {
+	config := &Config{
+		Rule: []*RoutingRule{
+			{
+				TargetTag: &RoutingRule_Tag{
+					Tag: "direct",
+				},
+				Networks: []net.Network{net.Network_TCP},
+				RuleTag:  "tcp",
+			},
+		},
+	}
+
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	mockDNS := mocks.NewDNSClient(mockCtl)
+	mockOhm := mocks.NewOutboundManager(mockCtl)
+	mockHs := mocks.NewOutboundHandlerSelector(mockCtl)
+	mockHs.EXPECT().Select(gomock.Eq([]string{"proxy-"})).Return([]string{"proxy-a"}).AnyTimes()
+
+	r := new(Router)
+	common.Must(r.Init(context.TODO(), config, mockDNS, &mockOutboundManager{
+		Manager:         mockOhm,
+		HandlerSelector: mockHs,
+	}))
+
+	pick := func() string {
+		ctx := session.ContextWithOutbound(context.Background(), &session.Outbound{Target: net.TCPDestination(net.DomainAddress("example.com"), 80)})
+		route, err := r.PickRoute(routing_session.AsRoutingContext(ctx))
+		if err != nil {
+			return ""
+		}
+		return route.GetOutboundTag()
+	}
+
+	if tag := pick(); tag != "direct" {
+		t.Error("expect tag 'direct', but actually ", tag)
+	}
+
+	common.Must(r.AddBalancer(&BalancingRule{
+		Tag:              "balance",
+		OutboundSelector: []string{"proxy-"},
+	}))
+	common.Must(r.AddRule(&RoutingRule{
+		TargetTag: &RoutingRule_BalancingTag{
+			BalancingTag: "balance",
+		},
+		Domain:  []*Domain{{Type: Domain_Full, Value: "example.com"}},
+		RuleTag: "example",
+	}, false))
+	if tag := pick(); tag != "proxy-a" {
+		t.Error("expect tag 'proxy-a', but actually ", tag)
+	}
+
+	// Replace the rule in place.
+	common.Must(r.AddRule(&RoutingRule{
+		TargetTag: &RoutingRule_Tag{
+			Tag: "blocked",
+		},
+		Domain:  []*Domain{{Type: Domain_Full, Value: "example.com"}},
+		RuleTag: "example",
+	}, true))
+	if rules := r.ListRules(); len(rules) != 2 || rules[0].RuleTag != "example" || rules[1].RuleTag != "tcp" {
+		t.Error("unexpected rules: ", rules)
+	}
+	if tag := pick(); tag != "blocked" {
+		t.Error("expect tag 'blocked', but actually ", tag)
+	}
+
+	common.Must(r.RemoveRule("example"))
+	if tag := pick(); tag != "direct" {
+		t.Error("expect tag 'direct', but actually ", tag)
+	}
+	if err := r.RemoveRule("example"); err == nil {
+		t.Error("expect error when removing a missing rule")
+	}
+
+	common.Must(r.RemoveBalancer("balance"))
+	if balancers := r.ListBalancers(); len(balancers) != 0 {
+		t.Error("unexpected balancers: ", balancers)
+	}
+}
+
 func TestIPOnDemand(t *testing.T) {
 	config := &Config{
 		DomainStrategy: Config_IpOnDemand,
@@ -205,3 +290,102 @@ func TestIPIfNonMatchIP(t *testing.T) {
 		t.Error("expect tag 'test', bug actually ", tag)
 	}
 }
+
+func TestWeightedRoundRobinBalancer(t *testing.T) {
+	config := &Config{
+		Rule: []*RoutingRule{
+			{
+				TargetTag: &RoutingRule_BalancingTag{
+					BalancingTag: "balance",
+				},
+				Networks: []net.Network{net.Network_TCP},
+			},
+		},
+		BalancingRule: []*BalancingRule{
+			{
+				Tag:              "balance",
+				OutboundSelector: []string{"test-"},
+				Strategy:         "roundRobin",
+				StrategySettings: serial.ToTypedMessage(&StrategyRoundRobinConfig{
+					Weights: []*StrategyWeight{
+						{Match: "test-a", Value: 2},
+						{Regexp: true, Match: "-c$", Value: 0},
+					},
+				}),
+			},
+		},
+	}
+
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	mockDNS := mocks.NewDNSClient(mockCtl)
+	mockOhm := mocks.NewOutboundManager(mockCtl)
+	mockHs := mocks.NewOutboundHandlerSelector(mockCtl)
+	mockHs.EXPECT().Select(gomock.Eq([]string{"test-"})).Return([]string{"test-c", "test-b", "test-a"}).AnyTimes()
+
+	r := new(Router)
+	common.Must(r.Init(context.TODO(), config, mockDNS, &mockOutboundManager{
+		Manager:         mockOhm,
+		HandlerSelector: mockHs,
+	}))
+
+	counts := make(map[string]int)
+	for i := 0; i < 6; i++ {
+		ctx := session.ContextWithOutbound(context.Background(), &session.Outbound{Target: net.TCPDestination(net.DomainAddress("example.com"), 80)})
+		route, err := r.PickRoute(routing_session.AsRoutingContext(ctx))
+		common.Must(err)
+		counts[route.GetOutboundTag()]++
+	}
+	if counts["test-a"] != 4 || counts["test-b"] != 2 || counts["test-c"] != 0 {
+		t.Error("unexpected picks: ", counts)
+	}
+}
+
+func TestBalancerFallback(t *testing.T) {
+	config := &Config{
+		Rule: []*RoutingRule{
+			{
+				TargetTag: &RoutingRule_BalancingTag{
+					BalancingTag: "balance",
+				},
+				Networks: []net.Network{net.Network_TCP},
+			},
+		},
+		BalancingRule: []*BalancingRule{
+			{
+				Tag:              "balance",
+				OutboundSelector: []string{"test-"},
+				Strategy:         "leastLoad",
+				FallbackTag:      "direct",
+			},
+		},
+	}
+
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	mockDNS := mocks.NewDNSClient(mockCtl)
+	mockOhm := mocks.NewOutboundManager(mockCtl)
+	mockHs := mocks.NewOutboundHandlerSelector(mockCtl)
+	gomock.InOrder(
+		mockHs.EXPECT().Select(gomock.Eq([]string{"test-"})).Return([]string{"test-a"}),
+		mockHs.EXPECT().Select(gomock.Eq([]string{"test-"})).Return(nil),
+	)
+
+	r := new(Router)
+	common.Must(r.Init(context.TODO(), config, mockDNS, &mockOutboundManager{
+		Manager:         mockOhm,
+		HandlerSelector: mockHs,
+	}))
+
+	for i := 0; i < 2; i++ {
+		// No outbound is known to be alive without observatory, nor selected at the second time.
+		ctx := session.ContextWithOutbound(context.Background(), &session.Outbound{Target: net.TCPDestination(net.DomainAddress("example.com"), 80)})
+		route, err := r.PickRoute(routing_session.AsRoutingContext(ctx))
+		common.Must(err)
+		if tag := route.GetOutboundTag(); tag != "direct" {
+			t.Error("expect tag 'direct', but actually ", tag)
+		}
+	}
+}
@@ -0,0 +1,357 @@
+package router
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/xtls/xray-core/common/net"
+	"github.com/xtls/xray-core/common/platform"
+	"github.com/xtls/xray-core/features/routing"
+)
+
+// defaultRuleSetRefreshInterval is the interval to check rule set files for changes if not configured.
+const defaultRuleSetRefreshInterval = time.Minute
+
+// ruleSetFile is the file of a rule set. The file is checked for changes on matching once the refresh interval has
+// elapsed, and reloaded in background if changed, so that rule sets are never reloaded while unused.
+type ruleSetFile struct {
+	config   *RuleSet
+	path     string
+	interval time.Duration
+	// parse parses the content of the file, and swaps the compiled rules in.
+	parse func(data []byte) error
+
+	nextCheck int64 // unix nano, accessed atomically
+	reloading int32 // accessed atomically
+
+	access  sync.Mutex
+	modTime time.Time
+	size    int64
+}
+
+func newRuleSetFile(config *RuleSet, parse func(data []byte) error) (*ruleSetFile, error) {
+	if config.Path == "" {
+		return nil, newError("empty rule set path")
+	}
+	path := config.Path
+	if !filepath.IsAbs(path) {
+		path = platform.GetAssetLocation(path)
+	}
+	interval := time.Duration(config.RefreshInterval) * time.Second
+	if interval == 0 {
+		interval = defaultRuleSetRefreshInterval
+	}
+	f := &ruleSetFile{
+		config:   config,
+		path:     path,
+		interval: interval,
+		parse:    parse,
+	}
+	if _, err := f.reload(); err != nil {
+		return nil, newError("failed to load rule set ", path).Base(err)
+	}
+	return f, nil
+}
+
+// reload loads the file if it has changed since last load, and returns whether it is reloaded.
+func (f *ruleSetFile) reload() (bool, error) {
+	f.access.Lock()
+	defer f.access.Unlock()
+
+	atomic.StoreInt64(&f.nextCheck, time.Now().Add(f.interval).UnixNano())
+
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return false, err
+	}
+	if info.ModTime().Equal(f.modTime) && info.Size() == f.size {
+		return false, nil
+	}
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		return false, err
+	}
+	if err := f.parse(data); err != nil {
+		return false, err
+	}
+	f.modTime = info.ModTime()
+	f.size = info.Size()
+	return true, nil
+}
+
+// refresh reloads the file in background if the refresh interval has elapsed.
+func (f *ruleSetFile) refresh() {
+	if time.Now().UnixNano() < atomic.LoadInt64(&f.nextCheck) {
+		return
+	}
+	if !atomic.CompareAndSwapInt32(&f.reloading, 0, 1) {
+		return
+	}
+	go func() {
+		defer atomic.StoreInt32(&f.reloading, 0)
+		reloaded, err := f.reload()
+		if err != nil {
+			newError("failed to reload rule set ", f.path).Base(err).AtWarning().WriteToLog()
+			return
+		}
+		if reloaded {
+			newError("rule set ", f.path, " reloaded").AtInfo().WriteToLog()
+		}
+	}()
+}
+
+// readLines returns the lines in the text, without the empty ones and the comments starting with "#".
+func readLines(data []byte) []string {
+	var lines []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+var domainLinePrefixes = []struct {
+	prefix     string
+	domainType Domain_Type
+}{
+	{"regexp:", Domain_Regex},
+	{"domain:", Domain_Domain},
+	{"full:", Domain_Full},
+	{"keyword:", Domain_Plain},
+}
+
+// parseDomainLine parses a domain in the syntax of routing rules.
+func parseDomainLine(line string) *Domain {
+	for _, p := range domainLinePrefixes {
+		if strings.HasPrefix(line, p.prefix) {
+			value := line[len(p.prefix):]
+			if p.domainType != Domain_Regex {
+				value = strings.ToLower(value)
+			}
+			return &Domain{Type: p.domainType, Value: value}
+		}
+	}
+	return &Domain{Type: Domain_Plain, Value: strings.ToLower(line)}
+}
+
+// DomainRuleSet is a domain rule set.
+type DomainRuleSet struct {
+	file    *ruleSetFile
+	matcher atomic.Value // *DomainMatcher
+}
+
+// NewDomainRuleSet loads the domain rule set.
+func NewDomainRuleSet(config *RuleSet) (*DomainRuleSet, error) {
+	s := new(DomainRuleSet)
+	file, err := newRuleSetFile(config, func(data []byte) error {
+		return s.parse(config, data)
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.file = file
+	return s, nil
+}
+
+func (s *DomainRuleSet) parse(config *RuleSet, data []byte) error {
+	var domains []*Domain
+	switch config.Format {
+	case RuleSet_Text:
+		for _, line := range readLines(data) {
+			domains = append(domains, parseDomainLine(line))
+		}
+	case RuleSet_Protobuf:
+		var list GeoSiteList
+		if err := proto.Unmarshal(data, &list); err != nil {
+			return err
+		}
+		found := false
+		for _, site := range list.Entry {
+			if strings.EqualFold(site.CountryCode, config.Code) {
+				domains = site.Domain
+				found = true
+				break
+			}
+		}
+		if !found {
+			return newError("list ", config.Code, " not found")
+		}
+	default:
+		return newError("unknown rule set format: ", config.Format)
+	}
+
+	matcher, err := NewMphMatcherGroup(domains)
+	if err != nil {
+		return err
+	}
+	s.matcher.Store(matcher)
+	return nil
+}
+
+// Match returns true if the domain is in the rule set.
+func (s *DomainRuleSet) Match(domain string) bool {
+	s.file.refresh()
+	return s.matcher.Load().(*DomainMatcher).ApplyDomain(domain)
+}
+
+// IPRuleSet is an IP rule set.
+type IPRuleSet struct {
+	file    *ruleSetFile
+	matcher atomic.Value // *GeoIPMatcher
+}
+
+// NewIPRuleSet loads the IP rule set.
+func NewIPRuleSet(config *RuleSet) (*IPRuleSet, error) {
+	s := new(IPRuleSet)
+	file, err := newRuleSetFile(config, func(data []byte) error {
+		return s.parse(config, data)
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.file = file
+	return s, nil
+}
+
+func (s *IPRuleSet) parse(config *RuleSet, data []byte) error {
+	var cidrs []*CIDR
+	switch config.Format {
+	case RuleSet_Text:
+		for _, line := range readLines(data) {
+			cidr, err := parseCIDRLine(line)
+			if err != nil {
+				return err
+			}
+			cidrs = append(cidrs, cidr)
+		}
+	case RuleSet_Protobuf:
+		var list GeoIPList
+		if err := proto.Unmarshal(data, &list); err != nil {
+			return err
+		}
+		found := false
+		for _, geoip := range list.Entry {
+			if strings.EqualFold(geoip.CountryCode, config.Code) {
+				cidrs = geoip.Cidr
+				found = true
+				break
+			}
+		}
+		if !found {
+			return newError("list ", config.Code, " not found")
+		}
+	default:
+		return newError("unknown rule set format: ", config.Format)
+	}
+
+	matcher := new(GeoIPMatcher)
+	if err := matcher.Init(cidrs); err != nil {
+		return err
+	}
+	s.matcher.Store(matcher)
+	return nil
+}
+
+// parseCIDRLine parses an IP or a CIDR.
+func parseCIDRLine(line string) (*CIDR, error) {
+	if ip := net.ParseIP(line); ip != nil {
+		if ip4 := ip.To4(); ip4 != nil {
+			return &CIDR{Ip: ip4, Prefix: 32}, nil
+		}
+		return &CIDR{Ip: ip, Prefix: 128}, nil
+	}
+	_, ipNet, err := net.ParseCIDR(line)
+	if err != nil {
+		return nil, newError("invalid CIDR ", line).Base(err)
+	}
+	ones, _ := ipNet.Mask.Size()
+	ip := ipNet.IP
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+	}
+	return &CIDR{Ip: ip, Prefix: uint32(ones)}, nil
+}
+
+// Match returns true if the IP is in the rule set.
+func (s *IPRuleSet) Match(ip net.IP) bool {
+	s.file.refresh()
+	return s.matcher.Load().(*GeoIPMatcher).Match(ip)
+}
+
+// DomainRuleSetMatcher matches the target domain with rule sets.
+type DomainRuleSetMatcher struct {
+	ruleSets []*DomainRuleSet
+}
+
+func NewDomainRuleSetMatcher(configs []*RuleSet) (*DomainRuleSetMatcher, error) {
+	m := new(DomainRuleSetMatcher)
+	for _, config := range configs {
+		s, err := NewDomainRuleSet(config)
+		if err != nil {
+			return nil, err
+		}
+		m.ruleSets = append(m.ruleSets, s)
+	}
+	return m, nil
+}
+
+// Apply implements Condition.
+func (m *DomainRuleSetMatcher) Apply(ctx routing.Context) bool {
+	domain := ctx.GetTargetDomain()
+	if len(domain) == 0 {
+		return false
+	}
+	for _, s := range m.ruleSets {
+		if s.Match(domain) {
+			return true
+		}
+	}
+	return false
+}
+
+// IPRuleSetMatcher matches the target or source IPs with rule sets.
+type IPRuleSetMatcher struct {
+	ruleSets []*IPRuleSet
+	onSource bool
+}
+
+func NewIPRuleSetMatcher(configs []*RuleSet, onSource bool) (*IPRuleSetMatcher, error) {
+	m := &IPRuleSetMatcher{onSource: onSource}
+	for _, config := range configs {
+		s, err := NewIPRuleSet(config)
+		if err != nil {
+			return nil, err
+		}
+		m.ruleSets = append(m.ruleSets, s)
+	}
+	return m, nil
+}
+
+// Apply implements Condition.
+func (m *IPRuleSetMatcher) Apply(ctx routing.Context) bool {
+	var ips []net.IP
+	if m.onSource {
+		ips = ctx.GetSourceIPs()
+	} else {
+		ips = ctx.GetTargetIPs()
+	}
+	for _, ip := range ips {
+		for _, s := range m.ruleSets {
+			if s.Match(ip) {
+				return true
+			}
+		}
+	}
+	return false
+}
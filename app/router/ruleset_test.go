@@ -0,0 +1,106 @@
+package router_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	. "github.com/xtls/xray-core/app/router"
+	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/common/net"
+	"github.com/xtls/xray-core/common/session"
+)
+
+func TestDomainRuleSet(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "domains.txt")
+	common.Must(os.WriteFile(path, []byte("# comment\n\ndomain:example.com\nfull:www.example.org\n"), 0o644))
+
+	ruleSet, err := NewDomainRuleSet(&RuleSet{Path: path, RefreshInterval: 1})
+	common.Must(err)
+
+	for domain, expected := range map[string]bool{
+		"example.com":     true,
+		"www.example.com": true,
+		"www.example.org": true,
+		"example.org":     false,
+		"example.net":     false,
+	} {
+		if ruleSet.Match(domain) != expected {
+			t.Error("expect ", expected, " for ", domain)
+		}
+	}
+
+	common.Must(os.WriteFile(path, []byte("keyword:example.net\n"), 0o644))
+	if !waitForMatch(func() bool { return ruleSet.Match("www.example.net") }) {
+		t.Error("rule set is not reloaded")
+	}
+	if ruleSet.Match("example.com") {
+		t.Error("expect rules to be replaced")
+	}
+
+	// the last loaded rules are kept when the file becomes invalid
+	common.Must(os.Remove(path))
+	time.Sleep(1100 * time.Millisecond)
+	ruleSet.Match("example.net")
+	time.Sleep(100 * time.Millisecond)
+	if !ruleSet.Match("example.net") {
+		t.Error("expect rules to be kept")
+	}
+}
+
+func TestIPRuleSet(t *testing.T) {
+	dir := t.TempDir()
+	textPath := filepath.Join(dir, "ips.txt")
+	common.Must(os.WriteFile(textPath, []byte("10.0.0.0/8\n192.168.1.1\n2001:db8::/32\n"), 0o644))
+
+	datPath := filepath.Join(dir, "ips.dat")
+	data, err := proto.Marshal(&GeoIPList{Entry: []*GeoIP{
+		{CountryCode: "TEST", Cidr: []*CIDR{{Ip: []byte{8, 8, 8, 0}, Prefix: 24}}},
+	}})
+	common.Must(err)
+	common.Must(os.WriteFile(datPath, data, 0o644))
+
+	rule := &RoutingRule{
+		IpRuleSet: []*RuleSet{
+			{Path: textPath},
+			{Path: datPath, Format: RuleSet_Protobuf, Code: "test"},
+		},
+	}
+	cond, err := rule.BuildCondition()
+	common.Must(err)
+
+	for ip, expected := range map[string]bool{
+		"10.1.2.3":      true,
+		"192.168.1.1":   true,
+		"192.168.1.2":   false,
+		"2001:db8::1":   true,
+		"8.8.8.8":       true,
+		"8.8.4.4":       false,
+		"2001:4860::88": false,
+	} {
+		ctx := withOutbound(&session.Outbound{Target: net.TCPDestination(net.ParseAddress(ip), 80)})
+		if cond.Apply(ctx) != expected {
+			t.Error("expect ", expected, " for ", ip)
+		}
+	}
+
+	rule = &RoutingRule{
+		IpRuleSet: []*RuleSet{{Path: datPath, Format: RuleSet_Protobuf, Code: "cn"}},
+	}
+	if _, err := rule.BuildCondition(); err == nil {
+		t.Error("expect error for missing list")
+	}
+}
+
+// waitForMatch waits for the rule set to be reloaded in background.
+func waitForMatch(match func() bool) bool {
+	for i := 0; i < 30; i++ {
+		if match() {
+			return true
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	return false
+}
@@ -0,0 +1,142 @@
+package router
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/xtls/xray-core/app/observatory"
+	"github.com/xtls/xray-core/common/dice"
+)
+
+// LeastLoadStrategy picks randomly among the outbounds with the least load, i.e. the lowest RTT plus its deviation,
+// as reported by the observatory. The deviation and failure rate are only known with the burst observatory.
+type LeastLoadStrategy struct {
+	ctx      context.Context
+	settings *StrategyLeastLoadConfig
+	costs    *weightManager
+}
+
+// NewLeastLoadStrategy creates a LeastLoadStrategy with the given settings. settings may be nil.
+func NewLeastLoadStrategy(settings *StrategyLeastLoadConfig) (*LeastLoadStrategy, error) {
+	if settings == nil {
+		settings = &StrategyLeastLoadConfig{}
+	}
+	costs, err := newWeightManager(settings.Costs, 1)
+	if err != nil {
+		return nil, err
+	}
+	return &LeastLoadStrategy{
+		settings: settings,
+		costs:    costs,
+	}, nil
+}
+
+func (s *LeastLoadStrategy) InjectContext(ctx context.Context) {
+	s.ctx = ctx
+}
+
+func (s *LeastLoadStrategy) PickOutbound(tags []string) string {
+	selected := s.selectLeastLoad(s.getNodes(tags))
+	if len(selected) == 0 {
+		return ""
+	}
+	return selected[dice.Roll(len(selected))].Tag
+}
+
+// node is the load statistics of an outbound.
+type node struct {
+	Tag          string
+	RTTAverage   time.Duration
+	RTTDeviation time.Duration
+	FailRate     float64
+
+	// Cost applied load, used for sorting.
+	Load time.Duration
+}
+
+// getNodes returns the qualified nodes among tags, sorted by load.
+func (s *LeastLoadStrategy) getNodes(tags []string) []*node {
+	status := getObservation(s.ctx)
+	if status == nil {
+		return nil
+	}
+	return s.buildNodes(tags, status)
+}
+
+// buildNodes returns the qualified nodes among tags with the given status, sorted by load.
+func (s *LeastLoadStrategy) buildNodes(tags []string, status map[string]*observatory.OutboundStatus) []*node {
+	maxRTT := time.Duration(s.settings.MaxRTT)
+	nodes := make([]*node, 0, len(tags))
+	for _, tag := range tags {
+		st, found := status[tag]
+		if !found || !st.Alive {
+			continue
+		}
+		n := &node{
+			Tag:        tag,
+			RTTAverage: time.Duration(st.Delay) * time.Millisecond,
+		}
+		if hp := st.HealthPing; hp != nil && hp.All > 0 {
+			n.RTTAverage = time.Duration(hp.Average)
+			n.RTTDeviation = time.Duration(hp.Deviation)
+			n.FailRate = float64(hp.Fail) / float64(hp.All)
+		}
+		// a zero tolerance means no limit on the failure rate
+		if s.settings.Tolerance > 0 && n.FailRate > float64(s.settings.Tolerance) {
+			continue
+		}
+		if maxRTT > 0 && n.RTTAverage > maxRTT {
+			continue
+		}
+		n.Load = time.Duration(float64(n.RTTAverage+n.RTTDeviation) * s.costs.Get(tag))
+		nodes = append(nodes, n)
+	}
+
+	sort.Slice(nodes, func(i, j int) bool {
+		if nodes[i].Load != nodes[j].Load {
+			return nodes[i].Load < nodes[j].Load
+		}
+		return nodes[i].Tag < nodes[j].Tag
+	})
+	return nodes
+}
+
+// selectLeastLoad selects the nodes to pick from, out of the nodes sorted by load.
+//
+// Without baselines, the expected count of nodes with the least load are selected. With baselines, all nodes below
+// the first baseline are selected, and next baselines are tried until at least the expected count of nodes is
+// selected.
+func (s *LeastLoadStrategy) selectLeastLoad(nodes []*node) []*node {
+	if len(nodes) == 0 {
+		return nil
+	}
+	expected := int(s.settings.Expected)
+	if expected > len(nodes) {
+		return nodes
+	}
+	if expected <= 0 {
+		expected = 1
+	}
+	if len(s.settings.Baselines) == 0 {
+		return nodes[:expected]
+	}
+
+	count := 0
+	for _, b := range s.settings.Baselines {
+		baseline := time.Duration(b)
+		for i := count; i < len(nodes); i++ {
+			if nodes[i].Load >= baseline {
+				break
+			}
+			count = i + 1
+		}
+		if count >= expected {
+			break
+		}
+	}
+	if count < expected {
+		count = expected
+	}
+	return nodes[:count]
+}
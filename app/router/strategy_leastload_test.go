@@ -0,0 +1,98 @@
+package router
+
+import (
+	"testing"
+	"time"
+
+	"github.com/xtls/xray-core/app/observatory"
+)
+
+func TestSelectLeastLoad(t *testing.T) {
+	nodes := []*node{
+		{Tag: "a", Load: 50 * time.Millisecond},
+		{Tag: "b", Load: 80 * time.Millisecond},
+		{Tag: "c", Load: 150 * time.Millisecond},
+		{Tag: "d", Load: 300 * time.Millisecond},
+		{Tag: "e", Load: 600 * time.Millisecond},
+	}
+
+	cases := []struct {
+		settings *StrategyLeastLoadConfig
+		expected int
+	}{
+		{settings: &StrategyLeastLoadConfig{}, expected: 1},
+		{settings: &StrategyLeastLoadConfig{Expected: 3}, expected: 3},
+		{settings: &StrategyLeastLoadConfig{Expected: 10}, expected: 5},
+		{
+			settings: &StrategyLeastLoadConfig{
+				Baselines: []int64{int64(100 * time.Millisecond), int64(500 * time.Millisecond)},
+			},
+			expected: 2,
+		},
+		{
+			settings: &StrategyLeastLoadConfig{
+				Baselines: []int64{int64(100 * time.Millisecond), int64(500 * time.Millisecond)},
+				Expected:  3,
+			},
+			expected: 4,
+		},
+		{
+			settings: &StrategyLeastLoadConfig{
+				Baselines: []int64{int64(10 * time.Millisecond)},
+				Expected:  2,
+			},
+			expected: 2,
+		},
+	}
+
+	for _, c := range cases {
+		s, err := NewLeastLoadStrategy(c.settings)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if selected := s.selectLeastLoad(nodes); len(selected) != c.expected {
+			t.Error("settings ", c.settings, ": expect ", c.expected, " nodes, but actually ", len(selected))
+		}
+	}
+}
+
+func TestLeastLoadTolerance(t *testing.T) {
+	status := map[string]*observatory.OutboundStatus{
+		"a": {
+			OutboundTag: "a",
+			Alive:       true,
+			HealthPing:  &observatory.HealthPingMeasurementResult{All: 10, Fail: 1, Average: int64(100 * time.Millisecond)},
+		},
+		"b": {
+			OutboundTag: "b",
+			Alive:       true,
+			HealthPing:  &observatory.HealthPingMeasurementResult{All: 10, Fail: 5, Average: int64(50 * time.Millisecond)},
+		},
+	}
+	tags := []string{"a", "b"}
+
+	cases := []struct {
+		settings *StrategyLeastLoadConfig
+		expected []string
+	}{
+		{settings: &StrategyLeastLoadConfig{}, expected: []string{"b", "a"}},
+		{settings: &StrategyLeastLoadConfig{Tolerance: 0.2}, expected: []string{"a"}},
+		{settings: &StrategyLeastLoadConfig{Tolerance: 0.05}, expected: nil},
+	}
+
+	for _, c := range cases {
+		s, err := NewLeastLoadStrategy(c.settings)
+		if err != nil {
+			t.Fatal(err)
+		}
+		nodes := s.buildNodes(tags, status)
+		if len(nodes) != len(c.expected) {
+			t.Fatal("settings ", c.settings, ": expect ", c.expected, ", but actually ", len(nodes), " nodes")
+		}
+		for i, n := range nodes {
+			if n.Tag != c.expected[i] {
+				t.Error("settings ", c.settings, ": expect ", c.expected, ", but node ", i, " is ", n.Tag)
+			}
+		}
+	}
+}
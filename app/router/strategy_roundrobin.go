@@ -0,0 +1,110 @@
+package router
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/xtls/xray-core/app/observatory"
+	"github.com/xtls/xray-core/core"
+	"github.com/xtls/xray-core/features/extension"
+)
+
+// RoundRobinStrategy picks outbounds in turn, with a smooth weighted round-robin. Outbounds known to be dead by the
+// observatory are skipped, if there is one.
+type RoundRobinStrategy struct {
+	ctx     context.Context
+	weights *weightManager
+
+	access  sync.Mutex
+	current map[string]float64
+}
+
+// NewRoundRobinStrategy creates a RoundRobinStrategy with the given settings. settings may be nil.
+func NewRoundRobinStrategy(settings *StrategyRoundRobinConfig) (*RoundRobinStrategy, error) {
+	weights, err := newWeightManager(settings.GetWeights(), 1)
+	if err != nil {
+		return nil, err
+	}
+	return &RoundRobinStrategy{
+		weights: weights,
+		current: make(map[string]float64),
+	}, nil
+}
+
+func (s *RoundRobinStrategy) InjectContext(ctx context.Context) {
+	s.ctx = ctx
+}
+
+func (s *RoundRobinStrategy) PickOutbound(tags []string) string {
+	tags = s.filterAlive(tags)
+	sort.Strings(tags)
+
+	s.access.Lock()
+	defer s.access.Unlock()
+
+	current := make(map[string]float64, len(tags))
+	total := 0.0
+	selected := ""
+	for _, tag := range tags {
+		w := s.weights.Get(tag)
+		if w <= 0 {
+			continue
+		}
+		current[tag] = s.current[tag] + w
+		total += w
+		if selected == "" || current[tag] > current[selected] {
+			selected = tag
+		}
+	}
+	if selected != "" {
+		current[selected] -= total
+	}
+	s.current = current
+	return selected
+}
+
+// filterAlive removes the outbounds that the observatory reports dead. Outbounds not observed are kept.
+func (s *RoundRobinStrategy) filterAlive(tags []string) []string {
+	status := getObservation(s.ctx)
+	if status == nil {
+		return append([]string(nil), tags...)
+	}
+	alive := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		if st, found := status[tag]; !found || st.Alive {
+			alive = append(alive, tag)
+		}
+	}
+	return alive
+}
+
+// getObservation returns the outbound status reported by the observatory, by outbound tag. It returns nil if the
+// observatory is not available.
+func getObservation(ctx context.Context) map[string]*observatory.OutboundStatus {
+	if ctx == nil {
+		return nil
+	}
+	instance := core.FromContext(ctx)
+	if instance == nil {
+		return nil
+	}
+	o, ok := instance.GetFeature(extension.ObservatoryType()).(extension.Observatory)
+	if !ok {
+		return nil
+	}
+	report, err := o.GetObservation(ctx)
+	if err != nil {
+		newError("cannot get observe report").Base(err).WriteToLog()
+		return nil
+	}
+	result, ok := report.(*observatory.ObservationResult)
+	if !ok {
+		return nil
+	}
+	status := make(map[string]*observatory.OutboundStatus, len(result.Status))
+	for _, st := range result.Status {
+		status[st.OutboundTag] = st
+	}
+	return status
+}
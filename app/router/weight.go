@@ -0,0 +1,55 @@
+package router
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// weightManager looks up the weight of outbounds by tag, from a list of StrategyWeight.
+type weightManager struct {
+	access        sync.Mutex
+	settings      []*StrategyWeight
+	matchers      []*regexp.Regexp
+	defaultWeight float64
+	cache         map[string]float64
+}
+
+func newWeightManager(settings []*StrategyWeight, defaultWeight float64) (*weightManager, error) {
+	matchers := make([]*regexp.Regexp, len(settings))
+	for i, s := range settings {
+		if !s.Regexp {
+			continue
+		}
+		r, err := regexp.Compile(s.Match)
+		if err != nil {
+			return nil, newError("invalid weight match: ", s.Match).Base(err)
+		}
+		matchers[i] = r
+	}
+	return &weightManager{
+		settings:      settings,
+		matchers:      matchers,
+		defaultWeight: defaultWeight,
+		cache:         make(map[string]float64),
+	}, nil
+}
+
+// Get returns the weight of the outbound with the tag. The first matching setting wins.
+func (m *weightManager) Get(tag string) float64 {
+	m.access.Lock()
+	defer m.access.Unlock()
+
+	if w, found := m.cache[tag]; found {
+		return w
+	}
+	w := m.defaultWeight
+	for i, s := range m.settings {
+		if m.matchers[i] != nil && m.matchers[i].MatchString(tag) || m.matchers[i] == nil && strings.Contains(tag, s.Match) {
+			w = float64(s.Value)
+			break
+		}
+	}
+	m.cache[tag] = w
+	return w
+}
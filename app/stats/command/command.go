@@ -11,13 +11,21 @@ import (
 	"github.com/xtls/xray-core/common"
 	"github.com/xtls/xray-core/common/strmatcher"
 	"github.com/xtls/xray-core/core"
+	"github.com/xtls/xray-core/features/policy"
 	feature_stats "github.com/xtls/xray-core/features/stats"
 	grpc "google.golang.org/grpc"
 )
 
+// userUsageManager is implemented by policy managers that track the usage of users.
+type userUsageManager interface {
+	GetUserUsage(email string, reset bool) (policy.UserUsage, bool)
+	QueryUserUsage(match func(email string) bool, reset bool) []policy.UserUsage
+}
+
 // statsServer is an implementation of StatsService.
 type statsServer struct {
 	stats     feature_stats.Manager
+	policy    policy.Manager
 	startTime time.Time
 }
 
@@ -101,14 +109,67 @@ func (s *statsServer) GetSysStats(ctx context.Context, request *SysStatsRequest)
 	return response, nil
 }
 
+func (s *statsServer) getUserUsageManager() (userUsageManager, error) {
+	m, ok := s.policy.(userUsageManager)
+	if !ok {
+		return nil, newError("policy manager does not track user usage")
+	}
+	return m, nil
+}
+
+func toUserUsage(u policy.UserUsage) *UserUsage {
+	return &UserUsage{
+		Email:       u.Email,
+		Connections: int64(u.Connections),
+		SourceIps:   u.SourceIPs,
+		Traffic:     u.Traffic,
+		Quota:       u.Quota,
+	}
+}
+
+func (s *statsServer) GetUserUsage(ctx context.Context, request *GetUserUsageRequest) (*GetUserUsageResponse, error) {
+	m, err := s.getUserUsageManager()
+	if err != nil {
+		return nil, err
+	}
+	usage, found := m.GetUserUsage(request.Email, request.Reset_)
+	if !found {
+		return nil, newError("usage of user ", request.Email, " not found.")
+	}
+	return &GetUserUsageResponse{
+		Usage: toUserUsage(usage),
+	}, nil
+}
+
+func (s *statsServer) QueryUserUsage(ctx context.Context, request *QueryUserUsageRequest) (*QueryUserUsageResponse, error) {
+	m, err := s.getUserUsageManager()
+	if err != nil {
+		return nil, err
+	}
+	matcher, err := strmatcher.Substr.New(request.Pattern)
+	if err != nil {
+		return nil, err
+	}
+	response := &QueryUserUsageResponse{}
+	for _, usage := range m.QueryUserUsage(matcher.Match, request.Reset_) {
+		response.Usage = append(response.Usage, toUserUsage(usage))
+	}
+	return response, nil
+}
+
 func (s *statsServer) mustEmbedUnimplementedStatsServiceServer() {}
 
 type service struct {
-	statsManager feature_stats.Manager
+	statsManager  feature_stats.Manager
+	policyManager policy.Manager
 }
 
 func (s *service) Register(server *grpc.Server) {
-	ss := NewStatsServer(s.statsManager)
+	ss := &statsServer{
+		stats:     s.statsManager,
+		policy:    s.policyManager,
+		startTime: time.Now(),
+	}
 	RegisterStatsServiceServer(server, ss)
 
 	// For compatibility purposes
@@ -121,8 +182,9 @@ func init() {
 	common.Must(common.RegisterConfig((*Config)(nil), func(ctx context.Context, cfg interface{}) (interface{}, error) {
 		s := new(service)
 
-		core.RequireFeatures(ctx, func(sm feature_stats.Manager) {
+		core.RequireFeatures(ctx, func(sm feature_stats.Manager, pm policy.Manager) {
 			s.statsManager = sm
+			s.policyManager = pm
 		})
 
 		return s, nil
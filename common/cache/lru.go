@@ -10,7 +10,12 @@ type Lru interface {
 	Get(key interface{}) (value interface{}, ok bool)
 	GetKeyFromValue(value interface{}) (key interface{}, ok bool)
 	PeekKeyFromValue(value interface{}) (key interface{}, ok bool) // Peek means check but NOT bring to top
+	Peek(key interface{}) (value interface{}, ok bool)
 	Put(key, value interface{})
+	Len() int
+	// Range calls f for the elements from the least recently used, until f returns false.
+	Range(f func(key, value interface{}) bool)
+	Clear()
 }
 
 type lru struct {
@@ -67,6 +72,16 @@ func (l *lru) PeekKeyFromValue(value interface{}) (key interface{}, ok bool) {
 	return nil, false
 }
 
+func (l *lru) Peek(key interface{}) (value interface{}, ok bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if v, ok := l.keyToElement.Load(key); ok {
+		element := v.(*list.Element)
+		return element.Value.(*lruElement).value, true
+	}
+	return nil, false
+}
+
 func (l *lru) Put(key, value interface{}) {
 	l.mu.Lock()
 	e := &lruElement{key, value}
@@ -87,3 +102,34 @@ func (l *lru) Put(key, value interface{}) {
 	}
 	l.mu.Unlock()
 }
+
+func (l *lru) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.doubleLinkedlist.Len()
+}
+
+func (l *lru) Range(f func(key, value interface{}) bool) {
+	l.mu.Lock()
+	elements := make([]*lruElement, 0, l.doubleLinkedlist.Len())
+	for element := l.doubleLinkedlist.Back(); element != nil; element = element.Prev() {
+		elements = append(elements, element.Value.(*lruElement))
+	}
+	l.mu.Unlock()
+
+	for _, e := range elements {
+		if !f(e.key, e.value) {
+			return
+		}
+	}
+}
+
+func (l *lru) Clear() {
+	l.mu.Lock()
+	for element := l.doubleLinkedlist.Front(); element != nil; element = element.Next() {
+		l.keyToElement.Delete(element.Value.(*lruElement).key)
+		l.valueToElement.Delete(element.Value.(*lruElement).value)
+	}
+	l.doubleLinkedlist.Init()
+	l.mu.Unlock()
+}
@@ -84,3 +84,50 @@ func TestPeekKeyFromValue(t *testing.T) {
 		t.Error("should get 2", v)
 	}
 }
+
+func TestLruRange(t *testing.T) {
+	lru := NewLru(3)
+	lru.Put(1, 1)
+	lru.Put(2, 2)
+	lru.Put(3, 3)
+	lru.Get(1)
+
+	var keys []interface{}
+	lru.Range(func(key, value interface{}) bool {
+		keys = append(keys, key)
+		return true
+	})
+	if len(keys) != 3 || keys[0] != 2 || keys[1] != 3 || keys[2] != 1 {
+		t.Error("should range from the least recently used", keys)
+	}
+
+	if v, ok := lru.Peek(2); !ok || v != 2 {
+		t.Error("should peek 2", v)
+	}
+	lru.Clear()
+	if lru.Len() != 0 {
+		t.Error("should be empty", lru.Len())
+	}
+	if _, ok := lru.PeekKeyFromValue(1); ok {
+		t.Error("should not find cleared value")
+	}
+}
+
+func TestLruPeekConcurrently(t *testing.T) {
+	lru := NewLru(2)
+	lru.Put(1, 0)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 1000; i++ {
+			lru.Put(1, i)
+		}
+	}()
+	for i := 0; i < 1000; i++ {
+		if _, ok := lru.Peek(1); !ok {
+			t.Fatal("should peek 1")
+		}
+	}
+	<-done
+}
@@ -3,6 +3,7 @@ package log
 import (
 	"context"
 	"strings"
+	"time"
 
 	"github.com/xtls/xray-core/common/serial"
 )
@@ -18,6 +19,7 @@ type AccessStatus string
 const (
 	AccessAccepted = AccessStatus("accepted")
 	AccessRejected = AccessStatus("rejected")
+	AccessClosed   = AccessStatus("closed")
 )
 
 type AccessMessage struct {
@@ -27,6 +29,19 @@ type AccessMessage struct {
 	Reason interface{}
 	Email  string
 	Detour string
+
+	// Routing details, filled by the dispatcher.
+	InboundTag  string
+	SniffedTo   interface{}
+	RuleTag     string
+	OutboundTag string
+	// Deferred is true if the message will be recorded again with status closed when the session ends.
+	Deferred bool
+
+	// Traffic of the session, only available when the status is closed.
+	Uplink   int64
+	Downlink int64
+	Duration time.Duration
 }
 
 func (m *AccessMessage) String() string {
@@ -53,9 +68,41 @@ func (m *AccessMessage) String() string {
 		builder.WriteString(m.Email)
 	}
 
+	if m.Status == AccessClosed {
+		builder.WriteString(" up: ")
+		builder.WriteString(serial.ToString(m.Uplink))
+		builder.WriteString(" down: ")
+		builder.WriteString(serial.ToString(m.Downlink))
+		builder.WriteString(" duration: ")
+		builder.WriteString(m.Duration.String())
+	}
+
 	return builder.String()
 }
 
+// Fields implements FieldsMessage.
+func (m *AccessMessage) Fields() map[string]interface{} {
+	fields := map[string]interface{}{
+		"type":   "access",
+		"status": string(m.Status),
+		"from":   serial.ToString(m.From),
+		"to":     serial.ToString(m.To),
+	}
+	setField(fields, "reason", serial.ToString(m.Reason))
+	setField(fields, "email", m.Email)
+	setField(fields, "detour", m.Detour)
+	setField(fields, "inboundTag", m.InboundTag)
+	setField(fields, "sniffedTo", serial.ToString(m.SniffedTo))
+	setField(fields, "ruleTag", m.RuleTag)
+	setField(fields, "outboundTag", m.OutboundTag)
+	if m.Status == AccessClosed {
+		fields["uplink"] = m.Uplink
+		fields["downlink"] = m.Downlink
+		fields["duration"] = m.Duration.Seconds()
+	}
+	return fields
+}
+
 func ContextWithAccessMessage(ctx context.Context, accessMessage *AccessMessage) context.Context {
 	return context.WithValue(ctx, accessMessageKey, accessMessage)
 }
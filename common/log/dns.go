@@ -40,6 +40,30 @@ func (l *DNSLog) String() string {
 	return builder.String()
 }
 
+// Fields implements FieldsMessage.
+func (l *DNSLog) Fields() map[string]interface{} {
+	fields := map[string]interface{}{
+		"type":   "dns",
+		"server": l.Server,
+		"status": strings.TrimSuffix(string(l.Status), ":"),
+		"domain": l.Domain,
+	}
+	if len(l.Result) > 0 {
+		ips := make([]string, 0, len(l.Result))
+		for _, ip := range l.Result {
+			ips = append(ips, ip.String())
+		}
+		fields["result"] = ips
+	}
+	if l.Elapsed > 0 {
+		fields["elapsed"] = l.Elapsed.Seconds()
+	}
+	if l.Error != nil {
+		fields["error"] = l.Error.Error()
+	}
+	return fields
+}
+
 type dnsStatus string
 
 var (
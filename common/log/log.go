@@ -11,6 +11,12 @@ type Message interface {
 	String() string
 }
 
+// FieldsMessage is a message that can be written as a structured log.
+type FieldsMessage interface {
+	Message
+	Fields() map[string]interface{}
+}
+
 // Handler is the interface for log handler.
 type Handler interface {
 	Handle(msg Message)
@@ -27,6 +33,21 @@ func (m *GeneralMessage) String() string {
 	return serial.Concat("[", m.Severity, "] ", m.Content)
 }
 
+// Fields implements FieldsMessage.
+func (m *GeneralMessage) Fields() map[string]interface{} {
+	return map[string]interface{}{
+		"type":    "error",
+		"level":   m.Severity.String(),
+		"message": serial.ToString(m.Content),
+	}
+}
+
+func setField(fields map[string]interface{}, key string, value string) {
+	if len(value) > 0 {
+		fields[key] = value
+	}
+}
+
 // Record writes a message into log stream.
 func Record(msg Message) {
 	logHandler.Handle(msg)
@@ -1,6 +1,7 @@
 package log
 
 import (
+	"encoding/json"
 	"io"
 	"log"
 	"os"
@@ -20,8 +21,19 @@ type Writer interface {
 // WriterCreator is a function to create LogWriters.
 type WriterCreator func() Writer
 
+// Format is the format of the log lines.
+type Format int
+
+const (
+	// FormatText writes messages as plain text.
+	FormatText Format = iota
+	// FormatJSON writes messages as JSON objects, one per line.
+	FormatJSON
+)
+
 type generalLogger struct {
 	creator WriterCreator
+	format  Format
 	buffer  chan Message
 	access  *semaphore.Instance
 	done    *done.Instance
@@ -29,14 +41,41 @@ type generalLogger struct {
 
 // NewLogger returns a generic log handler that can handle all type of messages.
 func NewLogger(logWriterCreator WriterCreator) Handler {
+	return NewFormattedLogger(logWriterCreator, FormatText)
+}
+
+// NewFormattedLogger returns a generic log handler that writes messages in the given format.
+// Writers of JSON loggers should be created with WithoutTimestamp, as the time is included in the objects.
+func NewFormattedLogger(logWriterCreator WriterCreator, format Format) Handler {
 	return &generalLogger{
 		creator: logWriterCreator,
+		format:  format,
 		buffer:  make(chan Message, 16),
 		access:  semaphore.New(1),
 		done:    done.New(),
 	}
 }
 
+func (l *generalLogger) formatMessage(msg Message) string {
+	if l.format != FormatJSON {
+		return msg.String()
+	}
+	var fields map[string]interface{}
+	if m, ok := msg.(FieldsMessage); ok {
+		fields = m.Fields()
+	} else {
+		fields = map[string]interface{}{
+			"message": msg.String(),
+		}
+	}
+	fields["time"] = time.Now().Format(time.RFC3339Nano)
+	b, err := json.Marshal(fields)
+	if err != nil {
+		return msg.String()
+	}
+	return string(b)
+}
+
 func (l *generalLogger) run() {
 	defer l.access.Signal()
 
@@ -55,7 +94,7 @@ func (l *generalLogger) run() {
 		case <-l.done.Wait():
 			return
 		case msg := <-l.buffer:
-			logger.Write(msg.String() + platform.LineSeparator())
+			logger.Write(l.formatMessage(msg) + platform.LineSeparator())
 			dataWritten = true
 		case <-ticker.C:
 			if !dataWritten {
@@ -83,6 +122,43 @@ func (l *generalLogger) Close() error {
 	return l.done.Close()
 }
 
+type writerOptions struct {
+	flags      int
+	maxSize    int64
+	maxAge     time.Duration
+	maxBackups int
+}
+
+// WriterOption is an option of the log writers.
+type WriterOption func(*writerOptions)
+
+// WithoutTimestamp disables the timestamp prefix of each line.
+func WithoutTimestamp() WriterOption {
+	return func(o *writerOptions) {
+		o.flags = 0
+	}
+}
+
+// WithRotation rotates the log file when it grows over maxSize bytes or it is older than maxAge, keeping at most
+// maxBackups rotated files. Zero values disable the corresponding limit.
+func WithRotation(maxSize int64, maxAge time.Duration, maxBackups int) WriterOption {
+	return func(o *writerOptions) {
+		o.maxSize = maxSize
+		o.maxAge = maxAge
+		o.maxBackups = maxBackups
+	}
+}
+
+func newWriterOptions(opts []WriterOption) *writerOptions {
+	o := &writerOptions{
+		flags: log.Ldate | log.Ltime,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
 type consoleLogWriter struct {
 	logger *log.Logger
 }
@@ -97,7 +173,7 @@ func (w *consoleLogWriter) Close() error {
 }
 
 type fileLogWriter struct {
-	file   *os.File
+	file   io.WriteCloser
 	logger *log.Logger
 }
 
@@ -111,38 +187,59 @@ func (w *fileLogWriter) Close() error {
 }
 
 // CreateStdoutLogWriter returns a LogWriterCreator that creates LogWriter for stdout.
-func CreateStdoutLogWriter() WriterCreator {
+func CreateStdoutLogWriter(opts ...WriterOption) WriterCreator {
+	o := newWriterOptions(opts)
 	return func() Writer {
 		return &consoleLogWriter{
-			logger: log.New(os.Stdout, "", log.Ldate|log.Ltime),
+			logger: log.New(os.Stdout, "", o.flags),
 		}
 	}
 }
 
 // CreateStderrLogWriter returns a LogWriterCreator that creates LogWriter for stderr.
-func CreateStderrLogWriter() WriterCreator {
+func CreateStderrLogWriter(opts ...WriterOption) WriterCreator {
+	o := newWriterOptions(opts)
 	return func() Writer {
 		return &consoleLogWriter{
-			logger: log.New(os.Stderr, "", log.Ldate|log.Ltime),
+			logger: log.New(os.Stderr, "", o.flags),
 		}
 	}
 }
 
 // CreateFileLogWriter returns a LogWriterCreator that creates LogWriter for the given file.
-func CreateFileLogWriter(path string) (WriterCreator, error) {
+func CreateFileLogWriter(path string, opts ...WriterOption) (WriterCreator, error) {
+	o := newWriterOptions(opts)
 	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY|os.O_CREATE, 0o600)
 	if err != nil {
 		return nil, err
 	}
 	file.Close()
+	var rotation *fileRotation
+	if o.maxSize > 0 || o.maxAge > 0 {
+		rotation = &fileRotation{
+			path:       path,
+			maxSize:    o.maxSize,
+			maxAge:     o.maxAge,
+			maxBackups: o.maxBackups,
+			start:      time.Now(),
+		}
+	}
 	return func() Writer {
 		file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY|os.O_CREATE, 0o600)
 		if err != nil {
 			return nil
 		}
+		var w io.WriteCloser = file
+		if rotation != nil {
+			w, err = rotation.open(file)
+			if err != nil {
+				file.Close()
+				return nil
+			}
+		}
 		return &fileLogWriter{
-			file:   file,
-			logger: log.New(file, "", log.Ldate|log.Ltime),
+			file:   w,
+			logger: log.New(w, "", o.flags),
 		}
 	}, nil
 }
@@ -1,7 +1,9 @@
 package log_test
 
 import (
+	"encoding/json"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -36,3 +38,65 @@ func TestFileLogger(t *testing.T) {
 		t.Fatal("Expect log text contains 'Test Log', but actually: ", string(b))
 	}
 }
+
+func TestJSONLogger(t *testing.T) {
+	f, err := os.CreateTemp("", "vtest")
+	common.Must(err)
+	path := f.Name()
+	common.Must(f.Close())
+	defer os.Remove(path)
+
+	creator, err := CreateFileLogWriter(path, WithoutTimestamp())
+	common.Must(err)
+
+	handler := NewFormattedLogger(creator, FormatJSON)
+	handler.Handle(&AccessMessage{
+		From:        "127.0.0.1:1234",
+		To:          "tcp:example.com:443",
+		Status:      AccessClosed,
+		InboundTag:  "in",
+		OutboundTag: "direct",
+		Uplink:      100,
+		Downlink:    200,
+		Duration:    time.Second,
+	})
+	time.Sleep(2 * time.Second)
+
+	common.Must(common.Close(handler))
+
+	b, err := os.ReadFile(path)
+	common.Must(err)
+	var fields map[string]interface{}
+	common.Must(json.Unmarshal(b, &fields))
+	if fields["status"] != "closed" || fields["inboundTag"] != "in" || fields["outboundTag"] != "direct" ||
+		fields["uplink"] != float64(100) || fields["downlink"] != float64(200) || fields["duration"] != float64(1) || fields["time"] == nil {
+		t.Error("unexpected log: ", string(b))
+	}
+}
+
+func TestFileLoggerRotation(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "access.log")
+
+	creator, err := CreateFileLogWriter(path, WithRotation(100, 0, 2))
+	common.Must(err)
+
+	w := creator()
+	for i := 0; i < 20; i++ {
+		common.Must(w.Write(strings.Repeat("a", 40)))
+	}
+	common.Must(w.Close())
+
+	entries, err := os.ReadDir(dir)
+	common.Must(err)
+	if len(entries) != 3 {
+		t.Fatal("expect 1 log file and 2 backups, but got ", len(entries))
+	}
+	for _, entry := range entries {
+		info, err := entry.Info()
+		common.Must(err)
+		if info.Size() > 100 {
+			t.Error("expect file ", entry.Name(), " to be rotated before 100 bytes, but got ", info.Size())
+		}
+	}
+}
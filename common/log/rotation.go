@@ -0,0 +1,111 @@
+package log
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const rotationTimeFormat = "20060102-150405.000000000"
+
+// fileRotation is the rotation state of a log file, shared by all writers created for the file.
+type fileRotation struct {
+	path       string
+	maxSize    int64
+	maxAge     time.Duration
+	maxBackups int
+
+	access sync.Mutex
+	start  time.Time
+}
+
+func (r *fileRotation) open(file *os.File) (*rotatingFile, error) {
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	return &rotatingFile{
+		rotation: r,
+		file:     file,
+		size:     info.Size(),
+	}, nil
+}
+
+func (r *fileRotation) shouldRotate(size int64) bool {
+	if r.maxSize > 0 && size > r.maxSize {
+		return true
+	}
+	r.access.Lock()
+	defer r.access.Unlock()
+	return r.maxAge > 0 && time.Since(r.start) > r.maxAge
+}
+
+// rotate moves the current log file to a backup, and opens a new one at the path.
+func (r *fileRotation) rotate(file *os.File) (*os.File, error) {
+	r.access.Lock()
+	defer r.access.Unlock()
+
+	file.Close()
+	now := time.Now()
+	if err := os.Rename(r.path, r.path+"."+now.Format(rotationTimeFormat)); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	r.start = now
+	r.prune()
+	return os.OpenFile(r.path, os.O_APPEND|os.O_WRONLY|os.O_CREATE, 0o600)
+}
+
+// prune removes the oldest backups over maxBackups.
+func (r *fileRotation) prune() {
+	if r.maxBackups <= 0 {
+		return
+	}
+	dir, name := filepath.Split(r.path)
+	entries, err := os.ReadDir(filepath.Clean(dir + "."))
+	if err != nil {
+		return
+	}
+	var backups []string
+	for _, entry := range entries {
+		suffix := strings.TrimPrefix(entry.Name(), name+".")
+		if suffix == entry.Name() {
+			continue
+		}
+		if _, err := time.Parse(rotationTimeFormat, suffix); err == nil {
+			backups = append(backups, entry.Name())
+		}
+	}
+	sort.Strings(backups)
+	for len(backups) > r.maxBackups {
+		os.Remove(filepath.Join(dir, backups[0]))
+		backups = backups[1:]
+	}
+}
+
+// rotatingFile is a log file that rotates itself before a write exceeds the limits.
+type rotatingFile struct {
+	rotation *fileRotation
+	file     *os.File
+	size     int64
+}
+
+func (f *rotatingFile) Write(p []byte) (int, error) {
+	if f.size > 0 && f.rotation.shouldRotate(f.size+int64(len(p))) {
+		file, err := f.rotation.rotate(f.file)
+		if err != nil {
+			return 0, err
+		}
+		f.file = file
+		f.size = 0
+	}
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+func (f *rotatingFile) Close() error {
+	return f.file.Close()
+}
@@ -0,0 +1,14 @@
+// Package process finds the local processes owning sockets, for routing by process.
+package process
+
+//go:generate go run github.com/xtls/xray-core/common/errors/errorgen
+
+// Process is a local process.
+type Process struct {
+	// PID is the process ID, or 0 if only the owner of the socket is known.
+	PID int
+	// Name is the name of the executable of the process.
+	Name string
+	// UID is the user ID owning the socket.
+	UID uint32
+}
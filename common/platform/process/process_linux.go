@@ -0,0 +1,210 @@
+//go:build linux
+// +build linux
+
+package process
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/hex"
+	gonet "net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/xtls/xray-core/common/net"
+)
+
+// procPath is the mount point of procfs.
+var procPath = "/proc"
+
+// scanInterval is the minimal interval between two scans of the open files of all processes.
+const scanInterval = time.Second
+
+// sockets caches the owners of the socket inodes, as scanning the open files of all processes is expensive.
+var sockets struct {
+	sync.Mutex
+	pids    map[uint64]int
+	scanned time.Time
+}
+
+// FindProcess returns the local process owning the socket bound to the source address, looked up in procfs. The
+// process may be unknown while the owner of the socket is, if the socket belongs to another user.
+func FindProcess(source net.Destination) (*Process, error) {
+	if !source.IsValid() || !source.Address.Family().IsIP() {
+		return nil, newError("invalid source: ", source)
+	}
+
+	var tables []string
+	switch source.Network {
+	case net.Network_TCP:
+		tables = []string{"tcp", "tcp6"}
+	case net.Network_UDP:
+		tables = []string{"udp", "udp6"}
+	default:
+		return nil, newError("unsupported network: ", source.Network)
+	}
+
+	// Only a source on a local interface may be bound to the unspecified address.
+	unspecified := source.Network == net.Network_UDP && isLocalIP(source.Address.IP())
+	for _, table := range tables {
+		uid, inode, found, err := findSocket(filepath.Join(procPath, "net", table), source, unspecified)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			continue
+		}
+		process := &Process{UID: uid}
+		if pid, found := findPID(inode); found {
+			process.PID = pid
+			process.Name = processName(pid)
+		}
+		return process, nil
+	}
+	return nil, newError("no socket found for ", source)
+}
+
+// isLocalIP returns whether the IP is an address of a local interface.
+func isLocalIP(ip net.IP) bool {
+	if ip.IsLoopback() {
+		return true
+	}
+	addrs, err := gonet.InterfaceAddrs()
+	if err != nil {
+		return false
+	}
+	for _, addr := range addrs {
+		if ipNet, ok := addr.(*net.IPNet); ok && ipNet.IP.Equal(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// findSocket returns the UID and the inode of the socket bound to the source address in the socket table. If
+// unspecified is true, a socket bound to the unspecified address is matched by the port.
+func findSocket(table string, source net.Destination, unspecified bool) (uint32, uint64, bool, error) {
+	file, err := os.Open(table)
+	if os.IsNotExist(err) {
+		return 0, 0, false, nil
+	}
+	if err != nil {
+		return 0, 0, false, newError("failed to open ", table).Base(err)
+	}
+	defer file.Close()
+
+	ip := source.Address.IP()
+	scanner := bufio.NewScanner(file)
+	// the first line is the header
+	scanner.Scan()
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 10 {
+			continue
+		}
+		localIP, localPort, ok := parseAddress(fields[1])
+		if !ok || localPort != source.Port {
+			continue
+		}
+		if !localIP.Equal(ip) && !(unspecified && localIP.IsUnspecified()) {
+			continue
+		}
+		inode, err := strconv.ParseUint(fields[9], 10, 64)
+		if err != nil || inode == 0 {
+			continue
+		}
+		uid, err := strconv.ParseUint(fields[7], 10, 32)
+		if err != nil {
+			continue
+		}
+		return uint32(uid), inode, true, nil
+	}
+	return 0, 0, false, scanner.Err()
+}
+
+// parseAddress parses an address in socket tables, like "0100007F:1F90", in which the IP is in 32-bit words of the
+// host byte order.
+func parseAddress(s string) (net.IP, net.Port, bool) {
+	ipHex, portHex, found := strings.Cut(s, ":")
+	if !found {
+		return nil, 0, false
+	}
+	raw, err := hex.DecodeString(ipHex)
+	if err != nil || (len(raw) != net.IPv4len && len(raw) != net.IPv6len) {
+		return nil, 0, false
+	}
+	ip := make(net.IP, len(raw))
+	for i := 0; i < len(raw); i += 4 {
+		binary.BigEndian.PutUint32(ip[i:], binary.LittleEndian.Uint32(raw[i:]))
+	}
+	port, err := strconv.ParseUint(portHex, 16, 16)
+	if err != nil {
+		return nil, 0, false
+	}
+	return ip, net.Port(port), true
+}
+
+// findPID returns the ID of the process having the socket of the inode open. The open files of all processes are
+// scanned at most once per scanInterval, so a socket opened shortly after a scan may not be found.
+func findPID(inode uint64) (int, bool) {
+	sockets.Lock()
+	defer sockets.Unlock()
+
+	if pid, found := sockets.pids[inode]; found {
+		return pid, true
+	}
+	if time.Since(sockets.scanned) < scanInterval {
+		return 0, false
+	}
+	sockets.pids = scanSockets()
+	sockets.scanned = time.Now()
+	pid, found := sockets.pids[inode]
+	return pid, found
+}
+
+// scanSockets returns the IDs of the processes by the inodes of the sockets they have open.
+func scanSockets() map[uint64]int {
+	pids := make(map[uint64]int)
+	entries, err := os.ReadDir(procPath)
+	if err != nil {
+		return pids
+	}
+	for _, entry := range entries {
+		pid, err := strconv.Atoi(entry.Name())
+		if err != nil || !entry.IsDir() {
+			continue
+		}
+		fdPath := filepath.Join(procPath, entry.Name(), "fd")
+		fds, err := os.ReadDir(fdPath)
+		if err != nil {
+			continue
+		}
+		for _, fd := range fds {
+			link, err := os.Readlink(filepath.Join(fdPath, fd.Name()))
+			if err != nil || !strings.HasPrefix(link, "socket:[") {
+				continue
+			}
+			if inode, err := strconv.ParseUint(strings.TrimSuffix(strings.TrimPrefix(link, "socket:["), "]"), 10, 64); err == nil {
+				pids[inode] = pid
+			}
+		}
+	}
+	return pids
+}
+
+// processName returns the name of the executable of the process, or its command name if the executable is
+// inaccessible.
+func processName(pid int) string {
+	dir := filepath.Join(procPath, strconv.Itoa(pid))
+	if exe, err := os.Readlink(filepath.Join(dir, "exe")); err == nil {
+		return filepath.Base(strings.TrimSuffix(exe, " (deleted)"))
+	}
+	if comm, err := os.ReadFile(filepath.Join(dir, "comm")); err == nil {
+		return strings.TrimSpace(string(comm))
+	}
+	return ""
+}
@@ -0,0 +1,62 @@
+//go:build linux
+// +build linux
+
+package process_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/common/net"
+	. "github.com/xtls/xray-core/common/platform/process"
+)
+
+func TestFindProcess(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	common.Must(err)
+	defer listener.Close()
+
+	conn, err := net.Dial("tcp", listener.Addr().String())
+	common.Must(err)
+	defer conn.Close()
+
+	// open all sockets before the first lookup, as the owners of sockets are cached
+	udpConn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IP{0, 0, 0, 0}})
+	common.Must(err)
+	defer udpConn.Close()
+	udpPort := net.Port(udpConn.LocalAddr().(*net.UDPAddr).Port)
+
+	process, err := FindProcess(net.DestinationFromAddr(conn.LocalAddr()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if process.PID != os.Getpid() {
+		t.Error("expect PID ", os.Getpid(), ", but got ", process.PID)
+	}
+	if process.UID != uint32(os.Getuid()) {
+		t.Error("expect UID ", os.Getuid(), ", but got ", process.UID)
+	}
+	exe, err := os.Executable()
+	common.Must(err)
+	if process.Name != filepath.Base(exe) {
+		t.Error("expect name ", filepath.Base(exe), ", but got ", process.Name)
+	}
+
+	process, err = FindProcess(net.UDPDestination(net.LocalHostIP, udpPort))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if process.PID != os.Getpid() {
+		t.Error("expect PID ", os.Getpid(), ", but got ", process.PID)
+	}
+
+	if _, err := FindProcess(net.UDPDestination(net.ParseAddress("192.0.2.1"), udpPort)); err == nil {
+		t.Error("expect error for non-local source")
+	}
+
+	if _, err := FindProcess(net.TCPDestination(net.LocalHostIP, 1)); err == nil {
+		t.Error("expect error for unbound address")
+	}
+}
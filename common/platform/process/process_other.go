@@ -0,0 +1,14 @@
+//go:build !linux
+// +build !linux
+
+package process
+
+import (
+	"github.com/xtls/xray-core/common/net"
+)
+
+// FindProcess returns the local process owning the socket bound to the source address. It is only supported on
+// Linux.
+func FindProcess(source net.Destination) (*Process, error) {
+	return nil, newError("finding process is not supported on this platform")
+}
@@ -0,0 +1,99 @@
+package dtls
+
+import (
+	"encoding/binary"
+	"errors"
+
+	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/common/protocol/tls"
+)
+
+type SniffHeader struct {
+	domain string
+}
+
+func (h *SniffHeader) Protocol() string {
+	return "dtls"
+}
+
+func (h *SniffHeader) Domain() string {
+	return h.domain
+}
+
+var (
+	errNotDTLS        = errors.New("not DTLS header")
+	errNotClientHello = errors.New("not client hello")
+)
+
+// IsValidDTLSVersion returns true for DTLS 1.0 and 1.2. DTLS 1.3 records use the version of DTLS 1.2.
+func IsValidDTLSVersion(major, minor byte) bool {
+	return major == 0xFE && (minor == 0xFF || minor == 0xFD)
+}
+
+// SniffDTLS detects DTLS ClientHello records, and returns the server name in it if any.
+func SniffDTLS(b []byte) (*SniffHeader, error) {
+	// record header
+	if len(b) < 13 {
+		return nil, common.ErrNoClue
+	}
+	if b[0] != 0x16 /* DTLS Handshake */ {
+		return nil, errNotDTLS
+	}
+	if !IsValidDTLSVersion(b[1], b[2]) {
+		return nil, errNotDTLS
+	}
+	recordLen := int(binary.BigEndian.Uint16(b[11:13]))
+	if 13+recordLen > len(b) {
+		return nil, errNotDTLS
+	}
+	b = b[13 : 13+recordLen]
+
+	// handshake header
+	if len(b) < 12 {
+		return nil, errNotClientHello
+	}
+	if b[0] != 0x01 /* ClientHello */ {
+		return nil, errNotClientHello
+	}
+	msgLen := int(b[1])<<16 | int(b[2])<<8 | int(b[3])
+	fragmentOffset := int(b[6])<<16 | int(b[7])<<8 | int(b[8])
+	fragmentLen := int(b[9])<<16 | int(b[10])<<8 | int(b[11])
+	if fragmentOffset != 0 || fragmentLen > msgLen || 12+fragmentLen > len(b) {
+		return nil, errNotClientHello
+	}
+
+	h := &SniffHeader{}
+	if fragmentLen == msgLen {
+		h.domain = readServerName(b[12 : 12+fragmentLen])
+	}
+	return h, nil
+}
+
+// readServerName returns the server name in the DTLS ClientHello body, or an empty string if there isn't one.
+func readServerName(body []byte) string {
+	// client_version, random and session_id
+	if len(body) < 35 {
+		return ""
+	}
+	sessionIDLen := int(body[34])
+	if len(body) < 35+sessionIDLen+1 {
+		return ""
+	}
+	cookieOffset := 35 + sessionIDLen
+	cookieLen := int(body[cookieOffset])
+	if len(body) < cookieOffset+1+cookieLen {
+		return ""
+	}
+
+	// The ClientHello without the cookie is the same as the one of TLS, which is parsed with a dummy handshake header.
+	hello := make([]byte, 0, 4+len(body)-1-cookieLen)
+	hello = append(hello, 0x01, 0, 0, 0)
+	hello = append(hello, body[:cookieOffset]...)
+	hello = append(hello, body[cookieOffset+1+cookieLen:]...)
+
+	h := &tls.SniffHeader{}
+	if tls.ReadClientHello(hello, h) != nil {
+		return ""
+	}
+	return h.Domain()
+}
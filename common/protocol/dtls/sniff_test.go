@@ -0,0 +1,80 @@
+package dtls_test
+
+import (
+	"testing"
+
+	"github.com/xtls/xray-core/common"
+	. "github.com/xtls/xray-core/common/protocol/dtls"
+)
+
+func TestDTLSHeaders(t *testing.T) {
+	cases := []struct {
+		input  []byte
+		domain string
+		err    bool
+	}{
+		{
+			input: []byte{
+				0x16, 0xfe, 0xff, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x4c, 0x01, 0x00, 0x00,
+				0x40, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x40, 0xfe, 0xfd, 0x00, 0x01, 0x02, 0x03, 0x04,
+				0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c,
+				0x0d, 0x0e, 0x0f, 0x10, 0x11, 0x12, 0x13, 0x14,
+				0x15, 0x16, 0x17, 0x18, 0x19, 0x1a, 0x1b, 0x1c,
+				0x1d, 0x1e, 0x1f, 0x00, 0x00, 0x00, 0x02, 0xc0,
+				0x2b, 0x01, 0x00, 0x00, 0x14, 0x00, 0x00, 0x00,
+				0x10, 0x00, 0x0e, 0x00, 0x00, 0x0b, 0x65, 0x78,
+				0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x63, 0x6f,
+				0x6d,
+			},
+			domain: "example.com",
+		},
+		{
+			// ClientHello fragment
+			input: []byte{
+				0x16, 0xfe, 0xfd, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x10, 0x01, 0x00, 0x01,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x04, 0xfe, 0xfd, 0x00, 0x01,
+			},
+		},
+		{
+			// Application data
+			input: []byte{
+				0x17, 0xfe, 0xfd, 0x00, 0x01, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x01, 0x00, 0x04, 0x01, 0x02, 0x03,
+				0x04,
+			},
+			err: true,
+		},
+		{
+			// TLS record
+			input: []byte{
+				0x16, 0x03, 0x01, 0x00, 0xc8, 0x01, 0x00, 0x00,
+				0xc4, 0x03, 0x03, 0x1a, 0xac, 0xb2, 0xa8, 0xfe,
+			},
+			err: true,
+		},
+	}
+
+	for _, test := range cases {
+		header, err := SniffDTLS(test.input)
+		if test.err {
+			if err == nil {
+				t.Errorf("Expect error but nil in test %v", test)
+			}
+		} else {
+			if err != nil {
+				t.Errorf("Expect no error but actually %s in test %v", err.Error(), test)
+			}
+			if header.Domain() != test.domain {
+				t.Error("expect domain ", test.domain, " but got ", header.Domain())
+			}
+		}
+	}
+
+	if _, err := SniffDTLS([]byte{0x16, 0xfe, 0xfd}); err != common.ErrNoClue {
+		t.Error("expect no clue for short data, but got ", err)
+	}
+}
@@ -0,0 +1,46 @@
+package mail
+
+import (
+	"errors"
+
+	"github.com/xtls/xray-core/common/net"
+)
+
+type SniffHeader struct {
+	protocol string
+}
+
+func (h *SniffHeader) Protocol() string {
+	return h.protocol
+}
+
+func (h *SniffHeader) Domain() string {
+	return ""
+}
+
+var errNotMail = errors.New("not mail port")
+
+// ports are the well-known ports of the mail protocols, both the ones upgrading to TLS with STARTTLS and the ones
+// with implicit TLS.
+var ports = map[net.Port]string{
+	25:  "smtp",
+	465: "smtp",
+	587: "smtp",
+	110: "pop3",
+	995: "pop3",
+	143: "imap",
+	993: "imap",
+}
+
+// SniffMail detects mail protocols by the destination port. The servers speak first in these protocols, so the
+// client sends nothing to be sniffed until the connection is established. With implicit TLS the client sends the
+// TLS handshake first, which is sniffed in preference to the port.
+func SniffMail(dest net.Destination) (*SniffHeader, error) {
+	if dest.Network != net.Network_TCP {
+		return nil, errNotMail
+	}
+	if protocol, found := ports[dest.Port]; found {
+		return &SniffHeader{protocol: protocol}, nil
+	}
+	return nil, errNotMail
+}
@@ -0,0 +1,42 @@
+package mail_test
+
+import (
+	"testing"
+
+	"github.com/xtls/xray-core/common/net"
+	. "github.com/xtls/xray-core/common/protocol/mail"
+)
+
+func TestSniffMail(t *testing.T) {
+	cases := []struct {
+		dest     net.Destination
+		protocol string
+	}{
+		{net.TCPDestination(net.DomainAddress("smtp.example.com"), 25), "smtp"},
+		{net.TCPDestination(net.DomainAddress("smtp.example.com"), 587), "smtp"},
+		{net.TCPDestination(net.DomainAddress("smtp.example.com"), 465), "smtp"},
+		{net.TCPDestination(net.LocalHostIP, 993), "imap"},
+		{net.TCPDestination(net.LocalHostIP, 995), "pop3"},
+		{net.TCPDestination(net.LocalHostIP, 143), "imap"},
+		{net.TCPDestination(net.LocalHostIP, 110), "pop3"},
+		{net.TCPDestination(net.LocalHostIP, 443), ""},
+		{net.UDPDestination(net.LocalHostIP, 25), ""},
+	}
+
+	for _, test := range cases {
+		header, err := SniffMail(test.dest)
+		if test.protocol == "" {
+			if err == nil {
+				t.Error("expect error for ", test.dest, ", but got ", header.Protocol())
+			}
+			continue
+		}
+		if err != nil {
+			t.Error("expect ", test.protocol, " for ", test.dest, ", but got ", err)
+			continue
+		}
+		if header.Protocol() != test.protocol {
+			t.Error("expect ", test.protocol, " for ", test.dest, ", but got ", header.Protocol())
+		}
+	}
+}
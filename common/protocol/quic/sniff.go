@@ -5,7 +5,9 @@ import (
 	"crypto/aes"
 	"crypto/tls"
 	"encoding/binary"
+	"fmt"
 	"io"
+	"sort"
 
 	"github.com/quic-go/quic-go/quicvarint"
 	"github.com/xtls/xray-core/common"
@@ -16,7 +18,12 @@ import (
 )
 
 type SniffHeader struct {
-	domain string
+	domain  string
+	version uint32
+	alpn    []string
+	ech     bool
+	ja3     string
+	ja4     string
 }
 
 func (s SniffHeader) Protocol() string {
@@ -27,14 +34,54 @@ func (s SniffHeader) Domain() string {
 	return s.domain
 }
 
+// Version returns the name of the QUIC version, like "v1", "v2" or "draft-29".
+func (s SniffHeader) Version() string {
+	switch {
+	case s.version == version1:
+		return "v1"
+	case s.version == version2:
+		return "v2"
+	case s.version&0xffffff00 == 0xff000000:
+		return fmt.Sprintf("draft-%d", s.version&0xff)
+	default:
+		return fmt.Sprintf("0x%08x", s.version)
+	}
+}
+
+// ALPN returns the application protocols offered in the client hello, like "h3".
+func (s SniffHeader) ALPN() []string {
+	return s.alpn
+}
+
+// ECH returns true if the client hello offers Encrypted Client Hello.
+func (s SniffHeader) ECH() bool {
+	return s.ech
+}
+
+// JA3 returns the JA3 fingerprint of the client hello.
+func (s SniffHeader) JA3() string {
+	return s.ja3
+}
+
+// JA4 returns the JA4 fingerprint of the client hello, like "q13d0310h3_55b375c5d22e_cd85d2d88918".
+func (s SniffHeader) JA4() string {
+	return s.ja4
+}
+
 const (
 	versionDraft29 uint32 = 0xff00001d
+	versionDraft32 uint32 = 0xff000020
 	version1       uint32 = 0x1
+	version2       uint32 = 0x6b3343cf
 )
 
+// maxCryptoDataLen is the maximum length of the crypto data in Initial packets to be reassembled.
+const maxCryptoDataLen = 64 * 1024
+
 var (
 	quicSaltOld  = []byte{0xaf, 0xbf, 0xec, 0x28, 0x99, 0x93, 0xd2, 0x4c, 0x9e, 0x97, 0x86, 0xf1, 0x9c, 0x61, 0x11, 0xe0, 0x43, 0x90, 0xa8, 0x99}
 	quicSalt     = []byte{0x38, 0x76, 0x2c, 0xf7, 0xf5, 0x59, 0x34, 0xb3, 0x4d, 0x17, 0x9a, 0xe6, 0xa4, 0xc8, 0x0c, 0xad, 0xcc, 0xbb, 0x7f, 0x0a}
+	quicSaltV2   = []byte{0x0d, 0xed, 0xe3, 0xde, 0xf7, 0x00, 0xa6, 0xdb, 0x81, 0x93, 0x81, 0xbe, 0x6e, 0x26, 0x9d, 0xcb, 0xf9, 0xbd, 0x2e, 0xd9}
 	initialSuite = &CipherSuiteTLS13{
 		ID:     tls.TLS_AES_128_GCM_SHA256,
 		KeyLen: 16,
@@ -45,147 +92,267 @@ var (
 	errNotQuicInitial = errors.New("not initial packet")
 )
 
+// isSupportedVersion returns true for QUIC v1, v2 and drafts 29 to 32, which share the Initial packet protection.
+func isSupportedVersion(version uint32) bool {
+	return version == version1 || version == version2 || (version >= versionDraft29 && version <= versionDraft32)
+}
+
+// cryptoFrame is a CRYPTO frame in Initial packets.
+type cryptoFrame struct {
+	offset uint64
+	data   []byte
+}
+
+// SniffQUIC sniffs the client hello in the Initial packets in b. The client hello may be split into several CRYPTO
+// frames across the Initial packets, in any order, so common.ErrNoClue is returned until all of them arrive.
 func SniffQUIC(b []byte) (*SniffHeader, error) {
+	var version uint32
+	var frames []cryptoFrame
+	for len(b) > 0 {
+		v, payload, packetLen, err := openInitial(b)
+		if err != nil {
+			if version == 0 {
+				return nil, err
+			}
+			// The packets following the Initial ones, or truncated by the sniffing buffer, are ignored.
+			break
+		}
+		if version != 0 && v != version {
+			break
+		}
+		version = v
+		b = b[packetLen:]
+
+		f, err := readCryptoFrames(payload)
+		if err != nil {
+			return nil, err
+		}
+		frames = append(frames, f...)
+	}
+
+	if len(frames) == 0 {
+		return &SniffHeader{version: version}, nil
+	}
+
+	data := reassemble(frames)
+	if len(data) < 4 {
+		return nil, common.ErrNoClue
+	}
+	if data[0] != 0x01 /* client hello */ {
+		return nil, errNotQuicInitial
+	}
+	helloLen := 4 + (int(data[1])<<16 | int(data[2])<<8 | int(data[3]))
+	if helloLen > maxCryptoDataLen {
+		return nil, errNotQuicInitial
+	}
+	if len(data) < helloLen {
+		return nil, common.ErrNoClue
+	}
+
+	tlsHdr := &ptls.SniffHeader{}
+	err := ptls.ReadClientHello(data[:helloLen], tlsHdr)
+	if err == common.ErrNoClue {
+		err = errNotQuicInitial
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	h := &SniffHeader{
+		domain:  tlsHdr.Domain(),
+		version: version,
+		alpn:    tlsHdr.ALPN(),
+		ech:     tlsHdr.ECH(),
+		ja3:     tlsHdr.JA3(),
+	}
+	// JA4 of QUIC differs from the one of TLS over TCP only in the protocol.
+	if ja4 := tlsHdr.JA4(); ja4 != "" {
+		h.ja4 = "q" + ja4[1:]
+	}
+	return h, nil
+}
+
+// openInitial removes the protection of the client Initial packet at the start of b, and returns its version, its
+// payload and the length of the packet in b. b is not modified.
+func openInitial(b []byte) (uint32, []byte, int, error) {
 	buffer := buf.FromBytes(b)
 	typeByte, err := buffer.ReadByte()
 	if err != nil {
-		return nil, errNotQuic
+		return 0, nil, 0, errNotQuic
 	}
 	isLongHeader := typeByte&0x80 > 0
 	if !isLongHeader || typeByte&0x40 == 0 {
-		return nil, errNotQuicInitial
+		return 0, nil, 0, errNotQuicInitial
 	}
 
 	vb, err := buffer.ReadBytes(4)
 	if err != nil {
-		return nil, errNotQuic
+		return 0, nil, 0, errNotQuic
 	}
 
 	versionNumber := binary.BigEndian.Uint32(vb)
-
-	if versionNumber != 0 && typeByte&0x40 == 0 {
-		return nil, errNotQuic
-	} else if versionNumber != versionDraft29 && versionNumber != version1 {
-		return nil, errNotQuic
+	if !isSupportedVersion(versionNumber) {
+		return 0, nil, 0, errNotQuic
 	}
 
-	if (typeByte&0x30)>>4 != 0x0 {
-		return nil, errNotQuicInitial
+	packetType := (typeByte & 0x30) >> 4
+	if versionNumber == version2 {
+		if packetType != 0x1 {
+			return 0, nil, 0, errNotQuicInitial
+		}
+	} else if packetType != 0x0 {
+		return 0, nil, 0, errNotQuicInitial
 	}
 
 	var destConnID []byte
 	if l, err := buffer.ReadByte(); err != nil {
-		return nil, errNotQuic
+		return 0, nil, 0, errNotQuic
 	} else if destConnID, err = buffer.ReadBytes(int32(l)); err != nil {
-		return nil, errNotQuic
+		return 0, nil, 0, errNotQuic
 	}
 
 	if l, err := buffer.ReadByte(); err != nil {
-		return nil, errNotQuic
+		return 0, nil, 0, errNotQuic
 	} else if common.Error2(buffer.ReadBytes(int32(l))) != nil {
-		return nil, errNotQuic
+		return 0, nil, 0, errNotQuic
 	}
 
 	tokenLen, err := quicvarint.Read(buffer)
 	if err != nil || tokenLen > uint64(len(b)) {
-		return nil, errNotQuic
+		return 0, nil, 0, errNotQuic
 	}
 
 	if _, err = buffer.ReadBytes(int32(tokenLen)); err != nil {
-		return nil, errNotQuic
+		return 0, nil, 0, errNotQuic
 	}
 
 	packetLen, err := quicvarint.Read(buffer)
-	if err != nil {
-		return nil, errNotQuic
+	if err != nil || packetLen > uint64(buffer.Len()) {
+		return 0, nil, 0, errNotQuic
 	}
 
 	hdrLen := len(b) - int(buffer.Len())
-
-	origPNBytes := make([]byte, 4)
-	copy(origPNBytes, b[hdrLen:hdrLen+4])
+	// The header protection sample is taken 4 bytes after the start of the packet number.
+	if packetLen < 4+16 {
+		return 0, nil, 0, errNotQuic
+	}
+	packet := make([]byte, hdrLen+int(packetLen))
+	copy(packet, b)
 
 	var salt []byte
-	if versionNumber == version1 {
+	keyLabel, ivLabel, hpLabel := "quic key", "quic iv", "quic hp"
+	switch versionNumber {
+	case version1:
 		salt = quicSalt
-	} else {
+	case version2:
+		salt = quicSaltV2
+		keyLabel, ivLabel, hpLabel = "quicv2 key", "quicv2 iv", "quicv2 hp"
+	default:
 		salt = quicSaltOld
 	}
 	initialSecret := hkdf.Extract(crypto.SHA256.New, destConnID, salt)
 	secret := hkdfExpandLabel(crypto.SHA256, initialSecret, []byte{}, "client in", crypto.SHA256.Size())
-	hpKey := hkdfExpandLabel(initialSuite.Hash, secret, []byte{}, "quic hp", initialSuite.KeyLen)
+	hpKey := hkdfExpandLabel(initialSuite.Hash, secret, []byte{}, hpLabel, initialSuite.KeyLen)
 	block, err := aes.NewCipher(hpKey)
 	if err != nil {
-		return nil, err
+		return 0, nil, 0, err
 	}
 
-	cache := buf.New()
-	defer cache.Release()
-
-	mask := cache.Extend(int32(block.BlockSize()))
-	block.Encrypt(mask, b[hdrLen+4:hdrLen+4+16])
-	b[0] ^= mask[0] & 0xf
-	for i := range b[hdrLen : hdrLen+4] {
-		b[hdrLen+i] ^= mask[i+1]
+	mask := make([]byte, block.BlockSize())
+	block.Encrypt(mask, packet[hdrLen+4:hdrLen+4+16])
+	packet[0] ^= mask[0] & 0xf
+	packetNumberLength := int(packet[0]&0x3 + 1)
+	var packetNumber uint64
+	for i := 0; i < packetNumberLength; i++ {
+		packet[hdrLen+i] ^= mask[i+1]
+		packetNumber = packetNumber<<8 | uint64(packet[hdrLen+i])
 	}
-	packetNumberLength := b[0]&0x3 + 1
-	if packetNumberLength != 1 {
-		return nil, errNotQuicInitial
-	}
-	var packetNumber uint32
-	{
-		n, err := buffer.ReadByte()
-		if err != nil {
-			return nil, err
-		}
-		packetNumber = uint32(n)
-	}
-
-	if packetNumber != 0 {
-		return nil, errNotQuicInitial
-	}
-
-	extHdrLen := hdrLen + int(packetNumberLength)
-	copy(b[extHdrLen:hdrLen+4], origPNBytes[packetNumberLength:])
-	data := b[extHdrLen : int(packetLen)+hdrLen]
 
-	key := hkdfExpandLabel(crypto.SHA256, secret, []byte{}, "quic key", 16)
-	iv := hkdfExpandLabel(crypto.SHA256, secret, []byte{}, "quic iv", 12)
+	extHdrLen := hdrLen + packetNumberLength
+	key := hkdfExpandLabel(crypto.SHA256, secret, []byte{}, keyLabel, 16)
+	iv := hkdfExpandLabel(crypto.SHA256, secret, []byte{}, ivLabel, 12)
 	cipher := AEADAESGCMTLS13(key, iv)
-	nonce := cache.Extend(int32(cipher.NonceSize()))
-	binary.BigEndian.PutUint64(nonce[len(nonce)-8:], uint64(packetNumber))
-	decrypted, err := cipher.Open(b[extHdrLen:extHdrLen], nonce, data, b[:extHdrLen])
+	nonce := make([]byte, cipher.NonceSize())
+	binary.BigEndian.PutUint64(nonce[len(nonce)-8:], packetNumber)
+	decrypted, err := cipher.Open(packet[extHdrLen:extHdrLen], nonce, packet[extHdrLen:], packet[:extHdrLen])
 	if err != nil {
-		return nil, err
-	}
-	buffer = buf.FromBytes(decrypted)
-	frameType, err := buffer.ReadByte()
-	if err != nil {
-		return nil, io.ErrUnexpectedEOF
-	}
-	if frameType != 0x6 {
-		// not crypto frame
-		return &SniffHeader{domain: ""}, nil
-	}
-	if common.Error2(quicvarint.Read(buffer)) != nil {
-		return nil, io.ErrUnexpectedEOF
+		return 0, nil, 0, err
 	}
-	dataLen, err := quicvarint.Read(buffer)
-	if err != nil {
-		return nil, io.ErrUnexpectedEOF
-	}
-	if dataLen > uint64(buffer.Len()) {
-		return nil, io.ErrUnexpectedEOF
-	}
-	frameData, err := buffer.ReadBytes(int32(dataLen))
-	common.Must(err)
-	tlsHdr := &ptls.SniffHeader{}
-	err = ptls.ReadClientHello(frameData, tlsHdr)
-	if err != nil {
-		return nil, err
+	return versionNumber, decrypted, len(packet), nil
+}
+
+// readCryptoFrames returns the CRYPTO frames in the payload of an Initial packet.
+func readCryptoFrames(payload []byte) ([]cryptoFrame, error) {
+	var frames []cryptoFrame
+	buffer := buf.FromBytes(payload)
+	for !buffer.IsEmpty() {
+		frameType, err := quicvarint.Read(buffer)
+		if err != nil {
+			return nil, io.ErrUnexpectedEOF
+		}
+		switch frameType {
+		case 0x00, 0x01: // PADDING, PING
+		case 0x02, 0x03: // ACK
+			var rangeCount uint64
+			for i := 0; i < 4; i++ {
+				v, err := quicvarint.Read(buffer)
+				if err != nil {
+					return nil, io.ErrUnexpectedEOF
+				}
+				if i == 2 {
+					rangeCount = v
+				}
+			}
+			fields := rangeCount * 2
+			if frameType == 0x03 {
+				fields += 3
+			}
+			if fields > uint64(buffer.Len()) {
+				return nil, io.ErrUnexpectedEOF
+			}
+			for i := uint64(0); i < fields; i++ {
+				if common.Error2(quicvarint.Read(buffer)) != nil {
+					return nil, io.ErrUnexpectedEOF
+				}
+			}
+		case 0x06: // CRYPTO
+			offset, err := quicvarint.Read(buffer)
+			if err != nil {
+				return nil, io.ErrUnexpectedEOF
+			}
+			dataLen, err := quicvarint.Read(buffer)
+			if err != nil || dataLen > uint64(buffer.Len()) {
+				return nil, io.ErrUnexpectedEOF
+			}
+			if offset+dataLen > maxCryptoDataLen {
+				return nil, errNotQuicInitial
+			}
+			data, err := buffer.ReadBytes(int32(dataLen))
+			common.Must(err)
+			frames = append(frames, cryptoFrame{offset: offset, data: data})
+		default:
+			// CONNECTION_CLOSE is the only other frame allowed in Initial packets.
+			return frames, nil
+		}
 	}
+	return frames, nil
+}
 
-	return &SniffHeader{domain: tlsHdr.Domain()}, nil
+// reassemble returns the contiguous crypto data from offset 0 in the frames.
+func reassemble(frames []cryptoFrame) []byte {
+	sort.Slice(frames, func(i, j int) bool {
+		return frames[i].offset < frames[j].offset
+	})
+	var data []byte
+	for _, f := range frames {
+		if f.offset > uint64(len(data)) {
+			break
+		}
+		if end := f.offset + uint64(len(f.data)); end > uint64(len(data)) {
+			data = append(data, f.data[uint64(len(data))-f.offset:]...)
+		}
+	}
+	return data
 }
 
 func hkdfExpandLabel(hash crypto.Hash, secret, context []byte, label string, length int) []byte {
@@ -2,6 +2,7 @@ package quic_test
 
 import (
 	"encoding/hex"
+	"reflect"
 	"testing"
 
 	"github.com/xtls/xray-core/common"
@@ -16,3 +17,57 @@ func TestSniffQUIC(t *testing.T) {
 		t.Error("failed")
 	}
 }
+
+func TestSniffQUICMultipleInitials(t *testing.T) {
+	cases := []struct {
+		packets []string
+		version string
+	}{
+		{
+			packets: []string{
+				"cb000000010801020304050607080000449b243fb6f2e94c769aeb6055bbfe3abb10a13b1578de4210e59ce0e689a3a43b0f50ccb1ed9499e0ff4b4c93a9d58c64d9d583a9ecf532f1ae888a52cf83902be95705ceb7b0cdb8e558aaa57b60a9fef73d7c19212732ae2f2aa537258e18b52f8d4c8fb74b8d9151173a5d3ba052eb082f3a0c53c1aa39135a9c58387fb26865732c4088627394a09a4b794b469a89c2643c906ba1cf2d77100b0ab347f6538f4bea953c7a63ae333cdf15dcfc58d6076406152f8f408febed4a04ebba9ae8083d51d8f2c20883c01ddce2978099e3babe88a0e79086084195b80ee7b9105dfec8673977d43ca40fa034895efc630e8b78d2266313aebc2476282f7abc3bd22f36f035ed5348e03294a57329c3c8da900c75b81287e5df9a009fc389198d0be1ccfe37d4c6826a842e9e3fe1dba5bb925b584aa8e1bd9e2fbfb3d88f1c076a169564c514e141eff06fb6b397a9daec4b0e4b69745a173d948aa85b23719a4840985f6256ae6f380408814da0fdf8989e966ef652f11afe050e499f7148b652b9fa15c790f3f6642359134a6d7972001311d0d52f49caf0d0cce7a149f80419e6a712a0357257acdc54ecbc31b4f217501368c02ed471c6c735a9f17c1cc593700b963a4da3d3fd956c848064b727fdca6333839b32bb59df0176d854cbd5d71c67d029eb62754868c25642cc3349d221361a684385f68e193408d64ccf0b7441e9d0f4fdfa256632248cc796851f3c43b0fb4d9bd37beeacb9a6c1cbba953c1f78fced24da0b26f0c01b00c7de2f1d95914a7bc2a08dd5d73ba41de63ff660336d23561725db2a577bafbb2de381cc170e0372231052398700a0539d8c07220fdf24c003833589a2c4d7a866fc481e1cde92f886b14277a94ad32a9c10c99722d268e857e430a4207b6573693954a381b30eacc8455fe71d9cbed4c41a416ba762c843627066e5f76a3ff500d8bc911b317219d3e95428162feaf527513303c11f86919998058512a04a33a590fec58f3532f0d47801f2bb7ddb74658e093738ba53a4c5953fd2eabec0ea2bf8a5277d4a38c1f99c7ca3ffbe325d9465d8b5a0be91496e0dd205ed0f3c2f335216c98beb9a4d9801c0c1512ffb35d4e7e665df314c768d4d77085a4c7bf3d5ba21709abf78a0a995afde7b7078d47325a664d47aeb466aa3e0129686f56545f6be182ce47b0676e1c5787b82c4030eeb8067e5357b246831e20353a3a5a97366c4e0edece2b1216b2515aa500ed2120c28903944b06949187922b30282be82e38e1a172aafab96e995367485c9360ff44a7fa3d71b894f58e176550a9e1b1910bf4c1ec30476c9bf5678e25cdd3ef2b8721ce6a6a9b91a9db96761b43a95f261fa14203b1f3a5414607f410bd70495220915b27eb54edb3724413b30f51d45f31d24480920ba40bedb7eddf4432cd41ba5ed5114c744399f6771bcc423328f8b062c7ae55281d37c0702cfdf9408caccfd15f5f02d90105075d9eabe51baea7bd6336095d3ffba024651d03525cec073e9ed728f1dd71484ecadc3489bed1868bb5e5a1d95813cde6f059365b1a7e9cc959e02cf7974416f02376de9ffcd7957081736bd4ca92549c7413a41e9e5529d3a6086d26aacbfa59dfbf3a63a9aa7964fa0d1a6f1f93a3b0ef1ac37ba47",
+				"cb000000010801020304050607080000449b5619c8f75cdb03812a07390f62804f51dff4d3ae462219af04c3d36bc7c00e583b5ca9c072a03b09e825cec7015beb2cb19bbe6119ec8681487ef5ffa8fb4e29c17181d2f77812f238a071caeb50a9d3b80181b3a3ffa5cb29dc195c6d801f02072add7fd08bc5bba50dcba1935cc9628abbf47ee8f181d5d2973f3f538086719a7cfa432bf84b4d7016808533f49e235d867aaefa3854accb7fd7ec1bf9fd542a9a8adcaa6e8d61a1c83caac3bc71af71f9db7eb08b0a78db345217b0a3da1a0362390387116e6cb6449458236d139126414a97ccbb0d4bc64c60a62075470fe2cc6367765e3c80892da073af74fb266bba470ec3232fd5a4d5af5c8bd6b851f6784244fbc66b01e73b24196f26eadbea42494ba2c18ad40c70588ef91414cb21e91d8dd7787199e282c5c1cbe3de263c9cbae5c044cf22d2a45c8f794d371fd507fe77256a05e17abcb322ea01bac1b4c01921a103c9aa6db1681d6a64226ff8b5990f43a762ab135d8f2959fd9b4336b712b53e9d2a11097328c8d6d5e4f93d7d8f4f62d80d8d540bbededaa4db98c45c81f3f1e1f223bf11771572b5a51883d59f211a49a6b861a87991b4f0b9411deb386dfffaaebad0a0f61617518ecfd072f83dbe9f9d1230b0e1c1df3c3bd384c8212b20eeeb76baf875c66f9868649b7b734ab0c6be8dc816601565015d247d4a85877d09af8eac23228366cf4e7a08ef1d201b6f0cc1996ecb469c366795d3faf676dbd8b1a45deeca19d13fa557a39415ac4bd0d016e374ccd49673c2354dd776713e4fba3e750eab1eb343201c1f370b530278c2b7fbc220aad709a00bbfde7a65b3a96ee5d230118b1cc3ae34a1331bbc538019622f41a6c93e10d8c6ca45e3f17e0cfa6ffb84b55fcae2e0b23d1018473fbc6a533ed0e160ed47010da090721fcfa961edc618fd5ec8a43c2e9ae855ac76972d37f03fbd4d019f268cf26b53288ddf5968205384b9022c8f06f771fa7b5b6abdeb10f46d186ff88427c2d082b9311a2bbd89083fb327a21f56d4c8031d512bb60998211f81e33980bdb52999d6d5c50f234969f39f3be01d4b2d7055acfc10f1e851b33653a4612f7ddaed7010ee0607f090811dde0440708c8a56375e2bb2391fab233a0d68eccc75fbb2d366a0a50f32ede3cb527431a035d56f0e3f85fa8f3b24ea4899e509c8a93051acf64a6634de494680fa073081d40d3a3ae9504c132c94cfc81ad3837c1a9217f754dc350c78c9afc656136ff11ca38b5b79aec5589af5b6ce9532949a756ecc4e15ceefcb3e7cdcf0b48ec362c39b955991dc922219ab49935869d8f5eefc8adc1e39ad2681faa889e24202b7066d9b07dd20b8d016e9d2570c826971d552c01d36b5505d65bb81a26c5763ce995b585ab509f4e12602236bb07cadaf11e68c0f2283172d4fa26f4dd757e4ad0539f1b99acc6437f5fc312cc1243a61cbf2ee9f75a4da8286ac3724e7243d76134ebef025ef800d5d331599d877a0933327eed94bb5640eae4644a55c983d3abb23beb41551a5bc6ada76dbf0a0a3b47c639986f48d8a36330a8e69539ce6cc0f6fa78960a9b9286a01835f43b90bbba5725c4e81c60485e19edfe82c63d40cb11401a41c3da3f85335fdd8a34632c03f2ec5144eded275aa872830",
+			},
+			version: "v1",
+		},
+		{
+			packets: []string{
+				"d76b3343cf0801020304050607080000449bf395f8e4d0d3e1cea777f2b5f7d01b94b33699ed66cae5c202ec24e8421c760bb7593ee196e836cbeb20f81e341a9c6b8a021e9e06f663b423c8af01cd1577cd11dc53b1a9a106a8c976f45a84bf26a5e1638f0037390f31af71e9d865d52c36c8c5242881416a70968a06db8dbdc4ee0c08d1016c5c39e4710ea22e3d4c854081a25fa0478063324d8d52affd81c44546d1a03ef1ea761feee919e40712d94ecf2895eb583507f12bde3bf4e91a9ab8c0855916358525985467e614c86dc26fdbe0200f8e407e753fe3e5586c3644632b0c807bbf76d82863c9c4b7e4f3a168ffc951e5f16af7b87ecfa6932be41682d78842251eb714183fcdd76507792a28d337ee5e66314fff9f5460fc5ea9611bb64e055bd3b2fd65d253daf58ec2b74c947e3b46b6a111c71faa8c6fae5949f83508f140a26f13d763bf0910b6c1e8925728553f67bc163662e64e9c46c9fa2f5f3b2ca27be4f6f7cb05e3466f45d5c2213152d44bf9300b21e6c4dadae900dda725948363e6c4233d03654f1728e1057be041b8528ebb5014ff294046d6911a3a14078855c400fbde439d424fcd1d677b3fc1bc7caace81129756078baac7bcb1727713edfe32d381f2f295d3f38b5d2111866057261e864e53d8bb5d64c22b49e5c3919a70a8bc9cb4d1e7176703a52edea25aba279ae5750155f2953767abed31b86afe25c4a6e883bcedfb9b4161815a9217a0fb89348681c10857d3d97b93a6a5b7fb4abfa870bfae91662efd9cb4529d47bfc258a84313e74994f4c9f320260f6dcf53acc561ada33c108730e5142edf73cb11d0892cc6da1aa8657d970f20842e19aee659b6a0e43428792b59c262126d7a71177eaa360f442a4c0d4b21b5efaa963ab59bcde1d9003c0a2b1b2b80682f04f8f2d2acd7626f9d9c97e013e336ba5931861ff082d78478a0c4c0e141ebe3a1765c20b86da8a481f7e086dacd6a42142633df8d2bf7156eaa5557e4d84920d071250228396461027eea7648d8e2ec33ce9cdfcfb0f20979ae5b6113230a4e49317bb2ddf2a25a0aaaf1b6392bc276b0156842fc846d6c8b3f54345050d74b025a40abb8de828ba87647fcaca33940708c7e1c766a5b888a69599a43998dc25f20ee41f4d6723a43beb5c3868d9a245c1a732980c8ee75bc14d9ff93be51db756c1a087ecfbb93ab431acc8eee79fbfda18aa1b71e96b4551db5777aa89eb95a2505c99187278451c87011ca38b8ae80fe4848c150bdee0cf8215802cba241476037ea02e1004c6695421d9b405fa0049ca5ee543b0a70525617bcd5daf4bf8b83cef616d15f3805c9186e80af492ba4240d3af6cd29772066511db1c5872f65e9aaa5373ae3aac9ae904de2ab90afa05ac0af659527703602066555cfa0441b7fbb9caedeb21be6a661a59bdf8ab1084e1eb7c9dd692e053046b8e7ded00b691492c1dacabd9080d0b611439d0e0296d629f50b9d4e8e8f63bb0b328a9cc9f9a08dd76cba91a74002015d8c08b735c0760df91a70f30d893de519aae6b64b5aef0bc3c4d85e42855f738f547cdcde24e7fbe11b54485aa6394a44740aa9927bb7fc2cfc4db054103c988ccf80ccd001fddb4bff386c58069f80d0ec1d3e1e17bef0ba846d9cf1fc03cde48f83251906344b7c7cf0aa",
+				"d86b3343cf0801020304050607080000449bf99b15c0b1c4acf3f4f3f362cd8e54163d68b7f547e8599be5e3aed636ff701ad2d9cbd39711207c9bdd772e6e19cab657b092a77e5f47728f85395ffcd2e0ee6fa37e9ea9c19c09057aec85aa6f0c24a30528d94d029afcb2f58acb802d6635c063e405cd9c0f310608f2ea24e5c43c0c9f6db59a2d5a4405fef04a8520baf3d2d3f7608951684d5246b7acaa46ef93886c2481577f1937d9aa40b471025c53933fe11960e5bb16630f98d10ea1e99d8409ed65b39e18ee55d127ac2a3be97b4bade768021e13adb028fe6e69a765a9d741ca6abadf3e70f0123a913920b9f6b75441c412e1af464f39c1df3b17c81ef631bf4cf3ecf3cc0018e4f785991942035307466d0b22ff4fd74b47238109f6a2f0c0286d5589a9576e7a01d6679fa888fc0e98906dd27c13eeca9e7bc616767e09ac43ef826299afad813f9c5027c3b2b6f782299a199406ab846ebc64b4e1110e770c9c1bd2b45099671ba1cd5b05880d4b655a61f25107d5cb6e6bb730842c9b003609feac8b12581360317dd56a56041204a1d563d1bbed0a41c8c0ce86261ed1ffa93e6d691e4e0241045ff936a2503fd711be798c89ff3c5a90eb936d756d96d165abf1a45617201db2b924f41a2ed039e92377fa82ad12473c9aecdc09c349b8955d0800e32713684ddc28b8aa926fa70c69b67252bb0f3f77dcef364ac0e9fdf3af699547dac20044a2584c45862ca1e177777f9d529e82225a23b8e2882696c980423fa023539a142f7004697088751cf69dff4bd899b90da4ff31e37dc5838bf13550b164fc731ecae6c566de872de53c9ed458327481d4dcd81b292d30427886551990e89647741ee99cb997eb517d0252b668cb78bf797caeddd3072ca5de4065929103647418f7880b2773722d923d3e0cf5615c34405d7f7974c386cca251ad0183076175bef4ae2e82cd7c86331bf4ac031afedb6f5e2a72a6290f19bf25911381cddf14be32d15ed131add405a48826a67e09440925f800f5be7ec2f797ecf38db37943222f76011f4c51a0a85f7d0305897de765fadfd6053c27c4d772507ab728b26e374f5534f46c5528eb9ca89556a8ed99eade67a56bbe9b43806cc4a09c946f370f3cc0017cc703638ca128ab2e6c0aee5681b2d5f5b72170a0805bf0e27abe30282eb5e2e6fdedb31cfc3841c73aa9753f51faecad430b3b27fb17d8e6e09ba38386c7a6186df801670ee73c23d5b3c630c6851fe6ce052354bbbdff6871d80a561515eba4672aee311dcde205fb44d4f652d1d35511cbfdb7ceae2ce251d7ee2ba02f4fa7724aca50c6d444cbdd5d32ef065a4e667faa25a7712e410690605f47e80f6e2a645cce3b5d546ddb139a653f518ec8cf01a19d1a37e46b4de0270586d10cc9472bc5315c900faff2a69608e4a9ab240f4e65708f9354575aada7991ec9bf32e685547613da53d7209aaae70e453b41e690ce00ce552a5bdf0cc697fe47cad0986be8a2503896215b27183ba3fb6e0eb45cb0a16b100842786283be456d35dada18e73a72befb5de6e2c520aff70d06cf55447a52cc80a55dfaf672582b0a9bd35f0cd030332bc265fc8534399fead5ad939c2029150e7f2177aa6728fbbc8b7fe144b7575824bb98ca0b958ea31bc680279c3278f27c5c6652ad",
+			},
+			version: "v2",
+		},
+	}
+
+	for _, test := range cases {
+		var pkts []byte
+		for _, p := range test.packets {
+			pkt, err := hex.DecodeString(p)
+			common.Must(err)
+			pkts = append(pkts, pkt...)
+		}
+
+		first := pkts[:len(pkts)/2]
+		if _, err := quic.SniffQUIC(first); err != common.ErrNoClue {
+			t.Error("expect no clue with the first Initial packet, but got ", err)
+		}
+
+		// sniffing again with all the packets, as the dispatcher does
+		quicHdr, err := quic.SniffQUIC(pkts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if quicHdr.Domain() != "www.example.com" {
+			t.Error("unexpected domain ", quicHdr.Domain())
+		}
+		if quicHdr.Version() != test.version {
+			t.Error("expect version ", test.version, ", but got ", quicHdr.Version())
+		}
+		if !reflect.DeepEqual(quicHdr.ALPN(), []string{"h3"}) {
+			t.Error("unexpected ALPN ", quicHdr.ALPN())
+		}
+		if quicHdr.JA4() != "q12d0103h3_0f2cb44170f4_052bfcbedb83" {
+			t.Error("unexpected JA4 ", quicHdr.JA4())
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package rdp
+
+import (
+	"encoding/binary"
+	"errors"
+
+	"github.com/xtls/xray-core/common"
+)
+
+type SniffHeader struct{}
+
+func (h *SniffHeader) Protocol() string {
+	return "rdp"
+}
+
+func (h *SniffHeader) Domain() string {
+	return ""
+}
+
+var errNotRDP = errors.New("not RDP header")
+
+// SniffRDP detects the X.224 Connection Request in a TPKT packet, which starts an RDP connection. See MS-RDPBCGR
+// section 2.2.1.1.
+func SniffRDP(b []byte) (*SniffHeader, error) {
+	if len(b) < 8 {
+		return nil, common.ErrNoClue
+	}
+
+	// TPKT header, version 3
+	if b[0] != 0x03 || b[1] != 0x00 {
+		return nil, errNotRDP
+	}
+	length := int(binary.BigEndian.Uint16(b[2:4]))
+	if length < 11 {
+		return nil, errNotRDP
+	}
+
+	// X.224 Connection Request TPDU
+	if int(b[4]) != length-5 || b[5] != 0xE0 {
+		return nil, errNotRDP
+	}
+	// DST-REF is zero in Connection Requests
+	if b[6] != 0 || b[7] != 0 {
+		return nil, errNotRDP
+	}
+
+	return &SniffHeader{}, nil
+}
@@ -0,0 +1,54 @@
+package rdp_test
+
+import (
+	"testing"
+
+	"github.com/xtls/xray-core/common"
+	. "github.com/xtls/xray-core/common/protocol/rdp"
+)
+
+func TestSniffRDP(t *testing.T) {
+	cases := []struct {
+		input  []byte
+		noClue bool
+		err    bool
+	}{
+		{
+			// Connection Request with a routing token cookie and an RDP Negotiation Request
+			input: append(append([]byte{0x03, 0x00, 0x00, 0x2c, 0x27, 0xe0, 0x00, 0x00, 0x00, 0x00, 0x00},
+				[]byte("Cookie: mstshash=user\r\n")...),
+				0x01, 0x00, 0x08, 0x00, 0x0b, 0x00, 0x00, 0x00),
+		},
+		{
+			input:  []byte{0x03, 0x00, 0x00},
+			noClue: true,
+		},
+		{
+			// Data TPDU
+			input: []byte{0x03, 0x00, 0x00, 0x0c, 0x02, 0xf0, 0x80, 0x7f, 0x65, 0x82, 0x01, 0x94},
+			err:   true,
+		},
+		{
+			input: []byte("GET / HTTP/1.1\r\n"),
+			err:   true,
+		},
+	}
+
+	for _, test := range cases {
+		_, err := SniffRDP(test.input)
+		switch {
+		case test.noClue:
+			if err != common.ErrNoClue {
+				t.Error("expect no clue for ", test.input, ", but got ", err)
+			}
+		case test.err:
+			if err == nil || err == common.ErrNoClue {
+				t.Error("expect error for ", test.input, ", but got ", err)
+			}
+		default:
+			if err != nil {
+				t.Error("expect RDP connection request in ", test.input, ", but got ", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package ssh
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/xtls/xray-core/common"
+)
+
+type SniffHeader struct{}
+
+func (h *SniffHeader) Protocol() string {
+	return "ssh"
+}
+
+func (h *SniffHeader) Domain() string {
+	return ""
+}
+
+var errNotSSH = errors.New("not SSH banner")
+
+var bannerPrefixes = [][]byte{
+	[]byte("SSH-2.0-"),
+	[]byte("SSH-1.99-"),
+}
+
+// SniffSSH detects the identification string sent by an SSH client. See RFC 4253 section 4.2.
+func SniffSSH(b []byte) (*SniffHeader, error) {
+	for _, prefix := range bannerPrefixes {
+		if len(b) < len(prefix) {
+			if bytes.HasPrefix(prefix, b) {
+				return nil, common.ErrNoClue
+			}
+			continue
+		}
+		if bytes.HasPrefix(b, prefix) {
+			return &SniffHeader{}, nil
+		}
+	}
+	return nil, errNotSSH
+}
@@ -0,0 +1,41 @@
+package ssh_test
+
+import (
+	"testing"
+
+	"github.com/xtls/xray-core/common"
+	. "github.com/xtls/xray-core/common/protocol/ssh"
+)
+
+func TestSniffSSH(t *testing.T) {
+	cases := []struct {
+		input  string
+		noClue bool
+		err    bool
+	}{
+		{input: "SSH-2.0-OpenSSH_9.6\r\n"},
+		{input: "SSH-1.99-Cisco-1.25\r\n"},
+		{input: "SSH-2", noClue: true},
+		{input: "SSH-1.99", noClue: true},
+		{input: "SSH-1.5-OpenSSH_3.0\r\n", err: true},
+		{input: "GET / HTTP/1.1\r\n", err: true},
+	}
+
+	for _, test := range cases {
+		_, err := SniffSSH([]byte(test.input))
+		switch {
+		case test.noClue:
+			if err != common.ErrNoClue {
+				t.Error("expect no clue for ", test.input, ", but got ", err)
+			}
+		case test.err:
+			if err == nil || err == common.ErrNoClue {
+				t.Error("expect error for ", test.input, ", but got ", err)
+			}
+		default:
+			if err != nil {
+				t.Error("expect SSH banner in ", test.input, ", but got ", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package stun
+
+import (
+	"encoding/binary"
+	"errors"
+
+	"github.com/xtls/xray-core/common"
+)
+
+type SniffHeader struct{}
+
+func (h *SniffHeader) Protocol() string {
+	return "stun"
+}
+
+func (h *SniffHeader) Domain() string {
+	return ""
+}
+
+var errNotSTUN = errors.New("not STUN message")
+
+const magicCookie = 0x2112A442
+
+// SniffSTUN detects STUN messages, which are also used by TURN and ICE. See RFC 8489 section 5.
+func SniffSTUN(b []byte) (*SniffHeader, error) {
+	if len(b) < 20 {
+		return nil, common.ErrNoClue
+	}
+
+	// The most significant 2 bits of every STUN message are zeroes.
+	if b[0]&0xC0 != 0 {
+		return nil, errNotSTUN
+	}
+	if binary.BigEndian.Uint32(b[4:8]) != magicCookie {
+		return nil, errNotSTUN
+	}
+	// Message length is always a multiple of 4, as attributes are padded.
+	length := int(binary.BigEndian.Uint16(b[2:4]))
+	if length%4 != 0 || 20+length > len(b) {
+		return nil, errNotSTUN
+	}
+
+	return &SniffHeader{}, nil
+}
@@ -0,0 +1,66 @@
+package stun_test
+
+import (
+	"testing"
+
+	"github.com/xtls/xray-core/common"
+	. "github.com/xtls/xray-core/common/protocol/stun"
+)
+
+func TestSniffSTUN(t *testing.T) {
+	cases := []struct {
+		input  []byte
+		noClue bool
+		err    bool
+	}{
+		{
+			// Binding Request with a SOFTWARE attribute
+			input: []byte{
+				0x00, 0x01, 0x00, 0x08, 0x21, 0x12, 0xa4, 0x42,
+				0xb7, 0xe7, 0xa7, 0x01, 0xbc, 0x34, 0xd6, 0x86,
+				0xfa, 0x87, 0xdf, 0xae, 0x80, 0x22, 0x00, 0x04,
+				0x74, 0x65, 0x73, 0x74,
+			},
+		},
+		{
+			input:  []byte{0x00, 0x01, 0x00, 0x00, 0x21, 0x12, 0xa4, 0x42},
+			noClue: true,
+		},
+		{
+			// RFC 3489 Binding Request without the magic cookie
+			input: []byte{
+				0x00, 0x01, 0x00, 0x00, 0x01, 0x02, 0x03, 0x04,
+				0xb7, 0xe7, 0xa7, 0x01, 0xbc, 0x34, 0xd6, 0x86,
+				0xfa, 0x87, 0xdf, 0xae,
+			},
+			err: true,
+		},
+		{
+			// truncated attributes
+			input: []byte{
+				0x00, 0x01, 0x00, 0x08, 0x21, 0x12, 0xa4, 0x42,
+				0xb7, 0xe7, 0xa7, 0x01, 0xbc, 0x34, 0xd6, 0x86,
+				0xfa, 0x87, 0xdf, 0xae,
+			},
+			err: true,
+		},
+	}
+
+	for _, test := range cases {
+		_, err := SniffSTUN(test.input)
+		switch {
+		case test.noClue:
+			if err != common.ErrNoClue {
+				t.Error("expect no clue for ", test.input, ", but got ", err)
+			}
+		case test.err:
+			if err == nil || err == common.ErrNoClue {
+				t.Error("expect error for ", test.input, ", but got ", err)
+			}
+		default:
+			if err != nil {
+				t.Error("expect STUN message in ", test.input, ", but got ", err)
+			}
+		}
+	}
+}
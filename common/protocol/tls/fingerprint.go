@@ -0,0 +1,165 @@
+package tls
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// clientHelloInfo is the fields of a client hello used by fingerprints.
+type clientHelloInfo struct {
+	version             uint16
+	ciphers             []uint16
+	extensions          []uint16
+	groups              []uint16
+	pointFormats        []byte
+	signatureAlgorithms []uint16
+	supportedVersions   []uint16
+}
+
+// readUint16s reads a list of big-endian uint16 values. An odd byte at the end is ignored.
+func readUint16s(b []byte) []uint16 {
+	values := make([]uint16, 0, len(b)/2)
+	for i := 0; i+1 < len(b); i += 2 {
+		values = append(values, uint16(b[i])<<8|uint16(b[i+1]))
+	}
+	return values
+}
+
+// isGREASE returns true for the reserved values in RFC 8701, which are ignored by fingerprints.
+func isGREASE(v uint16) bool {
+	return v&0x0f0f == 0x0a0a && v>>8 == v&0xff
+}
+
+func withoutGREASE(values []uint16) []uint16 {
+	ret := make([]uint16, 0, len(values))
+	for _, v := range values {
+		if !isGREASE(v) {
+			ret = append(ret, v)
+		}
+	}
+	return ret
+}
+
+func joinUint16s(values []uint16, format string) string {
+	s := make([]string, len(values))
+	for i, v := range values {
+		s[i] = fmt.Sprintf(format, v)
+	}
+	return strings.Join(s, ",")
+}
+
+// JA3 returns the JA3 fingerprint of the client hello, as an MD5 hash in hex.
+// https://github.com/salesforce/ja3
+func (h *SniffHeader) JA3() string {
+	if h.hello.version == 0 {
+		return ""
+	}
+	pointFormats := make([]string, len(h.hello.pointFormats))
+	for i, f := range h.hello.pointFormats {
+		pointFormats[i] = strconv.Itoa(int(f))
+	}
+	s := strings.Join([]string{
+		strconv.Itoa(int(h.hello.version)),
+		strings.ReplaceAll(joinUint16s(withoutGREASE(h.hello.ciphers), "%d"), ",", "-"),
+		strings.ReplaceAll(joinUint16s(withoutGREASE(h.hello.extensions), "%d"), ",", "-"),
+		strings.ReplaceAll(joinUint16s(withoutGREASE(h.hello.groups), "%d"), ",", "-"),
+		strings.Join(pointFormats, "-"),
+	}, ",")
+	sum := md5.Sum([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+// JA4 returns the JA4 fingerprint of the client hello over TCP, like "t13d1516h2_8daaf6152771_e5627efa2ab1".
+// https://github.com/FoxIO-LLC/ja4/blob/main/technical_details/JA4.md
+func (h *SniffHeader) JA4() string {
+	if h.hello.version == 0 {
+		return ""
+	}
+
+	version := h.hello.version
+	if versions := withoutGREASE(h.hello.supportedVersions); len(versions) > 0 {
+		version = 0
+		for _, v := range versions {
+			if v > version {
+				version = v
+			}
+		}
+	}
+	var versionString string
+	switch version {
+	case 0x0304:
+		versionString = "13"
+	case 0x0303:
+		versionString = "12"
+	case 0x0302:
+		versionString = "11"
+	case 0x0301:
+		versionString = "10"
+	case 0x0300:
+		versionString = "s3"
+	default:
+		versionString = "00"
+	}
+
+	sni := "i"
+	if h.domain != "" {
+		sni = "d"
+	}
+
+	alpn := "00"
+	if len(h.alpn) > 0 && len(h.alpn[0]) > 0 {
+		first, last := h.alpn[0][0], h.alpn[0][len(h.alpn[0])-1]
+		if isAlphanumeric(first) && isAlphanumeric(last) {
+			alpn = string([]byte{first, last})
+		} else {
+			alpn = hex.EncodeToString([]byte{first})[:1] + hex.EncodeToString([]byte{last})[1:]
+		}
+	}
+
+	ciphers := withoutGREASE(h.hello.ciphers)
+	extensions := withoutGREASE(h.hello.extensions)
+	a := fmt.Sprintf("t%s%s%02d%02d%s", versionString, sni, countOf(ciphers), countOf(extensions), alpn)
+
+	sortedCiphers := append([]uint16(nil), ciphers...)
+	sort.Slice(sortedCiphers, func(i, j int) bool { return sortedCiphers[i] < sortedCiphers[j] })
+
+	var sortedExtensions []uint16
+	for _, e := range extensions {
+		if e != extensionServerName && e != extensionALPN {
+			sortedExtensions = append(sortedExtensions, e)
+		}
+	}
+	sort.Slice(sortedExtensions, func(i, j int) bool { return sortedExtensions[i] < sortedExtensions[j] })
+	c := joinUint16s(sortedExtensions, "%04x")
+	if algorithms := withoutGREASE(h.hello.signatureAlgorithms); len(algorithms) > 0 {
+		c += "_" + joinUint16s(algorithms, "%04x")
+	}
+
+	return a + "_" + truncatedHash(joinUint16s(sortedCiphers, "%04x")) + "_" + truncatedHash(c)
+}
+
+// countOf returns the number of the values for JA4, which is at most 99.
+func countOf(values []uint16) int {
+	if len(values) > 99 {
+		return 99
+	}
+	return len(values)
+}
+
+func isAlphanumeric(b byte) bool {
+	return (b >= '0' && b <= '9') || (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z')
+}
+
+// truncatedHash returns the first 12 hex characters of the SHA256 hash of s, or zeroes if s is empty.
+func truncatedHash(s string) string {
+	if s == "" {
+		return "000000000000"
+	}
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])[:12]
+}
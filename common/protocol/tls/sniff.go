@@ -10,6 +10,9 @@ import (
 
 type SniffHeader struct {
 	domain string
+	alpn   []string
+	ech    bool
+	hello  clientHelloInfo
 }
 
 func (h *SniffHeader) Protocol() string {
@@ -20,21 +23,44 @@ func (h *SniffHeader) Domain() string {
 	return h.domain
 }
 
+// ALPN returns the application protocols offered in the client hello.
+func (h *SniffHeader) ALPN() []string {
+	return h.alpn
+}
+
+// ECH returns true if the client hello offers Encrypted Client Hello, in which case the domain is the public name of
+// the client-facing server, rather than the one the client is connecting to.
+func (h *SniffHeader) ECH() bool {
+	return h.ech
+}
+
 var (
 	errNotTLS         = errors.New("not TLS header")
 	errNotClientHello = errors.New("not client hello")
 )
 
+const (
+	extensionServerName          = 0x00
+	extensionSupportedGroups     = 0x0a
+	extensionECPointFormats      = 0x0b
+	extensionSignatureAlgorithms = 0x0d
+	extensionALPN                = 0x10
+	extensionSupportedVersions   = 0x2b
+	extensionECH                 = 0xfe0d
+)
+
 func IsValidTLSVersion(major, minor byte) bool {
 	return major == 3
 }
 
-// ReadClientHello returns server name (if any) from TLS client hello message.
+// ReadClientHello returns server name (if any) from TLS client hello message. The application protocols, the
+// presence of Encrypted Client Hello and the fields for fingerprints are recorded in the header as well.
 // https://github.com/golang/go/blob/master/src/crypto/tls/handshake_messages.go#L300
 func ReadClientHello(data []byte, h *SniffHeader) error {
 	if len(data) < 42 {
 		return common.ErrNoClue
 	}
+	h.hello.version = uint16(data[4])<<8 | uint16(data[5])
 	sessionIDLen := int(data[38])
 	if sessionIDLen > 32 || len(data) < 39+sessionIDLen {
 		return common.ErrNoClue
@@ -49,6 +75,7 @@ func ReadClientHello(data []byte, h *SniffHeader) error {
 	if cipherSuiteLen%2 == 1 || len(data) < 2+cipherSuiteLen {
 		return errNotClientHello
 	}
+	h.hello.ciphers = readUint16s(data[2 : 2+cipherSuiteLen])
 	data = data[2+cipherSuiteLen:]
 	if len(data) < 1 {
 		return common.ErrNoClue
@@ -72,6 +99,18 @@ func ReadClientHello(data []byte, h *SniffHeader) error {
 		return errNotClientHello
 	}
 
+	err := readExtensions(data, h)
+	if h.domain != "" {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return errNotTLS
+}
+
+// readExtensions reads the extensions of the client hello into the header.
+func readExtensions(data []byte, h *SniffHeader) error {
 	for len(data) != 0 {
 		if len(data) < 4 {
 			return errNotClientHello
@@ -82,8 +121,10 @@ func ReadClientHello(data []byte, h *SniffHeader) error {
 		if len(data) < length {
 			return errNotClientHello
 		}
+		h.hello.extensions = append(h.hello.extensions, extension)
 
-		if extension == 0x00 { /* extensionServerName */
+		switch extension {
+		case extensionServerName:
 			d := data[:length]
 			if len(d) < 2 {
 				return errNotClientHello
@@ -112,15 +153,58 @@ func ReadClientHello(data []byte, h *SniffHeader) error {
 						return errNotClientHello
 					}
 					h.domain = serverName
-					return nil
+					break
 				}
 				d = d[nameLen:]
 			}
+		case extensionALPN:
+			d := data[:length]
+			if len(d) < 2 {
+				return errNotClientHello
+			}
+			protocolsLen := int(d[0])<<8 | int(d[1])
+			d = d[2:]
+			if len(d) != protocolsLen {
+				return errNotClientHello
+			}
+			for len(d) > 0 {
+				protocolLen := int(d[0])
+				d = d[1:]
+				if protocolLen == 0 || len(d) < protocolLen {
+					return errNotClientHello
+				}
+				h.alpn = append(h.alpn, string(d[:protocolLen]))
+				d = d[protocolLen:]
+			}
+		case extensionECH:
+			h.ech = true
+		case extensionSupportedGroups, extensionSignatureAlgorithms:
+			d := data[:length]
+			if len(d) < 2 || int(d[0])<<8|int(d[1]) != len(d)-2 {
+				return errNotClientHello
+			}
+			if extension == extensionSupportedGroups {
+				h.hello.groups = readUint16s(d[2:])
+			} else {
+				h.hello.signatureAlgorithms = readUint16s(d[2:])
+			}
+		case extensionECPointFormats:
+			d := data[:length]
+			if len(d) < 1 || int(d[0]) != len(d)-1 {
+				return errNotClientHello
+			}
+			h.hello.pointFormats = append([]byte(nil), d[1:]...)
+		case extensionSupportedVersions:
+			d := data[:length]
+			if len(d) < 1 || int(d[0]) != len(d)-1 {
+				return errNotClientHello
+			}
+			h.hello.supportedVersions = readUint16s(d[1:])
 		}
 		data = data[length:]
 	}
 
-	return errNotTLS
+	return nil
 }
 
 func SniffTLS(b []byte) (*SniffHeader, error) {
@@ -1,8 +1,14 @@
 package tls_test
 
 import (
+	gotls "crypto/tls"
+	"io"
+	"net"
+	"reflect"
+	"strings"
 	"testing"
 
+	"github.com/xtls/xray-core/common"
 	. "github.com/xtls/xray-core/common/protocol/tls"
 )
 
@@ -159,3 +165,48 @@ func TestTLSHeaders(t *testing.T) {
 		}
 	}
 }
+
+func TestTLSClientHelloFingerprint(t *testing.T) {
+	client, server := net.Pipe()
+	go func() {
+		gotls.Client(client, &gotls.Config{
+			ServerName: "www.example.com",
+			NextProtos: []string{"h2", "http/1.1"},
+			MinVersion: gotls.VersionTLS12,
+		}).Handshake()
+	}()
+	defer client.Close()
+	defer server.Close()
+
+	b := make([]byte, 2048)
+	n, err := io.ReadAtLeast(server, b, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for {
+		header, err := SniffTLS(b[:n])
+		if err == nil {
+			if header.Domain() != "www.example.com" {
+				t.Error("unexpected domain ", header.Domain())
+			}
+			if !reflect.DeepEqual(header.ALPN(), []string{"h2", "http/1.1"}) {
+				t.Error("unexpected ALPN ", header.ALPN())
+			}
+			if len(header.JA3()) != 32 {
+				t.Error("unexpected JA3 ", header.JA3())
+			}
+			if ja4 := header.JA4(); !strings.HasPrefix(ja4, "t13d") || !strings.Contains(ja4, "h2_") || len(ja4) != 36 {
+				t.Error("unexpected JA4 ", ja4)
+			}
+			return
+		}
+		if err != common.ErrNoClue {
+			t.Fatal(err)
+		}
+		m, err := server.Read(b[n:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		n += m
+	}
+}
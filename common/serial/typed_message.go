@@ -5,6 +5,7 @@ import (
 	"reflect"
 
 	"github.com/golang/protobuf/proto"
+	protov2 "google.golang.org/protobuf/proto"
 )
 
 // ToTypedMessage converts a proto Message into TypedMessage.
@@ -12,7 +13,8 @@ func ToTypedMessage(message proto.Message) *TypedMessage {
 	if message == nil {
 		return nil
 	}
-	settings, _ := proto.Marshal(message)
+	// Marshal deterministically, so that equal configs produce equal TypedMessages.
+	settings, _ := protov2.MarshalOptions{Deterministic: true}.Marshal(proto.MessageV2(message))
 	return &TypedMessage{
 		Type:  GetMessageType(message),
 		Value: settings,
@@ -73,6 +73,13 @@ type Content struct {
 	// Protocol of current content.
 	Protocol string
 
+	// ALPN of current content, i.e. the application protocols offered in the sniffed TLS or QUIC client hello.
+	ALPN []string
+
+	// JA3 and JA4 fingerprints of the sniffed TLS or QUIC client hello.
+	JA3 string
+	JA4 string
+
 	SniffingRequest SniffingRequest
 
 	Attributes map[string]string
@@ -0,0 +1,489 @@
+package core
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/common/log"
+	"github.com/xtls/xray-core/common/serial"
+	"github.com/xtls/xray-core/features"
+	"github.com/xtls/xray-core/features/inbound"
+	"github.com/xtls/xray-core/features/outbound"
+)
+
+// Reload applies the given config to the running Instance without restarting it.
+//
+// Inbound and outbound handlers are diffed by tag against the current config, and only the added, removed or changed
+// ones are touched. Unchanged inbounds keep their listening sockets and existing sessions. App settings that changed
+// are applied in place to the features implementing features.Reloadable, such as router, DNS and policy. Other
+// changes, including changes to untagged handlers, need a restart and are only logged.
+//
+// All new handlers and features are created before any of them is applied. If applying them fails, the previous
+// handlers and app settings are restored, and the running config is left unchanged.
+//
+// If config is nil, the config is loaded again with the loader set by SetConfigLoader.
+func (s *Instance) Reload(config *Config) error {
+	if config == nil {
+		s.access.Lock()
+		loader := s.configLoader
+		s.access.Unlock()
+		if loader == nil {
+			return newError("no config loader set")
+		}
+		c, err := loader()
+		if err != nil {
+			return newError("failed to load config").Base(err)
+		}
+		config = c
+	}
+
+	s.access.Lock()
+	defer s.access.Unlock()
+
+	r := &reload{instance: s}
+	if err := r.prepare(config); err != nil {
+		r.discard()
+		return newError("failed to reload config").Base(err)
+	}
+	if err := r.apply(); err != nil {
+		r.rollback()
+		return newError("failed to reload config, previous config restored").Base(err)
+	}
+	r.commit()
+
+	s.config = r.config
+	newError("Xray config reloaded").AtWarning().WriteToLog()
+	return nil
+}
+
+// SetConfigLoader sets the function to load the config from its original source, such as the config files the
+// Instance was started with. It is used by Reload when no config is given.
+func (s *Instance) SetConfigLoader(loader func() (*Config, error)) {
+	s.access.Lock()
+	defer s.access.Unlock()
+
+	s.configLoader = loader
+}
+
+// reload is a reload of an Instance in progress. It is prepared without touching the running Instance, then applied,
+// and finally committed or rolled back.
+type reload struct {
+	instance *Instance
+	// config is the config in effect after the reload.
+	config *Config
+
+	apps        []*appReload
+	appliedApps int
+
+	// removeOutbounds are the tagged outbound handlers to remove, in the order of the current config.
+	removeOutbounds  []outbound.Handler
+	removedOutbounds int
+	addOutbounds     []outbound.Handler
+	addedOutbounds   int
+
+	// removeInbounds are the configs of the tagged inbound handlers to remove, in the order of the current config.
+	removeInbounds  []*InboundHandlerConfig
+	removedInbounds int
+	addInbounds     []inbound.Handler
+	addedInbounds   int
+}
+
+// appReload is the new settings of a reloadable feature.
+type appReload struct {
+	reloadable features.Reloadable
+	feature    features.Feature
+	// old is the current settings, which are restored on rollback.
+	old *serial.TypedMessage
+}
+
+// prepare creates the new handlers and features, without applying them.
+func (r *reload) prepare(config *Config) error {
+	apps, err := r.prepareApps(config.App)
+	if err != nil {
+		return newError("failed to prepare app settings").Base(err)
+	}
+	outbounds, err := r.prepareOutbounds(config.Outbound)
+	if err != nil {
+		return newError("failed to prepare outbounds").Base(err)
+	}
+	inbounds, err := r.prepareInbounds(config.Inbound)
+	if err != nil {
+		return newError("failed to prepare inbounds").Base(err)
+	}
+
+	r.config = &Config{
+		Inbound:   inbounds,
+		Outbound:  outbounds,
+		App:       apps,
+		Transport: r.instance.config.Transport,
+		Extension: r.instance.config.Extension,
+	}
+	return nil
+}
+
+// apply swaps the prepared handlers and features in. It stops at the first failure, and records the progress for
+// rollback.
+func (r *reload) apply() error {
+	s := r.instance
+	ohm := s.GetFeature(outbound.ManagerType()).(outbound.Manager)
+	ihm := s.GetFeature(inbound.ManagerType()).(inbound.Manager)
+
+	for _, handler := range r.removeOutbounds {
+		if err := ohm.RemoveHandler(s.ctx, handler.Tag()); err != nil {
+			return newError("failed to remove outbound ", handler.Tag()).Base(err)
+		}
+		r.removedOutbounds++
+		newError("removed outbound ", handler.Tag()).AtInfo().WriteToLog()
+	}
+	for _, handler := range r.addOutbounds {
+		r.addedOutbounds++
+		if err := ohm.AddHandler(s.ctx, handler); err != nil {
+			return newError("failed to add outbound ", handler.Tag()).Base(err)
+		}
+		newError("added outbound ", handler.Tag()).AtInfo().WriteToLog()
+	}
+
+	// Remove first, so that a changed handler can listen on the same port again.
+	for _, config := range r.removeInbounds {
+		if err := ihm.RemoveHandler(s.ctx, config.Tag); err != nil {
+			return newError("failed to remove inbound ", config.Tag).Base(err)
+		}
+		r.removedInbounds++
+		newError("removed inbound ", config.Tag).AtInfo().WriteToLog()
+	}
+	for _, handler := range r.addInbounds {
+		r.addedInbounds++
+		if err := ihm.AddHandler(s.ctx, handler); err != nil {
+			return newError("failed to add inbound ", handler.Tag()).Base(err)
+		}
+		newError("added inbound ", handler.Tag()).AtInfo().WriteToLog()
+	}
+
+	for _, app := range r.apps {
+		r.appliedApps++
+		if err := app.reloadable.Reload(app.feature); err != nil {
+			return newError("failed to reload ", app.old.Type).Base(err)
+		}
+		newError("reloaded ", app.old.Type).AtInfo().WriteToLog()
+	}
+	return nil
+}
+
+// rollback restores the state before apply. The removed inbound handlers are recreated from their configs, as they
+// are closed on removal.
+func (r *reload) rollback() {
+	s := r.instance
+	ohm := s.GetFeature(outbound.ManagerType()).(outbound.Manager)
+	ihm := s.GetFeature(inbound.ManagerType()).(inbound.Manager)
+
+	for i := r.appliedApps - 1; i >= 0; i-- {
+		app := r.apps[i]
+		feature, err := s.createFeature(app.old)
+		if err == nil {
+			err = app.reloadable.Reload(feature)
+		}
+		if err != nil {
+			newError("failed to restore ", app.old.Type).Base(err).AtError().WriteToLog()
+		}
+	}
+	r.discardApps(r.appliedApps)
+
+	for i, handler := range r.addInbounds {
+		if i < r.addedInbounds {
+			if h, err := ihm.GetHandler(s.ctx, handler.Tag()); err == nil && h == handler {
+				ihm.RemoveHandler(s.ctx, handler.Tag())
+				continue
+			}
+		}
+		handler.Close()
+	}
+	for _, config := range r.removeInbounds[:r.removedInbounds] {
+		if err := AddInboundHandler(s, config); err != nil {
+			newError("failed to restore inbound ", config.Tag).Base(err).AtError().WriteToLog()
+		}
+	}
+
+	for _, handler := range r.addOutbounds[:r.addedOutbounds] {
+		if ohm.GetHandler(handler.Tag()) == handler {
+			ohm.RemoveHandler(s.ctx, handler.Tag())
+		}
+	}
+	for _, handler := range r.addOutbounds {
+		handler.Close()
+	}
+	for _, handler := range r.removeOutbounds[:r.removedOutbounds] {
+		if err := ohm.AddHandler(s.ctx, handler); err != nil {
+			newError("failed to restore outbound ", handler.Tag()).Base(err).AtError().WriteToLog()
+		}
+	}
+}
+
+// discard releases the prepared handlers and features, if the reload fails before being applied.
+func (r *reload) discard() {
+	r.discardApps(0)
+	for _, handler := range r.addOutbounds {
+		handler.Close()
+	}
+	for _, handler := range r.addInbounds {
+		handler.Close()
+	}
+}
+
+// discardApps closes the prepared features from the i-th one, which are not applied. Features such as the logger
+// take effect on creation, so the running log handler is registered again.
+func (r *reload) discardApps(i int) {
+	for _, app := range r.apps[i:] {
+		common.Close(app.feature)
+	}
+	for _, feature := range r.instance.appFeatures {
+		if handler, ok := feature.(log.Handler); ok {
+			log.RegisterHandler(handler)
+		}
+	}
+}
+
+// commit releases the removed outbound handlers, once the reload succeeds.
+func (r *reload) commit() {
+	for _, handler := range r.removeOutbounds {
+		if err := handler.Close(); err != nil {
+			newError("failed to close outbound ", handler.Tag()).Base(err).AtWarning().WriteToLog()
+		}
+	}
+}
+
+// createFeature creates a feature with the settings.
+func (s *Instance) createFeature(settings *serial.TypedMessage) (features.Feature, error) {
+	instance, err := settings.GetInstance()
+	if err != nil {
+		return nil, err
+	}
+	obj, err := CreateObject(s, instance)
+	if err != nil {
+		return nil, err
+	}
+	feature, ok := obj.(features.Feature)
+	if !ok {
+		return nil, newError("not a feature: ", settings.Type)
+	}
+	return feature, nil
+}
+
+// prepareApps creates the features whose settings changed, and returns the app settings in effect afterwards.
+func (r *reload) prepareApps(configs []*serial.TypedMessage) ([]*serial.TypedMessage, error) {
+	s := r.instance
+	current := make(map[string]*serial.TypedMessage, len(s.config.App))
+	for _, settings := range s.config.App {
+		current[settings.Type] = settings
+	}
+
+	applied := make([]*serial.TypedMessage, 0, len(configs))
+	for _, settings := range configs {
+		old, found := current[settings.Type]
+		delete(current, settings.Type)
+		if found && proto.Equal(old, settings) {
+			applied = append(applied, old)
+			continue
+		}
+
+		reloadable, ok := s.appFeatures[settings.Type].(features.Reloadable)
+		if !found || !ok {
+			newError("settings of ", settings.Type, " changed, restart to apply").AtWarning().WriteToLog()
+			if found {
+				applied = append(applied, old)
+			}
+			continue
+		}
+
+		feature, err := s.createFeature(settings)
+		if err != nil {
+			return nil, newError("failed to create ", settings.Type).Base(err)
+		}
+		r.apps = append(r.apps, &appReload{
+			reloadable: reloadable,
+			feature:    feature,
+			old:        old,
+		})
+		applied = append(applied, settings)
+	}
+
+	for _, settings := range s.config.App {
+		if _, found := current[settings.Type]; found {
+			newError("settings of ", settings.Type, " removed, restart to apply").AtWarning().WriteToLog()
+			applied = append(applied, settings)
+		}
+	}
+
+	return applied, nil
+}
+
+// prepareInbounds creates the changed inbound handlers, and returns the inbound configs in effect afterwards.
+func (r *reload) prepareInbounds(configs []*InboundHandlerConfig) ([]*InboundHandlerConfig, error) {
+	s := r.instance
+	ihm := s.GetFeature(inbound.ManagerType()).(inbound.Manager)
+
+	current := make(map[string]*InboundHandlerConfig, len(s.config.Inbound))
+	for _, config := range s.config.Inbound {
+		if config.Tag != "" {
+			current[config.Tag] = config
+		}
+	}
+
+	keep := make(map[string]bool, len(configs))
+	for _, config := range configs {
+		if old, found := current[config.Tag]; found && proto.Equal(old, config) {
+			if _, err := ihm.GetHandler(s.ctx, config.Tag); err == nil {
+				keep[config.Tag] = true
+			}
+		}
+	}
+
+	for _, config := range s.config.Inbound {
+		if config.Tag == "" || keep[config.Tag] {
+			continue
+		}
+		if _, err := ihm.GetHandler(s.ctx, config.Tag); err == nil {
+			r.removeInbounds = append(r.removeInbounds, config)
+		}
+	}
+
+	applied := make([]*InboundHandlerConfig, 0, len(configs))
+	for _, config := range configs {
+		if config.Tag == "" {
+			continue
+		}
+		if keep[config.Tag] {
+			applied = append(applied, current[config.Tag])
+			continue
+		}
+		rawHandler, err := CreateObject(s, config)
+		if err != nil {
+			return nil, newError("failed to create inbound ", config.Tag).Base(err)
+		}
+		handler, ok := rawHandler.(inbound.Handler)
+		if !ok {
+			return nil, newError("not an InboundHandler: ", config.Tag)
+		}
+		r.addInbounds = append(r.addInbounds, handler)
+		applied = append(applied, config)
+	}
+
+	untagged := filterInbounds(s.config.Inbound, func(c *InboundHandlerConfig) bool { return c.Tag == "" })
+	if !inboundsEqual(untagged, filterInbounds(configs, func(c *InboundHandlerConfig) bool { return c.Tag == "" })) {
+		newError("untagged inbounds changed, restart to apply").AtWarning().WriteToLog()
+	}
+	return append(applied, untagged...), nil
+}
+
+// prepareOutbounds creates the changed outbound handlers, and returns the outbound configs in effect afterwards.
+func (r *reload) prepareOutbounds(configs []*OutboundHandlerConfig) ([]*OutboundHandlerConfig, error) {
+	s := r.instance
+	ohm := s.GetFeature(outbound.ManagerType()).(outbound.Manager)
+
+	current := make(map[string]*OutboundHandlerConfig, len(s.config.Outbound))
+	for _, config := range s.config.Outbound {
+		if config.Tag != "" {
+			current[config.Tag] = config
+		}
+	}
+
+	keep := make(map[string]bool, len(configs))
+	for _, config := range configs {
+		if old, found := current[config.Tag]; found && proto.Equal(old, config) && ohm.GetHandler(config.Tag) != nil {
+			keep[config.Tag] = true
+		}
+	}
+
+	// The first outbound is the default one. As the manager takes the first handler added after the default one
+	// is removed, both the old and the new default are recreated if the default changes.
+	if len(s.config.Outbound) > 0 && len(configs) > 0 && s.config.Outbound[0].Tag != configs[0].Tag {
+		if s.config.Outbound[0].Tag == "" || configs[0].Tag == "" {
+			newError("default outbound changed from or to an untagged one, restart to apply").AtWarning().WriteToLog()
+		} else {
+			delete(keep, s.config.Outbound[0].Tag)
+			delete(keep, configs[0].Tag)
+		}
+	}
+
+	for _, config := range s.config.Outbound {
+		if config.Tag == "" || keep[config.Tag] {
+			continue
+		}
+		if handler := ohm.GetHandler(config.Tag); handler != nil {
+			r.removeOutbounds = append(r.removeOutbounds, handler)
+		}
+	}
+
+	applied := make([]*OutboundHandlerConfig, 0, len(configs))
+	for _, config := range configs {
+		if config.Tag == "" {
+			continue
+		}
+		if keep[config.Tag] {
+			applied = append(applied, current[config.Tag])
+			continue
+		}
+		rawHandler, err := CreateObject(s, config)
+		if err != nil {
+			return nil, newError("failed to create outbound ", config.Tag).Base(err)
+		}
+		handler, ok := rawHandler.(outbound.Handler)
+		if !ok {
+			return nil, newError("not an OutboundHandler: ", config.Tag)
+		}
+		r.addOutbounds = append(r.addOutbounds, handler)
+		applied = append(applied, config)
+	}
+
+	untagged := filterOutbounds(s.config.Outbound, func(c *OutboundHandlerConfig) bool { return c.Tag == "" })
+	if !outboundsEqual(untagged, filterOutbounds(configs, func(c *OutboundHandlerConfig) bool { return c.Tag == "" })) {
+		newError("untagged outbounds changed, restart to apply").AtWarning().WriteToLog()
+	}
+	if len(s.config.Outbound) > 0 && s.config.Outbound[0].Tag == "" {
+		// Keep the untagged default outbound in front.
+		return append(untagged, applied...), nil
+	}
+	return append(applied, untagged...), nil
+}
+
+func filterInbounds(configs []*InboundHandlerConfig, f func(*InboundHandlerConfig) bool) []*InboundHandlerConfig {
+	var filtered []*InboundHandlerConfig
+	for _, config := range configs {
+		if f(config) {
+			filtered = append(filtered, config)
+		}
+	}
+	return filtered
+}
+
+func filterOutbounds(configs []*OutboundHandlerConfig, f func(*OutboundHandlerConfig) bool) []*OutboundHandlerConfig {
+	var filtered []*OutboundHandlerConfig
+	for _, config := range configs {
+		if f(config) {
+			filtered = append(filtered, config)
+		}
+	}
+	return filtered
+}
+
+func inboundsEqual(a, b []*InboundHandlerConfig) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !proto.Equal(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func outboundsEqual(a, b []*OutboundHandlerConfig) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !proto.Equal(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -93,6 +93,12 @@ type Instance struct {
 	featureResolutions []resolution
 	running            bool
 
+	// config is the config currently applied, and appFeatures maps the types of
+	// its app settings to the features created from them. Both are used by Reload.
+	config       *Config
+	appFeatures  map[string]features.Feature
+	configLoader func() (*Config, error)
+
 	ctx context.Context
 }
 
@@ -190,6 +196,8 @@ func initInstanceWithConfig(config *Config, server *Instance) (bool, error) {
 		return true, err
 	}
 
+	server.config = config
+	server.appFeatures = make(map[string]features.Feature, len(config.App))
 	for _, appSettings := range config.App {
 		settings, err := appSettings.GetInstance()
 		if err != nil {
@@ -203,6 +211,7 @@ func initInstanceWithConfig(config *Config, server *Instance) (bool, error) {
 			if err := server.AddFeature(feature); err != nil {
 				return true, err
 			}
+			server.appFeatures[appSettings.Type] = feature
 		}
 	}
 
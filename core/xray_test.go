@@ -1,12 +1,15 @@
 package core_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/xtls/xray-core/app/dispatcher"
+	"github.com/xtls/xray-core/app/log"
 	"github.com/xtls/xray-core/app/proxyman"
 	"github.com/xtls/xray-core/common"
+	clog "github.com/xtls/xray-core/common/log"
 	"github.com/xtls/xray-core/common/net"
 	"github.com/xtls/xray-core/common/protocol"
 	"github.com/xtls/xray-core/common/serial"
@@ -14,8 +17,11 @@ import (
 	. "github.com/xtls/xray-core/core"
 	"github.com/xtls/xray-core/features/dns"
 	"github.com/xtls/xray-core/features/dns/localdns"
+	"github.com/xtls/xray-core/features/inbound"
+	feature_outbound "github.com/xtls/xray-core/features/outbound"
 	_ "github.com/xtls/xray-core/main/distro/all"
 	"github.com/xtls/xray-core/proxy/dokodemo"
+	"github.com/xtls/xray-core/proxy/freedom"
 	"github.com/xtls/xray-core/proxy/vmess"
 	"github.com/xtls/xray-core/proxy/vmess/outbound"
 	"github.com/xtls/xray-core/testing/servers/tcp"
@@ -90,3 +96,193 @@ func TestXrayClose(t *testing.T) {
 	common.Must(err)
 	server.Close()
 }
+
+func TestXrayReload(t *testing.T) {
+	inboundConfig := func(tag string, port net.Port) *InboundHandlerConfig {
+		return &InboundHandlerConfig{
+			Tag: tag,
+			ReceiverSettings: serial.ToTypedMessage(&proxyman.ReceiverConfig{
+				PortList: &net.PortList{
+					Range: []*net.PortRange{net.SinglePortRange(port)},
+				},
+				Listen: net.NewIPOrDomain(net.LocalHostIP),
+			}),
+			ProxySettings: serial.ToTypedMessage(&dokodemo.Config{
+				Address: net.NewIPOrDomain(net.LocalHostIP),
+				Port:    uint32(0),
+				NetworkList: &net.NetworkList{
+					Network: []net.Network{net.Network_TCP},
+				},
+			}),
+		}
+	}
+	outboundConfig := func(tag string) *OutboundHandlerConfig {
+		return &OutboundHandlerConfig{
+			Tag:           tag,
+			ProxySettings: serial.ToTypedMessage(&freedom.Config{}),
+		}
+	}
+
+	config := &Config{
+		App: []*serial.TypedMessage{
+			serial.ToTypedMessage(&dispatcher.Config{}),
+			serial.ToTypedMessage(&proxyman.InboundConfig{}),
+			serial.ToTypedMessage(&proxyman.OutboundConfig{}),
+		},
+		Inbound: []*InboundHandlerConfig{
+			inboundConfig("keep", tcp.PickPort()),
+			inboundConfig("change", tcp.PickPort()),
+			inboundConfig("remove", tcp.PickPort()),
+		},
+		Outbound: []*OutboundHandlerConfig{
+			outboundConfig("direct"),
+		},
+	}
+
+	server, err := New(config)
+	common.Must(err)
+	common.Must(server.Start())
+	defer server.Close()
+
+	ihm := server.GetFeature(inbound.ManagerType()).(inbound.Manager)
+	kept, err := ihm.GetHandler(context.Background(), "keep")
+	common.Must(err)
+	changed, err := ihm.GetHandler(context.Background(), "change")
+	common.Must(err)
+
+	common.Must(server.Reload(&Config{
+		App: config.App,
+		Inbound: []*InboundHandlerConfig{
+			config.Inbound[0],
+			inboundConfig("change", tcp.PickPort()),
+			inboundConfig("add", tcp.PickPort()),
+		},
+		Outbound: []*OutboundHandlerConfig{
+			outboundConfig("direct"),
+			outboundConfig("block"),
+		},
+	}))
+
+	if h, err := ihm.GetHandler(context.Background(), "keep"); err != nil || h != kept {
+		t.Error("expect inbound 'keep' to be kept")
+	}
+	if h, err := ihm.GetHandler(context.Background(), "change"); err != nil || h == changed {
+		t.Error("expect inbound 'change' to be recreated")
+	}
+	if _, err := ihm.GetHandler(context.Background(), "add"); err != nil {
+		t.Error("expect inbound 'add' to be added")
+	}
+	if _, err := ihm.GetHandler(context.Background(), "remove"); err == nil {
+		t.Error("expect inbound 'remove' to be removed")
+	}
+	ohm := server.GetFeature(feature_outbound.ManagerType()).(feature_outbound.Manager)
+	if ohm.GetHandler("block") == nil {
+		t.Error("expect outbound 'block' to be added")
+	}
+
+	// The port of the new inbound is taken, so that the reload fails after the other changes are applied.
+	busy, err := net.Listen("tcp", "127.0.0.1:0")
+	common.Must(err)
+	defer busy.Close()
+	busyPort := net.Port(busy.Addr().(*net.TCPAddr).Port)
+	block := ohm.GetHandler("block")
+
+	if err := server.Reload(&Config{
+		App: config.App,
+		Inbound: []*InboundHandlerConfig{
+			inboundConfig("add", tcp.PickPort()),
+			inboundConfig("busy", busyPort),
+		},
+		Outbound: []*OutboundHandlerConfig{
+			outboundConfig("direct"),
+			outboundConfig("new"),
+		},
+	}); err == nil {
+		t.Fatal("expect reload to fail")
+	}
+
+	for _, tag := range []string{"keep", "change", "add"} {
+		if _, err := ihm.GetHandler(context.Background(), tag); err != nil {
+			t.Error("expect inbound '", tag, "' to be restored")
+		}
+	}
+	if _, err := ihm.GetHandler(context.Background(), "busy"); err == nil {
+		t.Error("expect inbound 'busy' to be rolled back")
+	}
+	if ohm.GetHandler("block") != block {
+		t.Error("expect outbound 'block' to be restored")
+	}
+	if ohm.GetHandler("new") != nil {
+		t.Error("expect outbound 'new' to be rolled back")
+	}
+}
+
+// recordHandler is a log handler that records the messages.
+type recordHandler chan string
+
+func (h recordHandler) Handle(msg clog.Message) {
+	select {
+	case h <- msg.String():
+	default:
+	}
+}
+
+func TestXrayReloadLogDiscarded(t *testing.T) {
+	recorder := make(recordHandler, 16)
+	common.Must(log.RegisterHandlerCreator(log.LogType_Event, func(log.LogType, log.HandlerCreatorOptions) (clog.Handler, error) {
+		return recorder, nil
+	}))
+
+	config := &Config{
+		App: []*serial.TypedMessage{
+			serial.ToTypedMessage(&log.Config{
+				ErrorLogType:  log.LogType_None,
+				ErrorLogLevel: clog.Severity_Debug,
+			}),
+			serial.ToTypedMessage(&dispatcher.Config{}),
+			serial.ToTypedMessage(&proxyman.InboundConfig{}),
+			serial.ToTypedMessage(&proxyman.OutboundConfig{}),
+		},
+	}
+
+	server, err := New(config)
+	common.Must(err)
+	common.Must(server.Start())
+	defer server.Close()
+
+	// The log settings change, but the inbound fails to be created, after the new logger is created.
+	if err := server.Reload(&Config{
+		App: []*serial.TypedMessage{
+			serial.ToTypedMessage(&log.Config{
+				ErrorLogType:  log.LogType_Event,
+				ErrorLogLevel: clog.Severity_Debug,
+			}),
+			config.App[1],
+			config.App[2],
+			config.App[3],
+		},
+		Inbound: []*InboundHandlerConfig{
+			{
+				Tag:              "invalid",
+				ReceiverSettings: serial.ToTypedMessage(&proxyman.ReceiverConfig{}),
+				ProxySettings:    &serial.TypedMessage{Type: "xray.test.Unknown"},
+			},
+		},
+	}); err == nil {
+		t.Fatal("expect reload to fail")
+	}
+
+	// drain the messages logged while the new logger was created
+	for len(recorder) > 0 {
+		<-recorder
+	}
+	clog.Record(&clog.GeneralMessage{
+		Severity: clog.Severity_Error,
+		Content:  "after reload",
+	})
+	select {
+	case msg := <-recorder:
+		t.Error("expect the previous logger in effect, but the discarded one logged: ", msg)
+	default:
+	}
+}
@@ -1,10 +1,13 @@
 package dns
 
 import (
+	"context"
+
 	"github.com/xtls/xray-core/common/errors"
 	"github.com/xtls/xray-core/common/net"
 	"github.com/xtls/xray-core/common/serial"
 	"github.com/xtls/xray-core/features"
+	"golang.org/x/net/dns/dnsmessage"
 )
 
 // IPOption is an object for IP query options.
@@ -22,6 +25,9 @@ type Client interface {
 
 	// LookupIP returns IP address for the given domain. IPs may contain IPv4 and/or IPv6 addresses.
 	LookupIP(domain string, option IPOption) ([]net.IP, error)
+
+	// Query returns the resource records of the type for the given domain, such as MX, TXT or HTTPS records.
+	Query(ctx context.Context, domain string, qType dnsmessage.Type) ([]dnsmessage.Resource, error)
 }
 
 type HostsLookup interface {
@@ -35,6 +41,12 @@ func ClientType() interface{} {
 	return (*Client)(nil)
 }
 
+// Record types that are not defined in golang.org/x/net/dns/dnsmessage.
+const (
+	TypeSVCB  dnsmessage.Type = 64
+	TypeHTTPS dnsmessage.Type = 65
+)
+
 // ErrEmptyResponse indicates that DNS query succeeded but no answer was returned.
 var ErrEmptyResponse = errors.New("empty response")
 
@@ -1,8 +1,13 @@
 package localdns
 
 import (
+	"context"
+	"strconv"
+	"strings"
+
 	"github.com/xtls/xray-core/common/net"
 	"github.com/xtls/xray-core/features/dns"
+	"golang.org/x/net/dns/dnsmessage"
 )
 
 // Client is an implementation of dns.Client, which queries localhost for DNS.
@@ -57,6 +62,158 @@ func (*Client) LookupIP(host string, option dns.IPOption) ([]net.IP, error) {
 	return nil, dns.ErrEmptyResponse
 }
 
+// Query implements Client. The system resolver only supports A, AAAA, CNAME, MX, NS, PTR, SRV and TXT records.
+func (c *Client) Query(ctx context.Context, domain string, qType dnsmessage.Type) ([]dnsmessage.Resource, error) {
+	name, err := dnsmessage.NewName(toFqdn(domain))
+	if err != nil {
+		return nil, err
+	}
+	header := dnsmessage.ResourceHeader{Name: name, Type: qType, Class: dnsmessage.ClassINET, TTL: 600}
+	resolver := &net.Resolver{}
+
+	var records []dnsmessage.Resource
+	add := func(body dnsmessage.ResourceBody) {
+		records = append(records, dnsmessage.Resource{Header: header, Body: body})
+	}
+	toName := func(s string) (dnsmessage.Name, bool) {
+		n, err := dnsmessage.NewName(toFqdn(s))
+		return n, err == nil
+	}
+
+	switch qType {
+	case dnsmessage.TypeA, dnsmessage.TypeAAAA:
+		ips, err := c.LookupIP(domain, dns.IPOption{
+			IPv4Enable: qType == dnsmessage.TypeA,
+			IPv6Enable: qType == dnsmessage.TypeAAAA,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, ip := range ips {
+			if qType == dnsmessage.TypeA {
+				var r dnsmessage.AResource
+				copy(r.A[:], ip.To4())
+				add(&r)
+			} else {
+				var r dnsmessage.AAAAResource
+				copy(r.AAAA[:], ip.To16())
+				add(&r)
+			}
+		}
+	case dnsmessage.TypeCNAME:
+		cname, err := resolver.LookupCNAME(ctx, domain)
+		if err != nil {
+			return nil, err
+		}
+		if n, ok := toName(cname); ok && !strings.EqualFold(cname, name.String()) {
+			add(&dnsmessage.CNAMEResource{CNAME: n})
+		}
+	case dnsmessage.TypeMX:
+		mxs, err := resolver.LookupMX(ctx, domain)
+		if err != nil {
+			return nil, err
+		}
+		for _, mx := range mxs {
+			if n, ok := toName(mx.Host); ok {
+				add(&dnsmessage.MXResource{Pref: mx.Pref, MX: n})
+			}
+		}
+	case dnsmessage.TypeNS:
+		nss, err := resolver.LookupNS(ctx, domain)
+		if err != nil {
+			return nil, err
+		}
+		for _, ns := range nss {
+			if n, ok := toName(ns.Host); ok {
+				add(&dnsmessage.NSResource{NS: n})
+			}
+		}
+	case dnsmessage.TypePTR:
+		ip := parseReverseName(domain)
+		if ip == nil {
+			return nil, newError("invalid reverse name: ", domain)
+		}
+		ptrs, err := resolver.LookupAddr(ctx, ip.String())
+		if err != nil {
+			return nil, err
+		}
+		for _, ptr := range ptrs {
+			if n, ok := toName(ptr); ok {
+				add(&dnsmessage.PTRResource{PTR: n})
+			}
+		}
+	case dnsmessage.TypeSRV:
+		_, srvs, err := resolver.LookupSRV(ctx, "", "", domain)
+		if err != nil {
+			return nil, err
+		}
+		for _, srv := range srvs {
+			if n, ok := toName(srv.Target); ok {
+				add(&dnsmessage.SRVResource{Priority: srv.Priority, Weight: srv.Weight, Port: srv.Port, Target: n})
+			}
+		}
+	case dnsmessage.TypeTXT:
+		txts, err := resolver.LookupTXT(ctx, domain)
+		if err != nil {
+			return nil, err
+		}
+		if len(txts) > 0 {
+			add(&dnsmessage.TXTResource{TXT: txts})
+		}
+	default:
+		return nil, dns.RCodeError(dnsmessage.RCodeNotImplemented)
+	}
+
+	if len(records) == 0 {
+		return nil, dns.ErrEmptyResponse
+	}
+	return records, nil
+}
+
+func toFqdn(domain string) string {
+	if strings.HasSuffix(domain, ".") {
+		return domain
+	}
+	return domain + "."
+}
+
+// parseReverseName returns the IP of a name in in-addr.arpa or ip6.arpa domain, or nil if the name is invalid.
+func parseReverseName(name string) net.IP {
+	name = strings.ToLower(strings.TrimSuffix(name, "."))
+	if s, ok := strings.CutSuffix(name, ".in-addr.arpa"); ok {
+		labels := strings.Split(s, ".")
+		if len(labels) != net.IPv4len {
+			return nil
+		}
+		ip := make(net.IP, net.IPv4len)
+		for i, label := range labels {
+			v, err := strconv.ParseUint(label, 10, 8)
+			if err != nil {
+				return nil
+			}
+			ip[net.IPv4len-1-i] = byte(v)
+		}
+		return ip
+	}
+	if s, ok := strings.CutSuffix(name, ".ip6.arpa"); ok {
+		labels := strings.Split(s, ".")
+		if len(labels) != net.IPv6len*2 {
+			return nil
+		}
+		ip := make(net.IP, net.IPv6len)
+		for i, label := range labels {
+			v, err := strconv.ParseUint(label, 16, 4)
+			if err != nil || len(label) != 1 {
+				return nil
+			}
+			n := len(labels) - 1 - i
+			ip[n/2] |= byte(v) << (4 * uint(1-n%2))
+		}
+		return ip
+	}
+	return nil
+}
+
 // New create a new dns.Client that queries localhost for DNS.
 func New() *Client {
 	return &Client{}
@@ -11,6 +11,13 @@ type Feature interface {
 	common.Runnable
 }
 
+// Reloadable is the interface for features that can apply a new config in place, so that references to them held
+// by other features stay valid. Reload takes over the state of the given feature, which is newly created from the
+// new config and has the same type as the current one.
+type Reloadable interface {
+	Reload(Feature) error
+}
+
 // PrintDeprecatedFeatureWarning prints a warning for deprecated feature.
 func PrintDeprecatedFeatureWarning(feature string) {
 	newError("You are using a deprecated feature: " + feature + ". Please update your config file with latest configuration format, or update your client software.").WriteToLog()
@@ -5,7 +5,10 @@ import (
 	"runtime"
 	"time"
 
+	"github.com/xtls/xray-core/common/net"
 	"github.com/xtls/xray-core/common/platform"
+	"github.com/xtls/xray-core/common/protocol"
+	"github.com/xtls/xray-core/common/session"
 	"github.com/xtls/xray-core/features"
 )
 
@@ -35,6 +38,30 @@ type Buffer struct {
 	PerConnection int32
 }
 
+// Bandwidth contains limits for throughput.
+type Bandwidth struct {
+	// Max uplink bytes per second. 0 for unlimited.
+	UplinkRate int64
+	// Max downlink bytes per second. 0 for unlimited.
+	DownlinkRate int64
+	// Bytes allowed to send in excess of the uplink rate at once.
+	UplinkBurst int64
+	// Bytes allowed to send in excess of the downlink rate at once.
+	DownlinkBurst int64
+}
+
+// Limit contains limits on the usage of a user.
+type Limit struct {
+	// Max concurrent sessions of the user. 0 for unlimited.
+	MaxConnections uint32
+	// Max source IPs the user is connected from at the same time. 0 for unlimited.
+	MaxSourceIPs uint32
+	// Traffic quota of the user in bytes, uplink and downlink combined. 0 for unlimited.
+	Quota int64
+	// Whether to close live sessions once the quota is exceeded. Otherwise only new sessions are rejected.
+	CloseOnQuotaExceeded bool
+}
+
 // SystemStats contains stat policy settings on system level.
 type SystemStats struct {
 	// Whether or not to enable stat counter for uplink traffic in inbound handlers.
@@ -51,13 +78,17 @@ type SystemStats struct {
 type System struct {
 	Stats  SystemStats
 	Buffer Buffer
+	// Bandwidth limits shared by all connections of an inbound, by inbound tag.
+	InboundBandwidth map[string]Bandwidth
 }
 
 // Session is session based settings for controlling Xray requests. It contains various settings (or limits) that may differ for different users in the context.
 type Session struct {
-	Timeouts Timeout // Timeout settings
-	Stats    Stats
-	Buffer   Buffer
+	Timeouts  Timeout // Timeout settings
+	Stats     Stats
+	Buffer    Buffer
+	Bandwidth Bandwidth // Bandwidth limits per user
+	Limit     Limit     // Usage limits per user
 }
 
 // Manager is a feature that provides Policy for the given user by its id or level.
@@ -73,6 +104,50 @@ type Manager interface {
 	ForSystem() System
 }
 
+// UserTracker is a Manager that tracks the sessions and traffic of users, to enforce their Limit.
+type UserTracker interface {
+	// OpenSession registers a new session of the user from the source address on the inbound connection conn, 0 if
+	// unknown. Sessions on the same connection count as one connection. It returns an error if the session exceeds
+	// the limits of the user.
+	OpenSession(email string, level uint32, source net.Address, conn session.ID) (UserSession, error)
+}
+
+// UserSession is a session registered to a UserTracker.
+type UserSession interface {
+	// AddTraffic adds n bytes to the traffic of the user. It returns an error if the session should be closed, as
+	// the quota of the user is exceeded.
+	AddTraffic(n int64) error
+	// Close unregisters the session.
+	Close() error
+}
+
+// OpenUserSession opens a session of the user on the inbound connection of ctx, if m is a UserTracker. It returns a
+// nil UserSession if the user is not tracked.
+func OpenUserSession(ctx context.Context, m Manager, user *protocol.MemoryUser) (UserSession, error) {
+	tracker, ok := m.(UserTracker)
+	if !ok || user == nil || len(user.Email) == 0 {
+		return nil, nil
+	}
+	var source net.Address
+	if inbound := session.InboundFromContext(ctx); inbound != nil && inbound.Source.IsValid() {
+		source = inbound.Source.Address
+	}
+	return tracker.OpenSession(user.Email, user.Level, source, session.IDFromContext(ctx))
+}
+
+// UserUsage is the usage of a user tracked by UserTracker.
+type UserUsage struct {
+	Email string
+	// Number of live sessions.
+	Connections int
+	// Source IPs of the live sessions.
+	SourceIPs []string
+	// Traffic in bytes since the last reset.
+	Traffic int64
+	// Traffic quota in bytes, 0 for unlimited.
+	Quota int64
+}
+
 // ManagerType returns the type of Manager interface. Can be used to implement common.HasType.
 //
 // xray:api:stable
@@ -32,6 +32,21 @@ type Context interface {
 	// GetProtocol returns the protocol from the connection content, if sniffed out.
 	GetProtocol() string
 
+	// GetALPN returns the application protocols from the connection content, if sniffed out.
+	GetALPN() []string
+
+	// GetJA3 returns the JA3 fingerprint of the TLS client hello from the connection content, if sniffed out.
+	GetJA3() string
+
+	// GetJA4 returns the JA4 fingerprint of the TLS client hello from the connection content, if sniffed out.
+	GetJA4() string
+
+	// GetProcessName returns the name of the local process the connection was from, if exists.
+	GetProcessName() string
+
+	// GetProcessUID returns the user ID of the local process the connection was from, and whether it exists.
+	GetProcessUID() (uint32, bool)
+
 	// GetUser returns the user email from the connection content, if exists.
 	GetUser() string
 
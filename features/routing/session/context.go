@@ -1,9 +1,13 @@
 package session
 
+//go:generate go run github.com/xtls/xray-core/common/errors/errorgen
+
 import (
 	"context"
+	"sync"
 
 	"github.com/xtls/xray-core/common/net"
+	"github.com/xtls/xray-core/common/platform/process"
 	"github.com/xtls/xray-core/common/session"
 	"github.com/xtls/xray-core/features/routing"
 )
@@ -13,6 +17,9 @@ type Context struct {
 	Inbound  *session.Inbound
 	Outbound *session.Outbound
 	Content  *session.Content
+
+	processOnce sync.Once
+	process     *process.Process
 }
 
 // GetInboundTag implements routing.Context.
@@ -98,6 +105,63 @@ func (ctx *Context) GetProtocol() string {
 	return ctx.Content.Protocol
 }
 
+// GetALPN implements routing.Context.
+func (ctx *Context) GetALPN() []string {
+	if ctx.Content == nil {
+		return nil
+	}
+	return ctx.Content.ALPN
+}
+
+// GetJA3 implements routing.Context.
+func (ctx *Context) GetJA3() string {
+	if ctx.Content == nil {
+		return ""
+	}
+	return ctx.Content.JA3
+}
+
+// GetJA4 implements routing.Context.
+func (ctx *Context) GetJA4() string {
+	if ctx.Content == nil {
+		return ""
+	}
+	return ctx.Content.JA4
+}
+
+// getProcess returns the local process owning the source of the connection. It is looked up only once, when first
+// needed by routing rules.
+func (ctx *Context) getProcess() *process.Process {
+	ctx.processOnce.Do(func() {
+		if ctx.Inbound == nil || !ctx.Inbound.Source.IsValid() || ctx.Inbound.Source.Address.Family().IsDomain() {
+			return
+		}
+		p, err := process.FindProcess(ctx.Inbound.Source)
+		if err != nil {
+			newError("failed to find process of ", ctx.Inbound.Source).Base(err).AtDebug().WriteToLog()
+			return
+		}
+		ctx.process = p
+	})
+	return ctx.process
+}
+
+// GetProcessName implements routing.Context.
+func (ctx *Context) GetProcessName() string {
+	if p := ctx.getProcess(); p != nil {
+		return p.Name
+	}
+	return ""
+}
+
+// GetProcessUID implements routing.Context.
+func (ctx *Context) GetProcessUID() (uint32, bool) {
+	if p := ctx.getProcess(); p != nil {
+		return p.UID, true
+	}
+	return 0, false
+}
+
 // GetUser implements routing.Context.
 func (ctx *Context) GetUser() string {
 	if ctx.Inbound == nil || ctx.Inbound.User == nil {
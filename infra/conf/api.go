@@ -4,9 +4,12 @@ import (
 	"strings"
 
 	"github.com/xtls/xray-core/app/commander"
+	connectionservice "github.com/xtls/xray-core/app/dispatcher/command"
+	fakednsservice "github.com/xtls/xray-core/app/dns/fakedns/command"
 	loggerservice "github.com/xtls/xray-core/app/log/command"
 	observatoryservice "github.com/xtls/xray-core/app/observatory/command"
 	handlerservice "github.com/xtls/xray-core/app/proxyman/command"
+	routerservice "github.com/xtls/xray-core/app/router/command"
 	statsservice "github.com/xtls/xray-core/app/stats/command"
 	"github.com/xtls/xray-core/common/serial"
 )
@@ -34,6 +37,12 @@ func (c *APIConfig) Build() (*commander.Config, error) {
 			services = append(services, serial.ToTypedMessage(&statsservice.Config{}))
 		case "observatoryservice":
 			services = append(services, serial.ToTypedMessage(&observatoryservice.Config{}))
+		case "routingservice":
+			services = append(services, serial.ToTypedMessage(&routerservice.Config{}))
+		case "connectionservice":
+			services = append(services, serial.ToTypedMessage(&connectionservice.Config{}))
+		case "fakednsservice":
+			services = append(services, serial.ToTypedMessage(&fakednsservice.Config{}))
 		}
 	}
 
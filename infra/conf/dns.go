@@ -8,15 +8,22 @@ import (
 	"github.com/xtls/xray-core/app/dns"
 	"github.com/xtls/xray-core/app/router"
 	"github.com/xtls/xray-core/common/net"
+	"github.com/xtls/xray-core/transport/internet/tls"
 )
 
 type NameServerConfig struct {
-	Address      *Address
-	ClientIP     *Address
-	Port         uint16
-	SkipFallback bool
-	Domains      []string
-	ExpectIPs    StringList
+	Address             *Address
+	ClientIP            *Address
+	Port                uint16
+	SkipFallback        bool
+	Domains             []string
+	ExpectIPs           StringList
+	TLSSettings         *TLSConfig
+	QueryStrategy       string
+	TimeoutMs           uint32
+	RejectUnexpectedIPs bool
+	ExcludedDomains     []string
+	DomainRuleSets      []*RuleSetConfig
 }
 
 func (c *NameServerConfig) UnmarshalJSON(data []byte) error {
@@ -27,12 +34,18 @@ func (c *NameServerConfig) UnmarshalJSON(data []byte) error {
 	}
 
 	var advanced struct {
-		Address      *Address   `json:"address"`
-		ClientIP     *Address   `json:"clientIp"`
-		Port         uint16     `json:"port"`
-		SkipFallback bool       `json:"skipFallback"`
-		Domains      []string   `json:"domains"`
-		ExpectIPs    StringList `json:"expectIps"`
+		Address             *Address         `json:"address"`
+		ClientIP            *Address         `json:"clientIp"`
+		Port                uint16           `json:"port"`
+		SkipFallback        bool             `json:"skipFallback"`
+		Domains             []string         `json:"domains"`
+		ExpectIPs           StringList       `json:"expectIps"`
+		TLSSettings         *TLSConfig       `json:"tlsSettings"`
+		QueryStrategy       string           `json:"queryStrategy"`
+		TimeoutMs           uint32           `json:"timeoutMs"`
+		RejectUnexpectedIPs bool             `json:"rejectUnexpectedIps"`
+		ExcludedDomains     []string         `json:"excludedDomains"`
+		DomainRuleSets      []*RuleSetConfig `json:"domainRuleSet"`
 	}
 	if err := json.Unmarshal(data, &advanced); err == nil {
 		c.Address = advanced.Address
@@ -41,6 +54,12 @@ func (c *NameServerConfig) UnmarshalJSON(data []byte) error {
 		c.SkipFallback = advanced.SkipFallback
 		c.Domains = advanced.Domains
 		c.ExpectIPs = advanced.ExpectIPs
+		c.TLSSettings = advanced.TLSSettings
+		c.QueryStrategy = advanced.QueryStrategy
+		c.TimeoutMs = advanced.TimeoutMs
+		c.RejectUnexpectedIPs = advanced.RejectUnexpectedIPs
+		c.ExcludedDomains = advanced.ExcludedDomains
+		c.DomainRuleSets = advanced.DomainRuleSets
 		return nil
 	}
 
@@ -88,11 +107,30 @@ func (c *NameServerConfig) Build() (*dns.NameServer, error) {
 		})
 	}
 
+	var excludedDomains []*dns.NameServer_PriorityDomain
+	for _, rule := range c.ExcludedDomains {
+		parsedDomain, err := parseDomainRule(rule)
+		if err != nil {
+			return nil, newError("invalid excluded domain rule: ", rule).Base(err)
+		}
+		for _, pd := range parsedDomain {
+			excludedDomains = append(excludedDomains, &dns.NameServer_PriorityDomain{
+				Type:   toDomainMatchingType(pd.Type),
+				Domain: pd.Value,
+			})
+		}
+	}
+
 	geoipList, err := ToCidrList(c.ExpectIPs)
 	if err != nil {
 		return nil, newError("invalid IP rule: ", c.ExpectIPs).Base(err)
 	}
 
+	domainRuleSets, err := buildRuleSets(c.DomainRuleSets)
+	if err != nil {
+		return nil, newError("invalid domain rule set").Base(err)
+	}
+
 	var myClientIP []byte
 	if c.ClientIP != nil {
 		if !c.ClientIP.Family().IsIP() {
@@ -101,20 +139,46 @@ func (c *NameServerConfig) Build() (*dns.NameServer, error) {
 		myClientIP = []byte(c.ClientIP.IP())
 	}
 
+	var tlsSettings *tls.Config
+	if c.TLSSettings != nil {
+		ts, err := c.TLSSettings.Build()
+		if err != nil {
+			return nil, newError("invalid TLS settings of name server").Base(err)
+		}
+		tlsSettings = ts.(*tls.Config)
+	}
+
 	return &dns.NameServer{
 		Address: &net.Endpoint{
 			Network: net.Network_UDP,
 			Address: c.Address.Build(),
 			Port:    uint32(c.Port),
 		},
-		ClientIp:          myClientIP,
-		SkipFallback:      c.SkipFallback,
-		PrioritizedDomain: domains,
-		Geoip:             geoipList,
-		OriginalRules:     originalRules,
+		ClientIp:            myClientIP,
+		SkipFallback:        c.SkipFallback,
+		PrioritizedDomain:   domains,
+		Geoip:               geoipList,
+		OriginalRules:       originalRules,
+		TlsSettings:         tlsSettings,
+		QueryStrategy:       toQueryStrategy(c.QueryStrategy),
+		TimeoutMs:           c.TimeoutMs,
+		RejectUnexpectedIps: c.RejectUnexpectedIPs,
+		ExcludedDomain:      excludedDomains,
+		DomainRuleSet:       domainRuleSets,
 	}, nil
 }
 
+func toQueryStrategy(s string) dns.QueryStrategy {
+	switch strings.ToLower(s) {
+	case "useip4", "useipv4", "use_ip4", "use_ipv4", "use_ip_v4", "use-ip4", "use-ipv4", "use-ip-v4":
+		return dns.QueryStrategy_USE_IP4
+	case "useip6", "useipv6", "use_ip6", "use_ipv6", "use_ip_v6", "use-ip6", "use-ipv6", "use-ip-v6":
+		return dns.QueryStrategy_USE_IP6
+	default:
+		return dns.QueryStrategy_USE_IP
+	}
+}
+
 var typeMap = map[router.Domain_Type]dns.DomainMatchingType{
 	router.Domain_Full:   dns.DomainMatchingType_Full,
 	router.Domain_Domain: dns.DomainMatchingType_Subdomain,
@@ -132,6 +196,9 @@ type DNSConfig struct {
 	DisableCache           bool                `json:"disableCache"`
 	DisableFallback        bool                `json:"disableFallback"`
 	DisableFallbackIfMatch bool                `json:"disableFallbackIfMatch"`
+	CacheFile              string              `json:"cacheFile"`
+	ServeStale             uint32              `json:"serveStale"`
+	Prefetch               bool                `json:"prefetch"`
 }
 
 type HostAddress struct {
@@ -311,6 +378,9 @@ func (c *DNSConfig) Build() (*dns.Config, error) {
 		DisableCache:           c.DisableCache,
 		DisableFallback:        c.DisableFallback,
 		DisableFallbackIfMatch: c.DisableFallbackIfMatch,
+		CacheFile:              c.CacheFile,
+		ServeStale:             c.ServeStale,
+		Prefetch:               c.Prefetch,
 	}
 
 	if c.ClientIP != nil {
@@ -320,15 +390,7 @@ func (c *DNSConfig) Build() (*dns.Config, error) {
 		config.ClientIp = []byte(c.ClientIP.IP())
 	}
 
-	config.QueryStrategy = dns.QueryStrategy_USE_IP
-	switch strings.ToLower(c.QueryStrategy) {
-	case "useip", "use_ip", "use-ip":
-		config.QueryStrategy = dns.QueryStrategy_USE_IP
-	case "useip4", "useipv4", "use_ip4", "use_ipv4", "use_ip_v4", "use-ip4", "use-ipv4", "use-ip-v4":
-		config.QueryStrategy = dns.QueryStrategy_USE_IP4
-	case "useip6", "useipv6", "use_ip6", "use_ipv6", "use_ip_v6", "use-ip6", "use-ipv6", "use-ip-v6":
-		config.QueryStrategy = dns.QueryStrategy_USE_IP6
-	}
+	config.QueryStrategy = toQueryStrategy(c.QueryStrategy)
 
 	for _, server := range c.Servers {
 		ns, err := server.Build()
@@ -1,16 +1,65 @@
 package conf
 
 import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
 	"github.com/golang/protobuf/proto"
 	"github.com/xtls/xray-core/common/net"
 	"github.com/xtls/xray-core/proxy/dns"
 )
 
+var dnsRecordTypes = map[string]uint32{
+	"A":     1,
+	"NS":    2,
+	"CNAME": 5,
+	"SOA":   6,
+	"PTR":   12,
+	"MX":    15,
+	"TXT":   16,
+	"AAAA":  28,
+	"SRV":   33,
+	"SVCB":  64,
+	"HTTPS": 65,
+	"ANY":   255,
+	"CAA":   257,
+}
+
+// DNSRecordType is a DNS record type, given by its number or name such as "HTTPS" or "TYPE65".
+type DNSRecordType uint32
+
+// UnmarshalJSON implements encoding/json.Unmarshaler.UnmarshalJSON
+func (t *DNSRecordType) UnmarshalJSON(data []byte) error {
+	var number uint32
+	if err := json.Unmarshal(data, &number); err == nil {
+		*t = DNSRecordType(number)
+		return nil
+	}
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return newError("invalid DNS record type: ", string(data)).Base(err)
+	}
+	name = strings.ToUpper(name)
+	if number, found := dnsRecordTypes[name]; found {
+		*t = DNSRecordType(number)
+		return nil
+	}
+	if s, ok := strings.CutPrefix(name, "TYPE"); ok {
+		if number, err := strconv.ParseUint(s, 10, 16); err == nil {
+			*t = DNSRecordType(number)
+			return nil
+		}
+	}
+	return newError("unknown DNS record type: ", name)
+}
+
 type DNSOutboundConfig struct {
-	Network   Network  `json:"network"`
-	Address   *Address `json:"address"`
-	Port      uint16   `json:"port"`
-	UserLevel uint32   `json:"userLevel"`
+	Network    Network         `json:"network"`
+	Address    *Address        `json:"address"`
+	Port       uint16          `json:"port"`
+	UserLevel  uint32          `json:"userLevel"`
+	BlockTypes []DNSRecordType `json:"blockTypes"`
 }
 
 func (c *DNSOutboundConfig) Build() (proto.Message, error) {
@@ -24,5 +73,32 @@ func (c *DNSOutboundConfig) Build() (proto.Message, error) {
 	if c.Address != nil {
 		config.Server.Address = c.Address.Build()
 	}
+	for _, t := range c.BlockTypes {
+		config.BlockTypes = append(config.BlockTypes, uint32(t))
+	}
+	return config, nil
+}
+
+type DNSInboundConfig struct {
+	Network    *NetworkList    `json:"network"`
+	UserLevel  uint32          `json:"userLevel"`
+	DoHPath    string          `json:"dohPath"`
+	BlockTypes []DNSRecordType `json:"blockTypes"`
+}
+
+func (c *DNSInboundConfig) Build() (proto.Message, error) {
+	config := &dns.ServerConfig{
+		UserLevel: c.UserLevel,
+		DohPath:   c.DoHPath,
+	}
+	if c.Network != nil {
+		config.Networks = c.Network.Build()
+	}
+	if len(config.DohPath) > 0 && !strings.HasPrefix(config.DohPath, "/") {
+		return nil, newError("dohPath must start with /: ", config.DohPath)
+	}
+	for _, t := range c.BlockTypes {
+		config.BlockTypes = append(config.BlockTypes, uint32(t))
+	}
 	return config, nil
 }
@@ -29,5 +29,48 @@ func TestDnsProxyConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			Input: `{
+				"blockTypes": ["HTTPS", "aaaa", 15, "TYPE64"]
+			}`,
+			Parser: loadJSON(creator),
+			Output: &dns.Config{
+				Server:     &net.Endpoint{},
+				BlockTypes: []uint32{65, 28, 15, 64},
+			},
+		},
+	})
+}
+
+func TestDnsInboundConfig(t *testing.T) {
+	creator := func() Buildable {
+		return new(DNSInboundConfig)
+	}
+
+	runMultiTestCase(t, []TestCase{
+		{
+			Input: `{
+				"network": "udp",
+				"userLevel": 1,
+				"blockTypes": ["HTTPS"]
+			}`,
+			Parser: loadJSON(creator),
+			Output: &dns.ServerConfig{
+				Networks:   []net.Network{net.Network_UDP},
+				UserLevel:  1,
+				BlockTypes: []uint32{65},
+			},
+		},
+		{
+			Input: `{
+				"network": "tcp",
+				"dohPath": "/dns-query"
+			}`,
+			Parser: loadJSON(creator),
+			Output: &dns.ServerConfig{
+				Networks: []net.Network{net.Network_TCP},
+				DohPath:  "/dns-query",
+			},
+		},
 	})
 }
@@ -14,6 +14,7 @@ import (
 	"github.com/xtls/xray-core/common/platform"
 	"github.com/xtls/xray-core/common/platform/filesystem"
 	. "github.com/xtls/xray-core/infra/conf"
+	"github.com/xtls/xray-core/transport/internet/tls"
 )
 
 func init() {
@@ -82,7 +83,10 @@ func TestDNSConfigParsing(t *testing.T) {
 				"clientIp": "10.0.0.1",
 				"queryStrategy": "UseIPv4",
 				"disableCache": true,
-				"disableFallback": true
+				"disableFallback": true,
+				"cacheFile": "/var/lib/xray/dns.cache",
+				"serveStale": 3600,
+				"prefetch": true
 			}`,
 			Parser: parserCreator(),
 			Output: &dns.Config{
@@ -143,6 +147,74 @@ func TestDNSConfigParsing(t *testing.T) {
 				QueryStrategy:   dns.QueryStrategy_USE_IP4,
 				DisableCache:    true,
 				DisableFallback: true,
+				CacheFile:       "/var/lib/xray/dns.cache",
+				ServeStale:      3600,
+				Prefetch:        true,
+			},
+		},
+		{
+			Input: `{
+				"servers": [{
+					"address": "tls://1.1.1.1",
+					"tlsSettings": {
+						"serverName": "one.one.one.one",
+						"pinnedPeerCertificateChainSha256": ["AQID"]
+					}
+				}]
+			}`,
+			Parser: parserCreator(),
+			Output: &dns.Config{
+				NameServer: []*dns.NameServer{
+					{
+						Address: &net.Endpoint{
+							Address: &net.IPOrDomain{
+								Address: &net.IPOrDomain_Domain{
+									Domain: "tls://1.1.1.1",
+								},
+							},
+							Network: net.Network_UDP,
+						},
+						TlsSettings: &tls.Config{
+							ServerName:                       "one.one.one.one",
+							PinnedPeerCertificateChainSha256: [][]byte{{1, 2, 3}},
+						},
+					},
+				},
+			},
+		},
+		{
+			Input: `{
+				"servers": [{
+					"address": "10.0.0.53",
+					"queryStrategy": "UseIPv4",
+					"timeoutMs": 1500,
+					"rejectUnexpectedIps": true,
+					"excludedDomains": ["domain:internal.example.com"]
+				}]
+			}`,
+			Parser: parserCreator(),
+			Output: &dns.Config{
+				NameServer: []*dns.NameServer{
+					{
+						Address: &net.Endpoint{
+							Address: &net.IPOrDomain{
+								Address: &net.IPOrDomain_Ip{
+									Ip: []byte{10, 0, 0, 53},
+								},
+							},
+							Network: net.Network_UDP,
+						},
+						QueryStrategy:       dns.QueryStrategy_USE_IP4,
+						TimeoutMs:           1500,
+						RejectUnexpectedIps: true,
+						ExcludedDomain: []*dns.NameServer_PriorityDomain{
+							{
+								Type:   dns.DomainMatchingType_Subdomain,
+								Domain: "internal.example.com",
+							},
+						},
+					},
+				},
 			},
 		},
 	})
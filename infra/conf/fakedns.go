@@ -9,8 +9,9 @@ import (
 )
 
 type FakeDNSPoolElementConfig struct {
-	IPPool  string `json:"ipPool"`
-	LRUSize int64  `json:"poolSize"`
+	IPPool      string `json:"ipPool"`
+	LRUSize     int64  `json:"poolSize"`
+	PersistFile string `json:"persistFile"`
 }
 
 type FakeDNSConfig struct {
@@ -38,15 +39,16 @@ func (f *FakeDNSConfig) Build() (*fakedns.FakeDnsPoolMulti, error) {
 
 	if f.pool != nil {
 		fakeDNSPool.Pools = append(fakeDNSPool.Pools, &fakedns.FakeDnsPool{
-			IpPool:  f.pool.IPPool,
-			LruSize: f.pool.LRUSize,
+			IpPool:      f.pool.IPPool,
+			LruSize:     f.pool.LRUSize,
+			PersistFile: f.pool.PersistFile,
 		})
 		return &fakeDNSPool, nil
 	}
 
 	if f.pools != nil {
 		for _, v := range f.pools {
-			fakeDNSPool.Pools = append(fakeDNSPool.Pools, &fakedns.FakeDnsPool{IpPool: v.IPPool, LruSize: v.LRUSize})
+			fakeDNSPool.Pools = append(fakeDNSPool.Pools, &fakedns.FakeDnsPool{IpPool: v.IPPool, LruSize: v.LRUSize, PersistFile: v.PersistFile})
 		}
 		return &fakeDNSPool, nil
 	}
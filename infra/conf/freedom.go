@@ -1,20 +1,140 @@
 package conf
 
 import (
+	"encoding/base64"
+	"encoding/hex"
 	"net"
+	"strconv"
 	"strings"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/xtls/xray-core/common/buf"
 	v2net "github.com/xtls/xray-core/common/net"
 	"github.com/xtls/xray-core/common/protocol"
 	"github.com/xtls/xray-core/proxy/freedom"
 )
 
 type FreedomConfig struct {
-	DomainStrategy string  `json:"domainStrategy"`
-	Timeout        *uint32 `json:"timeout"`
-	Redirect       string  `json:"redirect"`
-	UserLevel      uint32  `json:"userLevel"`
+	DomainStrategy string    `json:"domainStrategy"`
+	Timeout        *uint32   `json:"timeout"`
+	Redirect       string    `json:"redirect"`
+	UserLevel      uint32    `json:"userLevel"`
+	Fragment       *Fragment `json:"fragment"`
+	Noises         []*Noise  `json:"noises"`
+}
+
+type Fragment struct {
+	Packets  string `json:"packets"`
+	Length   string `json:"length"`
+	Interval string `json:"interval"`
+}
+
+// Build builds the fragment config. Packets are "tlshello" or a range of packet numbers starting from 1.
+func (c *Fragment) Build() (*freedom.Fragment, error) {
+	config := new(freedom.Fragment)
+	switch strings.ToLower(c.Packets) {
+	case "", "tlshello":
+		config.PacketsFrom, config.PacketsTo = 0, 1
+	default:
+		from, to, err := parseRange(c.Packets)
+		if err != nil {
+			return nil, newError("invalid fragment packets: ", c.Packets).Base(err)
+		}
+		if from == 0 {
+			return nil, newError("fragment packets start from 1")
+		}
+		config.PacketsFrom, config.PacketsTo = from, to
+	}
+
+	from, to, err := parseRange(c.Length)
+	if err != nil {
+		return nil, newError("invalid fragment length: ", c.Length).Base(err)
+	}
+	if from == 0 {
+		return nil, newError("fragment length must be positive")
+	}
+	config.LengthMin, config.LengthMax = from, to
+
+	if len(c.Interval) > 0 {
+		from, to, err := parseRange(c.Interval)
+		if err != nil {
+			return nil, newError("invalid fragment interval: ", c.Interval).Base(err)
+		}
+		config.IntervalMin, config.IntervalMax = from, to
+	}
+	return config, nil
+}
+
+type Noise struct {
+	Type   string `json:"type"`
+	Packet string `json:"packet"`
+	Delay  string `json:"delay"`
+}
+
+// Build builds the noise config. The packet is a length range of random bytes for "rand", or the content of the
+// noise for "str", "hex" and "base64".
+func (c *Noise) Build() (*freedom.Noise, error) {
+	config := new(freedom.Noise)
+	noiseType := strings.ToLower(c.Type)
+	switch noiseType {
+	case "rand":
+		from, to, err := parseRange(c.Packet)
+		if err != nil {
+			return nil, newError("invalid noise length: ", c.Packet).Base(err)
+		}
+		if from == 0 || to > buf.Size {
+			return nil, newError("noise length must be between 1 and ", buf.Size)
+		}
+		config.LengthMin, config.LengthMax = from, to
+	case "str":
+		config.Packet = []byte(c.Packet)
+	case "hex":
+		packet, err := hex.DecodeString(c.Packet)
+		if err != nil {
+			return nil, newError("invalid hex noise: ", c.Packet).Base(err)
+		}
+		config.Packet = packet
+	case "base64":
+		packet, err := base64.StdEncoding.DecodeString(c.Packet)
+		if err != nil {
+			return nil, newError("invalid base64 noise: ", c.Packet).Base(err)
+		}
+		config.Packet = packet
+	default:
+		return nil, newError("unknown noise type: ", c.Type)
+	}
+	if noiseType != "rand" && (len(config.Packet) == 0 || len(config.Packet) > buf.Size) {
+		return nil, newError("noise length must be between 1 and ", buf.Size)
+	}
+
+	if len(c.Delay) > 0 {
+		from, to, err := parseRange(c.Delay)
+		if err != nil {
+			return nil, newError("invalid noise delay: ", c.Delay).Base(err)
+		}
+		config.DelayMin, config.DelayMax = from, to
+	}
+	return config, nil
+}
+
+// parseRange parses a number or a range of numbers like "10-20".
+func parseRange(s string) (uint64, uint64, error) {
+	parts := strings.SplitN(s, "-", 2)
+	from, err := strconv.ParseUint(strings.TrimSpace(parts[0]), 10, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	to := from
+	if len(parts) == 2 {
+		to, err = strconv.ParseUint(strings.TrimSpace(parts[1]), 10, 64)
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+	if from > to {
+		return 0, 0, newError("invalid range ", from, " -> ", to)
+	}
+	return from, to, nil
 }
 
 // Build implements Buildable
@@ -53,5 +173,19 @@ func (c *FreedomConfig) Build() (proto.Message, error) {
 			config.DestinationOverride.Server.Address = v2net.NewIPOrDomain(v2net.ParseAddress(host))
 		}
 	}
+	if c.Fragment != nil {
+		fragment, err := c.Fragment.Build()
+		if err != nil {
+			return nil, err
+		}
+		config.Fragment = fragment
+	}
+	for _, n := range c.Noises {
+		noise, err := n.Build()
+		if err != nil {
+			return nil, err
+		}
+		config.Noises = append(config.Noises, noise)
+	}
 	return config, nil
 }
@@ -39,5 +39,54 @@ func TestFreedomConfig(t *testing.T) {
 				UserLevel: 1,
 			},
 		},
+		{
+			Input: `{
+				"fragment": {
+					"packets": "tlshello",
+					"length": "100-200",
+					"interval": "10-20"
+				},
+				"noises": [
+					{"type": "rand", "packet": "10-20", "delay": "5"},
+					{"type": "str", "packet": "hello"},
+					{"type": "base64", "packet": "AAE="}
+				]
+			}`,
+			Parser: loadJSON(creator),
+			Output: &freedom.Config{
+				DomainStrategy: freedom.Config_AS_IS,
+				Fragment: &freedom.Fragment{
+					PacketsFrom: 0,
+					PacketsTo:   1,
+					LengthMin:   100,
+					LengthMax:   200,
+					IntervalMin: 10,
+					IntervalMax: 20,
+				},
+				Noises: []*freedom.Noise{
+					{LengthMin: 10, LengthMax: 20, DelayMin: 5, DelayMax: 5},
+					{Packet: []byte("hello")},
+					{Packet: []byte{0, 1}},
+				},
+			},
+		},
+		{
+			Input: `{
+				"fragment": {
+					"packets": "1-3",
+					"length": "5"
+				}
+			}`,
+			Parser: loadJSON(creator),
+			Output: &freedom.Config{
+				DomainStrategy: freedom.Config_AS_IS,
+				Fragment: &freedom.Fragment{
+					PacketsFrom: 1,
+					PacketsTo:   3,
+					LengthMin:   5,
+					LengthMax:   5,
+				},
+			},
+		},
 	})
 }
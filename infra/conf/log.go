@@ -20,11 +20,17 @@ type LogConfig struct {
 	ErrorLog  string `json:"error"`
 	LogLevel  string `json:"loglevel"`
 	DNSLog    bool   `json:"dnsLog"`
+	// Format is "text" (default) or "json", for both access and error logs.
+	Format string `json:"format"`
+	// MaxSize is in megabytes, and MaxAge is in days.
+	MaxSize    int64  `json:"maxSize"`
+	MaxAge     int64  `json:"maxAge"`
+	MaxBackups uint32 `json:"maxBackups"`
 }
 
-func (v *LogConfig) Build() *log.Config {
+func (v *LogConfig) Build() (*log.Config, error) {
 	if v == nil {
-		return nil
+		return nil, nil
 	}
 	config := &log.Config{
 		ErrorLogType:  log.LogType_Console,
@@ -59,5 +65,22 @@ func (v *LogConfig) Build() *log.Config {
 	default:
 		config.ErrorLogLevel = clog.Severity_Warning
 	}
-	return config
+
+	switch strings.ToLower(v.Format) {
+	case "", "text":
+	case "json":
+		config.AccessLogFormat = log.LogFormat_JSON
+		config.ErrorLogFormat = log.LogFormat_JSON
+	default:
+		return nil, newError("unknown log format: ", v.Format)
+	}
+
+	if v.MaxSize > 0 || v.MaxAge > 0 {
+		config.Rotation = &log.Rotation{
+			MaxSize:    v.MaxSize * 1024 * 1024,
+			MaxAge:     v.MaxAge * 24 * 3600,
+			MaxBackups: v.MaxBackups,
+		}
+	}
+	return config, nil
 }
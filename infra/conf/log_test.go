@@ -0,0 +1,52 @@
+package conf_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/xtls/xray-core/app/log"
+	clog "github.com/xtls/xray-core/common/log"
+	. "github.com/xtls/xray-core/infra/conf"
+)
+
+func TestLogConfig(t *testing.T) {
+	createParser := func() func(string) (proto.Message, error) {
+		return func(s string) (proto.Message, error) {
+			config := new(LogConfig)
+			if err := json.Unmarshal([]byte(s), config); err != nil {
+				return nil, err
+			}
+			return config.Build()
+		}
+	}
+
+	runMultiTestCase(t, []TestCase{
+		{
+			Input: `{
+				"access": "/var/log/xray/access.log",
+				"error": "/var/log/xray/error.log",
+				"loglevel": "info",
+				"format": "json",
+				"maxSize": 100,
+				"maxAge": 7,
+				"maxBackups": 3
+			}`,
+			Parser: createParser(),
+			Output: &log.Config{
+				ErrorLogType:    log.LogType_File,
+				ErrorLogPath:    "/var/log/xray/error.log",
+				ErrorLogLevel:   clog.Severity_Info,
+				AccessLogType:   log.LogType_File,
+				AccessLogPath:   "/var/log/xray/access.log",
+				ErrorLogFormat:  log.LogFormat_JSON,
+				AccessLogFormat: log.LogFormat_JSON,
+				Rotation: &log.Rotation{
+					MaxSize:    100 * 1024 * 1024,
+					MaxAge:     7 * 24 * 3600,
+					MaxBackups: 3,
+				},
+			},
+		},
+	})
+}
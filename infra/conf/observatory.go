@@ -3,6 +3,7 @@ package conf
 import (
 	"github.com/golang/protobuf/proto"
 	"github.com/xtls/xray-core/app/observatory"
+	"github.com/xtls/xray-core/app/observatory/burst"
 	"github.com/xtls/xray-core/infra/conf/cfgcommon/duration"
 )
 
@@ -16,3 +17,46 @@ type ObservatoryConfig struct {
 func (o *ObservatoryConfig) Build() (proto.Message, error) {
 	return &observatory.Config{SubjectSelector: o.SubjectSelector, ProbeUrl: o.ProbeURL, ProbeInterval: int64(o.ProbeInterval), EnableConcurrency: o.EnableConcurrency}, nil
 }
+
+type BurstObservatoryConfig struct {
+	SubjectSelector []string `json:"subjectSelector"`
+	// health check settings
+	HealthCheck *healthCheckSettings `json:"pingConfig,omitempty"`
+}
+
+func (b BurstObservatoryConfig) Build() (proto.Message, error) {
+	if b.HealthCheck == nil {
+		return &burst.Config{SubjectSelector: b.SubjectSelector}, nil
+	}
+	result, err := b.HealthCheck.Build()
+	if err != nil {
+		return nil, err
+	}
+	return &burst.Config{SubjectSelector: b.SubjectSelector, PingConfig: result.(*burst.HealthPingConfig)}, nil
+}
+
+type healthCheckSettings struct {
+	Destination    string            `json:"destination"`
+	Connectivity   string            `json:"connectivity"`
+	Interval       duration.Duration `json:"interval"`
+	SamplingCount  int               `json:"sampling"`
+	Timeout        duration.Duration `json:"timeout"`
+	ExpectedStatus int               `json:"expectedStatus"`
+}
+
+func (h healthCheckSettings) Build() (proto.Message, error) {
+	if h.SamplingCount < 0 {
+		return nil, newError("negative sampling count")
+	}
+	if h.ExpectedStatus != 0 && (h.ExpectedStatus < 100 || h.ExpectedStatus > 599) {
+		return nil, newError("invalid expected status: ", h.ExpectedStatus)
+	}
+	return &burst.HealthPingConfig{
+		Destination:    h.Destination,
+		Connectivity:   h.Connectivity,
+		Interval:       int64(h.Interval),
+		Timeout:        int64(h.Timeout),
+		SamplingCount:  int32(h.SamplingCount),
+		ExpectedStatus: int32(h.ExpectedStatus),
+	}, nil
+}
@@ -12,6 +12,31 @@ type Policy struct {
 	StatsUserUplink   bool    `json:"statsUserUplink"`
 	StatsUserDownlink bool    `json:"statsUserDownlink"`
 	BufferSize        *int32  `json:"bufferSize"`
+	Bandwidth
+	MaxConnections       uint32 `json:"maxConnections"`
+	MaxSourceIPs         uint32 `json:"maxSourceIPs"`
+	Quota                uint64 `json:"quota"`
+	CloseOnQuotaExceeded bool   `json:"closeOnQuotaExceeded"`
+}
+
+// Bandwidth is the config of bandwidth limits, in bytes per second.
+type Bandwidth struct {
+	UplinkRate    uint64 `json:"uplinkRate"`
+	DownlinkRate  uint64 `json:"downlinkRate"`
+	UplinkBurst   uint64 `json:"uplinkBurst"`
+	DownlinkBurst uint64 `json:"downlinkBurst"`
+}
+
+func (b *Bandwidth) Build() *policy.Policy_Bandwidth {
+	if b.UplinkRate == 0 && b.DownlinkRate == 0 {
+		return nil
+	}
+	return &policy.Policy_Bandwidth{
+		UplinkRate:    b.UplinkRate,
+		DownlinkRate:  b.DownlinkRate,
+		UplinkBurst:   b.UplinkBurst,
+		DownlinkBurst: b.DownlinkBurst,
+	}
 }
 
 func (t *Policy) Build() (*policy.Policy, error) {
@@ -35,6 +60,16 @@ func (t *Policy) Build() (*policy.Policy, error) {
 			UserUplink:   t.StatsUserUplink,
 			UserDownlink: t.StatsUserDownlink,
 		},
+		Bandwidth: t.Bandwidth.Build(),
+	}
+
+	if t.MaxConnections > 0 || t.MaxSourceIPs > 0 || t.Quota > 0 {
+		p.Limit = &policy.Policy_Limit{
+			MaxConnections:       t.MaxConnections,
+			MaxSourceIps:         t.MaxSourceIPs,
+			Quota:                t.Quota,
+			CloseOnQuotaExceeded: t.CloseOnQuotaExceeded,
+		}
 	}
 
 	if t.BufferSize != nil {
@@ -51,26 +86,40 @@ func (t *Policy) Build() (*policy.Policy, error) {
 }
 
 type SystemPolicy struct {
-	StatsInboundUplink    bool `json:"statsInboundUplink"`
-	StatsInboundDownlink  bool `json:"statsInboundDownlink"`
-	StatsOutboundUplink   bool `json:"statsOutboundUplink"`
-	StatsOutboundDownlink bool `json:"statsOutboundDownlink"`
+	StatsInboundUplink    bool                  `json:"statsInboundUplink"`
+	StatsInboundDownlink  bool                  `json:"statsInboundDownlink"`
+	StatsOutboundUplink   bool                  `json:"statsOutboundUplink"`
+	StatsOutboundDownlink bool                  `json:"statsOutboundDownlink"`
+	InboundBandwidth      map[string]*Bandwidth `json:"inboundBandwidth"`
 }
 
 func (p *SystemPolicy) Build() (*policy.SystemPolicy, error) {
-	return &policy.SystemPolicy{
+	config := &policy.SystemPolicy{
 		Stats: &policy.SystemPolicy_Stats{
 			InboundUplink:    p.StatsInboundUplink,
 			InboundDownlink:  p.StatsInboundDownlink,
 			OutboundUplink:   p.StatsOutboundUplink,
 			OutboundDownlink: p.StatsOutboundDownlink,
 		},
-	}, nil
+	}
+	for tag, b := range p.InboundBandwidth {
+		if b == nil {
+			continue
+		}
+		if bw := b.Build(); bw != nil {
+			if config.InboundBandwidth == nil {
+				config.InboundBandwidth = make(map[string]*policy.Policy_Bandwidth)
+			}
+			config.InboundBandwidth[tag] = bw
+		}
+	}
+	return config, nil
 }
 
 type PolicyConfig struct {
-	Levels map[uint32]*Policy `json:"levels"`
-	System *SystemPolicy      `json:"system"`
+	Levels    map[uint32]*Policy `json:"levels"`
+	System    *SystemPolicy      `json:"system"`
+	UserQuota map[string]uint64  `json:"userQuota"`
 }
 
 func (c *PolicyConfig) Build() (*policy.Config, error) {
@@ -85,7 +134,8 @@ func (c *PolicyConfig) Build() (*policy.Config, error) {
 		}
 	}
 	config := &policy.Config{
-		Level: levels,
+		Level:     levels,
+		UserQuota: c.UserQuota,
 	}
 
 	if c.System != nil {
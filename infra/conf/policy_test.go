@@ -38,3 +38,36 @@ func TestBufferSize(t *testing.T) {
 		}
 	}
 }
+
+func TestBandwidth(t *testing.T) {
+	pConf := Policy{
+		Bandwidth: Bandwidth{
+			UplinkRate:   1024,
+			DownlinkRate: 2048,
+			UplinkBurst:  4096,
+		},
+	}
+	p, err := pConf.Build()
+	common.Must(err)
+	if b := p.Bandwidth; b.UplinkRate != 1024 || b.DownlinkRate != 2048 || b.UplinkBurst != 4096 || b.DownlinkBurst != 0 {
+		t.Error("unexpected bandwidth ", b)
+	}
+
+	p, err = (&Policy{}).Build()
+	common.Must(err)
+	if p.Bandwidth != nil {
+		t.Error("expected no bandwidth limit, but got ", p.Bandwidth)
+	}
+
+	sConf := SystemPolicy{
+		InboundBandwidth: map[string]*Bandwidth{
+			"in":   {DownlinkRate: 100},
+			"none": {},
+		},
+	}
+	s, err := sConf.Build()
+	common.Must(err)
+	if len(s.InboundBandwidth) != 1 || s.InboundBandwidth["in"].DownlinkRate != 100 {
+		t.Error("unexpected inbound bandwidth ", s.InboundBandwidth)
+	}
+}
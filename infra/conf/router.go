@@ -10,6 +10,7 @@ import (
 	"github.com/xtls/xray-core/app/router"
 	"github.com/xtls/xray-core/common/net"
 	"github.com/xtls/xray-core/common/platform/filesystem"
+	"github.com/xtls/xray-core/common/serial"
 )
 
 type RouterRulesConfig struct {
@@ -24,9 +25,10 @@ type StrategyConfig struct {
 }
 
 type BalancingRule struct {
-	Tag       string         `json:"tag"`
-	Selectors StringList     `json:"selector"`
-	Strategy  StrategyConfig `json:"strategy"`
+	Tag         string         `json:"tag"`
+	Selectors   StringList     `json:"selector"`
+	Strategy    StrategyConfig `json:"strategy"`
+	FallbackTag string         `json:"fallbackTag"`
 }
 
 func (r *BalancingRule) Build() (*router.BalancingRule, error) {
@@ -37,21 +39,48 @@ func (r *BalancingRule) Build() (*router.BalancingRule, error) {
 		return nil, newError("empty selector list")
 	}
 
+	strategyType := strings.ToLower(r.Strategy.Type)
+	if strategyType == "" {
+		strategyType = strategyRandom
+	}
+
 	var strategy string
-	switch strings.ToLower(r.Strategy.Type) {
-	case strategyRandom, "":
+	switch strategyType {
+	case strategyRandom:
 		strategy = strategyRandom
 	case strategyLeastPing:
 		strategy = "leastPing"
+	case strategyRoundRobin:
+		strategy = "roundRobin"
+	case strategyLeastLoad:
+		strategy = "leastLoad"
 	default:
 		return nil, newError("unknown balancing strategy: " + r.Strategy.Type)
 	}
 
-	return &router.BalancingRule{
+	settings := []byte("{}")
+	if r.Strategy.Settings != nil {
+		settings = ([]byte)(*r.Strategy.Settings)
+	}
+	rawConfig, err := strategyConfigLoader.LoadWithID(settings, strategyType)
+	if err != nil {
+		return nil, newError("failed to parse settings of balancing strategy").Base(err)
+	}
+	s, err := rawConfig.(Buildable).Build()
+	if err != nil {
+		return nil, newError("invalid settings of balancing strategy").Base(err)
+	}
+
+	rule := &router.BalancingRule{
 		Tag:              r.Tag,
 		OutboundSelector: []string(r.Selectors),
 		Strategy:         strategy,
-	}, nil
+		FallbackTag:      r.FallbackTag,
+	}
+	if s != nil {
+		rule.StrategySettings = serial.ToTypedMessage(s)
+	}
+	return rule, nil
 }
 
 type RouterConfig struct {
@@ -122,6 +151,7 @@ type RouterRule struct {
 	Type        string `json:"type"`
 	OutboundTag string `json:"outboundTag"`
 	BalancerTag string `json:"balancerTag"`
+	RuleTag     string `json:"ruleTag"`
 
 	DomainMatcher string `json:"domainMatcher"`
 }
@@ -501,6 +531,50 @@ func ToCidrList(ips StringList) ([]*router.GeoIP, error) {
 	return geoipList, nil
 }
 
+// RuleSetConfig is the config of a rule set file, which is reloaded when it changes.
+type RuleSetConfig struct {
+	Path            string `json:"path"`
+	Format          string `json:"format"`
+	Code            string `json:"code"`
+	RefreshInterval uint32 `json:"refreshInterval"`
+}
+
+// Build implements Buildable.
+func (c *RuleSetConfig) Build() (*router.RuleSet, error) {
+	if c.Path == "" {
+		return nil, newError("rule set path is not specified")
+	}
+	ruleSet := &router.RuleSet{
+		Path:            c.Path,
+		Code:            c.Code,
+		RefreshInterval: c.RefreshInterval,
+	}
+	switch strings.ToLower(c.Format) {
+	case "", "text":
+		ruleSet.Format = router.RuleSet_Text
+	case "protobuf", "dat":
+		if c.Code == "" {
+			return nil, newError("code of rule set ", c.Path, " is not specified")
+		}
+		ruleSet.Format = router.RuleSet_Protobuf
+	default:
+		return nil, newError("unknown rule set format: ", c.Format)
+	}
+	return ruleSet, nil
+}
+
+func buildRuleSets(configs []*RuleSetConfig) ([]*router.RuleSet, error) {
+	var ruleSets []*router.RuleSet
+	for _, config := range configs {
+		ruleSet, err := config.Build()
+		if err != nil {
+			return nil, err
+		}
+		ruleSets = append(ruleSets, ruleSet)
+	}
+	return ruleSets, nil
+}
+
 func parseFieldRule(msg json.RawMessage) (*router.RoutingRule, error) {
 	type RawFieldRule struct {
 		RouterRule
@@ -515,6 +589,15 @@ func parseFieldRule(msg json.RawMessage) (*router.RoutingRule, error) {
 		InboundTag *StringList  `json:"inboundTag"`
 		Protocols  *StringList  `json:"protocol"`
 		Attributes string       `json:"attrs"`
+
+		ALPN           *StringList `json:"alpn"`
+		TLSFingerprint *StringList `json:"tlsFingerprint"`
+		ProcessName    *StringList `json:"processName"`
+		ProcessUID     []uint32    `json:"processUid"`
+
+		DomainRuleSets   []*RuleSetConfig `json:"domainRuleSet"`
+		IPRuleSets       []*RuleSetConfig `json:"ipRuleSet"`
+		SourceIPRuleSets []*RuleSetConfig `json:"sourceIpRuleSet"`
 	}
 	rawFieldRule := new(RawFieldRule)
 	err := json.Unmarshal(msg, rawFieldRule)
@@ -540,6 +623,8 @@ func parseFieldRule(msg json.RawMessage) (*router.RoutingRule, error) {
 		rule.DomainMatcher = rawFieldRule.DomainMatcher
 	}
 
+	rule.RuleTag = rawFieldRule.RuleTag
+
 	if rawFieldRule.Domain != nil {
 		for _, domain := range *rawFieldRule.Domain {
 			rules, err := parseDomainRule(domain)
@@ -610,6 +695,34 @@ func parseFieldRule(msg json.RawMessage) (*router.RoutingRule, error) {
 		rule.Attributes = rawFieldRule.Attributes
 	}
 
+	if rawFieldRule.ALPN != nil {
+		rule.Alpn = *rawFieldRule.ALPN
+	}
+
+	if rawFieldRule.TLSFingerprint != nil {
+		rule.TlsFingerprint = *rawFieldRule.TLSFingerprint
+	}
+
+	if rawFieldRule.ProcessName != nil {
+		rule.ProcessName = *rawFieldRule.ProcessName
+	}
+
+	if len(rawFieldRule.ProcessUID) > 0 {
+		rule.ProcessUid = rawFieldRule.ProcessUID
+	}
+
+	if rule.DomainRuleSet, err = buildRuleSets(rawFieldRule.DomainRuleSets); err != nil {
+		return nil, newError("invalid domain rule set").Base(err)
+	}
+
+	if rule.IpRuleSet, err = buildRuleSets(rawFieldRule.IPRuleSets); err != nil {
+		return nil, newError("invalid IP rule set").Base(err)
+	}
+
+	if rule.SourceIpRuleSet, err = buildRuleSets(rawFieldRule.SourceIPRuleSets); err != nil {
+		return nil, newError("invalid source IP rule set").Base(err)
+	}
+
 	return rule, nil
 }
 
@@ -1,6 +1,113 @@
 package conf
 
+import (
+	"regexp"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/xtls/xray-core/app/router"
+	"github.com/xtls/xray-core/infra/conf/cfgcommon/duration"
+)
+
 const (
-	strategyRandom    string = "random"
-	strategyLeastPing string = "leastping"
+	strategyRandom     string = "random"
+	strategyLeastPing  string = "leastping"
+	strategyRoundRobin string = "roundrobin"
+	strategyLeastLoad  string = "leastload"
 )
+
+var strategyConfigLoader = NewJSONConfigLoader(ConfigCreatorCache{
+	strategyRandom:     func() interface{} { return new(strategyEmptyConfig) },
+	strategyLeastPing:  func() interface{} { return new(strategyEmptyConfig) },
+	strategyRoundRobin: func() interface{} { return new(strategyRoundRobinConfig) },
+	strategyLeastLoad:  func() interface{} { return new(strategyLeastLoadConfig) },
+}, "type", "settings")
+
+type strategyEmptyConfig struct{}
+
+func (v *strategyEmptyConfig) Build() (proto.Message, error) {
+	return nil, nil
+}
+
+type strategyWeight struct {
+	Regexp bool    `json:"regexp"`
+	Match  string  `json:"match"`
+	Value  float32 `json:"value"`
+}
+
+func (v *strategyWeight) Build() (*router.StrategyWeight, error) {
+	if v.Regexp {
+		if _, err := regexp.Compile(v.Match); err != nil {
+			return nil, newError("invalid weight match: ", v.Match).Base(err)
+		}
+	}
+	if v.Value < 0 {
+		return nil, newError("negative weight value for ", v.Match)
+	}
+	return &router.StrategyWeight{
+		Regexp: v.Regexp,
+		Match:  v.Match,
+		Value:  v.Value,
+	}, nil
+}
+
+func buildStrategyWeights(weights []*strategyWeight) ([]*router.StrategyWeight, error) {
+	result := make([]*router.StrategyWeight, 0, len(weights))
+	for _, w := range weights {
+		weight, err := w.Build()
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, weight)
+	}
+	return result, nil
+}
+
+type strategyRoundRobinConfig struct {
+	Weights []*strategyWeight `json:"weights"`
+}
+
+// Build implements Buildable.
+func (v *strategyRoundRobinConfig) Build() (proto.Message, error) {
+	weights, err := buildStrategyWeights(v.Weights)
+	if err != nil {
+		return nil, err
+	}
+	return &router.StrategyRoundRobinConfig{
+		Weights: weights,
+	}, nil
+}
+
+type strategyLeastLoadConfig struct {
+	Costs     []*strategyWeight   `json:"costs"`
+	Baselines []duration.Duration `json:"baselines"`
+	Expected  int32               `json:"expected"`
+	MaxRTT    duration.Duration   `json:"maxRTT"`
+	Tolerance float32             `json:"tolerance"`
+}
+
+// Build implements Buildable.
+func (v *strategyLeastLoadConfig) Build() (proto.Message, error) {
+	if v.Expected < 0 {
+		return nil, newError("negative expected count")
+	}
+	if v.Tolerance < 0 || v.Tolerance > 1 {
+		return nil, newError("tolerance must be between 0 and 1")
+	}
+	costs, err := buildStrategyWeights(v.Costs)
+	if err != nil {
+		return nil, err
+	}
+	config := &router.StrategyLeastLoadConfig{
+		Costs:     costs,
+		Expected:  v.Expected,
+		MaxRTT:    int64(v.MaxRTT),
+		Tolerance: v.Tolerance,
+	}
+	for _, b := range v.Baselines {
+		if b <= 0 {
+			return nil, newError("baselines must be positive")
+		}
+		config.Baselines = append(config.Baselines, int64(b))
+	}
+	return config, nil
+}
@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 	_ "unsafe"
 
 	"github.com/golang/protobuf/proto"
@@ -13,6 +14,7 @@ import (
 	"github.com/xtls/xray-core/common/net"
 	"github.com/xtls/xray-core/common/platform"
 	"github.com/xtls/xray-core/common/platform/filesystem"
+	"github.com/xtls/xray-core/common/serial"
 	. "github.com/xtls/xray-core/infra/conf"
 )
 
@@ -88,6 +90,19 @@ func TestRouterConfig(t *testing.T) {
 						},{
 							"type": "field",
 							"port": 123,
+							"outboundTag": "test",
+							"ruleTag": "ntp"
+						},{
+							"type": "field",
+							"alpn": ["h2"],
+							"tlsFingerprint": ["t13d1516h2_"],
+							"processName": ["curl"],
+							"processUid": [0, 1000],
+							"outboundTag": "test"
+						},{
+							"type": "field",
+							"domainRuleSet": [{"path": "ads.txt", "refreshInterval": 300}],
+							"ipRuleSet": [{"path": "geoip.dat", "format": "protobuf", "code": "private"}],
 							"outboundTag": "test"
 						}
 					]
@@ -165,6 +180,93 @@ func TestRouterConfig(t *testing.T) {
 						TargetTag: &router.RoutingRule_Tag{
 							Tag: "test",
 						},
+						RuleTag: "ntp",
+					},
+					{
+						Alpn:           []string{"h2"},
+						TlsFingerprint: []string{"t13d1516h2_"},
+						ProcessName:    []string{"curl"},
+						ProcessUid:     []uint32{0, 1000},
+						TargetTag: &router.RoutingRule_Tag{
+							Tag: "test",
+						},
+					},
+					{
+						DomainRuleSet: []*router.RuleSet{
+							{Path: "ads.txt", RefreshInterval: 300},
+						},
+						IpRuleSet: []*router.RuleSet{
+							{Path: "geoip.dat", Format: router.RuleSet_Protobuf, Code: "private"},
+						},
+						TargetTag: &router.RoutingRule_Tag{
+							Tag: "test",
+						},
+					},
+				},
+			},
+		},
+		{
+			Input: `{
+				"balancers": [
+					{
+						"tag": "b1",
+						"selector": ["test"],
+						"strategy": {
+							"type": "roundRobin",
+							"settings": {
+								"weights": [
+									{"match": "us", "value": 5}
+								]
+							}
+						}
+					},
+					{
+						"tag": "b2",
+						"selector": ["test"],
+						"strategy": {
+							"type": "leastLoad",
+							"settings": {
+								"costs": [
+									{"regexp": true, "match": "^test-hk", "value": 0.5}
+								],
+								"baselines": ["200ms", "1s"],
+								"expected": 2,
+								"maxRTT": "3s",
+								"tolerance": 0.1
+							}
+						},
+						"fallbackTag": "direct"
+					}
+				]
+			}`,
+			Parser: createParser(),
+			Output: &router.Config{
+				DomainStrategy: router.Config_AsIs,
+				BalancingRule: []*router.BalancingRule{
+					{
+						Tag:              "b1",
+						OutboundSelector: []string{"test"},
+						Strategy:         "roundRobin",
+						StrategySettings: serial.ToTypedMessage(&router.StrategyRoundRobinConfig{
+							Weights: []*router.StrategyWeight{
+								{Match: "us", Value: 5},
+							},
+						}),
+					},
+					{
+						Tag:              "b2",
+						OutboundSelector: []string{"test"},
+						Strategy:         "leastLoad",
+						StrategySettings: serial.ToTypedMessage(&router.StrategyLeastLoadConfig{
+							Costs: []*router.StrategyWeight{
+								{Regexp: true, Match: "^test-hk", Value: 0.5},
+							},
+							Baselines: []int64{int64(200 * time.Millisecond), int64(time.Second)},
+							Expected:  2,
+							MaxRTT:    int64(3 * time.Second),
+							Tolerance: 0.1,
+						}),
+						FallbackTag: "direct",
 					},
 				},
 			},
@@ -0,0 +1,148 @@
+package serial
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/pelletier/go-toml"
+	json_reader "github.com/xtls/xray-core/infra/conf/json"
+)
+
+// DecodeConfigMap reads a config of the format into a generic map, without interpreting it.
+func DecodeConfigMap(reader io.Reader, format string) (map[string]interface{}, error) {
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, newError("failed to read config file").Base(err)
+	}
+
+	switch format {
+	case "json":
+		content, err = io.ReadAll(&json_reader.Reader{Reader: bytes.NewReader(content)})
+		if err != nil {
+			return nil, newError("failed to read config file").Base(err)
+		}
+	case "yaml":
+		if content, err = yaml.YAMLToJSON(content); err != nil {
+			return nil, newError("failed to convert yaml to json").Base(err)
+		}
+	case "toml":
+		configMap := make(map[string]interface{})
+		if err := toml.Unmarshal(content, &configMap); err != nil {
+			return nil, newError("failed to convert toml to map").Base(err)
+		}
+		if content, err = json.Marshal(configMap); err != nil {
+			return nil, newError("failed to convert map to json").Base(err)
+		}
+	default:
+		return nil, newError("unknown config format: ", format)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+	configMap := make(map[string]interface{})
+	if err := decoder.Decode(&configMap); err != nil {
+		return nil, newError("failed to decode config").Base(err)
+	}
+	return normalizeValue(configMap).(map[string]interface{}), nil
+}
+
+// normalizeValue turns json.Number into int64 or float64 and drops null values, so that the value can be
+// encoded in all formats.
+func normalizeValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if value == nil {
+				delete(v, key)
+				continue
+			}
+			v[key] = normalizeValue(value)
+		}
+		return v
+	case []interface{}:
+		for i, value := range v {
+			v[i] = normalizeValue(value)
+		}
+		return v
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	default:
+		return v
+	}
+}
+
+// MergeConfigMap merges the config src read from file fn into dst, the same way as conf.Config.Override.
+func MergeConfigMap(dst map[string]interface{}, src map[string]interface{}, fn string) {
+	for key, value := range src {
+		switch key {
+		case "inbounds", "outbounds":
+			dst[key] = mergeDetours(key, dst[key], value, fn)
+		default:
+			dst[key] = value
+		}
+	}
+}
+
+func mergeDetours(key string, dst interface{}, src interface{}, fn string) interface{} {
+	dstList, _ := dst.([]interface{})
+	srcList, ok := src.([]interface{})
+	if !ok || len(srcList) == 0 {
+		if dst == nil {
+			return src
+		}
+		return dst
+	}
+	if len(dstList) == 0 || len(srcList) != 1 {
+		return srcList
+	}
+
+	tag := detourTag(srcList[0])
+	for i, detour := range dstList {
+		if detourTag(detour) == tag {
+			dstList[i] = srcList[0]
+			return dstList
+		}
+	}
+	if key == "outbounds" && !strings.Contains(strings.ToLower(fn), "tail") {
+		return append(srcList, dstList...)
+	}
+	return append(dstList, srcList[0])
+}
+
+func detourTag(v interface{}) string {
+	if m, ok := v.(map[string]interface{}); ok {
+		if tag, ok := m["tag"].(string); ok {
+			return tag
+		}
+	}
+	return ""
+}
+
+// EncodeConfigMap writes a generic config map in the format.
+func EncodeConfigMap(configMap map[string]interface{}, format string) ([]byte, error) {
+	switch format {
+	case "json":
+		return json.MarshalIndent(configMap, "", "  ")
+	case "yaml":
+		return yaml.Marshal(configMap)
+	case "toml":
+		tree, err := toml.TreeFromMap(configMap)
+		if err != nil {
+			return nil, newError("failed to convert map to toml").Base(err)
+		}
+		s, err := tree.ToTomlString()
+		if err != nil {
+			return nil, newError("failed to encode toml").Base(err)
+		}
+		return []byte(s), nil
+	default:
+		return nil, newError("unknown config format: ", format)
+	}
+}
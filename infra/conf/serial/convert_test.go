@@ -0,0 +1,51 @@
+package serial_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/infra/conf/serial"
+)
+
+func TestConvertConfigMap(t *testing.T) {
+	base, err := serial.DecodeConfigMap(strings.NewReader(`{
+		// comment
+		"log": {"loglevel": "info"},
+		"inbounds": [{"tag": "in", "port": 1080}],
+		"outbounds": [{"tag": "direct", "protocol": "freedom"}]
+	}`), "json")
+	common.Must(err)
+
+	override, err := serial.DecodeConfigMap(strings.NewReader(`
+outbounds:
+  - tag: block
+    protocol: blackhole
+`), "yaml")
+	common.Must(err)
+	serial.MergeConfigMap(base, override, "override.yaml")
+
+	expected := map[string]interface{}{
+		"log":      map[string]interface{}{"loglevel": "info"},
+		"inbounds": []interface{}{map[string]interface{}{"tag": "in", "port": int64(1080)}},
+		"outbounds": []interface{}{
+			map[string]interface{}{"tag": "block", "protocol": "blackhole"},
+			map[string]interface{}{"tag": "direct", "protocol": "freedom"},
+		},
+	}
+	if r := cmp.Diff(expected, base); r != "" {
+		t.Error(r)
+	}
+
+	for _, format := range []string{"json", "yaml", "toml"} {
+		content, err := serial.EncodeConfigMap(base, format)
+		common.Must(err)
+		decoded, err := serial.DecodeConfigMap(bytes.NewReader(content), format)
+		common.Must(err)
+		if r := cmp.Diff(expected, decoded); r != "" {
+			t.Error(format, r)
+		}
+	}
+}
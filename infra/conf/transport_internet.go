@@ -20,9 +20,11 @@ import (
 	"github.com/xtls/xray-core/transport/internet/domainsocket"
 	httpheader "github.com/xtls/xray-core/transport/internet/headers/http"
 	"github.com/xtls/xray-core/transport/internet/http"
+	"github.com/xtls/xray-core/transport/internet/httpupgrade"
 	"github.com/xtls/xray-core/transport/internet/kcp"
 	"github.com/xtls/xray-core/transport/internet/quic"
 	"github.com/xtls/xray-core/transport/internet/reality"
+	"github.com/xtls/xray-core/transport/internet/splithttp"
 	"github.com/xtls/xray-core/transport/internet/tcp"
 	"github.com/xtls/xray-core/transport/internet/tls"
 	"github.com/xtls/xray-core/transport/internet/websocket"
@@ -181,6 +183,66 @@ func (c *WebSocketConfig) Build() (proto.Message, error) {
 	return config, nil
 }
 
+type HTTPUpgradeConfig struct {
+	Host                string            `json:"host"`
+	Path                string            `json:"path"`
+	Headers             map[string]string `json:"headers"`
+	AcceptProxyProtocol bool              `json:"acceptProxyProtocol"`
+}
+
+// Build implements Buildable.
+func (c *HTTPUpgradeConfig) Build() (proto.Message, error) {
+	for key := range c.Headers {
+		if strings.EqualFold(key, "Host") {
+			return nil, newError(`HTTPUpgrade: please use "host" instead of "headers.Host"`)
+		}
+	}
+	path := c.Path
+	var ed uint32
+	if u, err := url.Parse(path); err == nil {
+		if q := u.Query(); q.Get("ed") != "" {
+			Ed, _ := strconv.Atoi(q.Get("ed"))
+			ed = uint32(Ed)
+			q.Del("ed")
+			u.RawQuery = q.Encode()
+			path = u.String()
+		}
+	}
+	config := &httpupgrade.Config{
+		Host:                c.Host,
+		Path:                path,
+		Header:              c.Headers,
+		AcceptProxyProtocol: c.AcceptProxyProtocol,
+		Ed:                  ed,
+	}
+	return config, nil
+}
+
+type SplitHTTPConfig struct {
+	Host                 string            `json:"host"`
+	Path                 string            `json:"path"`
+	Headers              map[string]string `json:"headers"`
+	MaxConcurrentUploads uint32            `json:"maxConcurrentUploads"`
+	MaxUploadSize        uint32            `json:"maxUploadSize"`
+}
+
+// Build implements Buildable.
+func (c *SplitHTTPConfig) Build() (proto.Message, error) {
+	for key := range c.Headers {
+		if strings.EqualFold(key, "Host") {
+			return nil, newError(`SplitHTTP: please use "host" instead of "headers.Host"`)
+		}
+	}
+	config := &splithttp.Config{
+		Host:                 c.Host,
+		Path:                 c.Path,
+		Header:               c.Headers,
+		MaxConcurrentUploads: c.MaxConcurrentUploads,
+		MaxUploadSize:        c.MaxUploadSize,
+	}
+	return config, nil
+}
+
 type HTTPConfig struct {
 	Host               *StringList            `json:"host"`
 	Path               string                 `json:"path"`
@@ -694,22 +756,85 @@ func (p TransportProtocol) Build() (string, error) {
 		return "quic", nil
 	case "grpc", "gun":
 		return "grpc", nil
+	case "splithttp":
+		return "splithttp", nil
+	case "httpupgrade":
+		return "httpupgrade", nil
 	default:
 		return "", newError("Config: unknown transport protocol: ", p)
 	}
 }
 
 type SocketConfig struct {
-	Mark                 int32       `json:"mark"`
-	TFO                  interface{} `json:"tcpFastOpen"`
-	TProxy               string      `json:"tproxy"`
-	AcceptProxyProtocol  bool        `json:"acceptProxyProtocol"`
-	DomainStrategy       string      `json:"domainStrategy"`
-	DialerProxy          string      `json:"dialerProxy"`
-	TCPKeepAliveInterval int32       `json:"tcpKeepAliveInterval"`
-	TCPKeepAliveIdle     int32       `json:"tcpKeepAliveIdle"`
-	TCPCongestion        string      `json:"tcpCongestion"`
-	Interface            string      `json:"interface"`
+	Mark                 int32                `json:"mark"`
+	TFO                  interface{}          `json:"tcpFastOpen"`
+	TProxy               string               `json:"tproxy"`
+	AcceptProxyProtocol  bool                 `json:"acceptProxyProtocol"`
+	DomainStrategy       string               `json:"domainStrategy"`
+	DialerProxy          string               `json:"dialerProxy"`
+	TCPKeepAliveInterval int32                `json:"tcpKeepAliveInterval"`
+	TCPKeepAliveIdle     int32                `json:"tcpKeepAliveIdle"`
+	TCPCongestion        string               `json:"tcpCongestion"`
+	Interface            string               `json:"interface"`
+	HappyEyeballs        *HappyEyeballsConfig `json:"happyEyeballs"`
+	TCPMptcp             bool                 `json:"tcpMptcp"`
+	TCPUserTimeout       int32                `json:"tcpUserTimeout"`
+	TCPMaxSeg            int32                `json:"tcpMaxSeg"`
+	V6Only               bool                 `json:"v6only"`
+	CustomSockopt        []*CustomSockopt     `json:"customSockopt"`
+}
+
+type CustomSockopt struct {
+	Level int32       `json:"level"`
+	Opt   int32       `json:"opt"`
+	Value interface{} `json:"value"`
+}
+
+// Build implements Buildable.
+func (c *CustomSockopt) Build() (*internet.CustomSockopt, error) {
+	config := &internet.CustomSockopt{
+		Level: c.Level,
+		Opt:   c.Opt,
+	}
+	switch v := c.Value.(type) {
+	case float64:
+		if v != math.Trunc(v) || v < math.MinInt32 || v > math.MaxInt32 {
+			return nil, newError("customSockopt: invalid integer value ", v)
+		}
+		config.Value = &internet.CustomSockopt_IntValue{IntValue: int32(v)}
+	case string:
+		config.Value = &internet.CustomSockopt_StringValue{StringValue: v}
+	default:
+		return nil, newError("customSockopt: only integer and string value is acceptable")
+	}
+	return config, nil
+}
+
+type HappyEyeballsConfig struct {
+	PrioritizeIPv6   bool   `json:"prioritizeIPv6"`
+	Interleave       uint32 `json:"interleave"`
+	TryDelayMs       uint64 `json:"tryDelayMs"`
+	MaxConcurrentTry uint32 `json:"maxConcurrentTry"`
+}
+
+// Build implements Buildable.
+func (c *HappyEyeballsConfig) Build() (*internet.HappyEyeballsConfig, error) {
+	config := &internet.HappyEyeballsConfig{
+		PrioritizeIpv6:   c.PrioritizeIPv6,
+		Interleave:       c.Interleave,
+		TryDelayMs:       c.TryDelayMs,
+		MaxConcurrentTry: c.MaxConcurrentTry,
+	}
+	if config.Interleave == 0 {
+		config.Interleave = 1
+	}
+	if config.TryDelayMs == 0 {
+		config.TryDelayMs = 250
+	}
+	if config.MaxConcurrentTry == 0 {
+		config.MaxConcurrentTry = 4
+	}
+	return config, nil
 }
 
 // Build implements Buildable.
@@ -749,6 +874,27 @@ func (c *SocketConfig) Build() (*internet.SocketConfig, error) {
 		dStrategy = internet.DomainStrategy_USE_IP6
 	}
 
+	var happyEyeballs *internet.HappyEyeballsConfig
+	if c.HappyEyeballs != nil {
+		config, err := c.HappyEyeballs.Build()
+		if err != nil {
+			return nil, newError("failed to build happy eyeballs config").Base(err)
+		}
+		happyEyeballs = config
+	}
+
+	if len(c.CustomSockopt) > 0 && runtime.GOOS != "linux" && runtime.GOOS != "android" {
+		return nil, newError(`"customSockopt" only support linux in this version`)
+	}
+	var customSockopts []*internet.CustomSockopt
+	for _, opt := range c.CustomSockopt {
+		config, err := opt.Build()
+		if err != nil {
+			return nil, err
+		}
+		customSockopts = append(customSockopts, config)
+	}
+
 	return &internet.SocketConfig{
 		Mark:                 c.Mark,
 		Tfo:                  tfo,
@@ -760,24 +906,32 @@ func (c *SocketConfig) Build() (*internet.SocketConfig, error) {
 		TcpKeepAliveIdle:     c.TCPKeepAliveIdle,
 		TcpCongestion:        c.TCPCongestion,
 		Interface:            c.Interface,
+		HappyEyeballs:        happyEyeballs,
+		TcpMptcp:             c.TCPMptcp,
+		TcpUserTimeout:       c.TCPUserTimeout,
+		TcpMaxSeg:            c.TCPMaxSeg,
+		V6Only:               c.V6Only,
+		CustomSockopt:        customSockopts,
 	}, nil
 }
 
 type StreamConfig struct {
-	Network         *TransportProtocol  `json:"network"`
-	Security        string              `json:"security"`
-	TLSSettings     *TLSConfig          `json:"tlsSettings"`
-	XTLSSettings    *XTLSConfig         `json:"xtlsSettings"`
-	REALITYSettings *REALITYConfig      `json:"realitySettings"`
-	TCPSettings     *TCPConfig          `json:"tcpSettings"`
-	KCPSettings     *KCPConfig          `json:"kcpSettings"`
-	WSSettings      *WebSocketConfig    `json:"wsSettings"`
-	HTTPSettings    *HTTPConfig         `json:"httpSettings"`
-	DSSettings      *DomainSocketConfig `json:"dsSettings"`
-	QUICSettings    *QUICConfig         `json:"quicSettings"`
-	SocketSettings  *SocketConfig       `json:"sockopt"`
-	GRPCConfig      *GRPCConfig         `json:"grpcSettings"`
-	GUNConfig       *GRPCConfig         `json:"gunSettings"`
+	Network             *TransportProtocol  `json:"network"`
+	Security            string              `json:"security"`
+	TLSSettings         *TLSConfig          `json:"tlsSettings"`
+	XTLSSettings        *XTLSConfig         `json:"xtlsSettings"`
+	REALITYSettings     *REALITYConfig      `json:"realitySettings"`
+	TCPSettings         *TCPConfig          `json:"tcpSettings"`
+	KCPSettings         *KCPConfig          `json:"kcpSettings"`
+	WSSettings          *WebSocketConfig    `json:"wsSettings"`
+	HTTPSettings        *HTTPConfig         `json:"httpSettings"`
+	DSSettings          *DomainSocketConfig `json:"dsSettings"`
+	QUICSettings        *QUICConfig         `json:"quicSettings"`
+	SocketSettings      *SocketConfig       `json:"sockopt"`
+	GRPCConfig          *GRPCConfig         `json:"grpcSettings"`
+	GUNConfig           *GRPCConfig         `json:"gunSettings"`
+	SplitHTTPSettings   *SplitHTTPConfig    `json:"splithttpSettings"`
+	HTTPUpgradeSettings *HTTPUpgradeConfig  `json:"httpupgradeSettings"`
 }
 
 // Build implements Buildable.
@@ -915,6 +1069,26 @@ func (c *StreamConfig) Build() (*internet.StreamConfig, error) {
 			Settings:     serial.ToTypedMessage(gs),
 		})
 	}
+	if c.SplitHTTPSettings != nil {
+		shs, err := c.SplitHTTPSettings.Build()
+		if err != nil {
+			return nil, newError("Failed to build SplitHTTP config.").Base(err)
+		}
+		config.TransportSettings = append(config.TransportSettings, &internet.TransportConfig{
+			ProtocolName: "splithttp",
+			Settings:     serial.ToTypedMessage(shs),
+		})
+	}
+	if c.HTTPUpgradeSettings != nil {
+		hs, err := c.HTTPUpgradeSettings.Build()
+		if err != nil {
+			return nil, newError("Failed to build HTTPUpgrade config.").Base(err)
+		}
+		config.TransportSettings = append(config.TransportSettings, &internet.TransportConfig{
+			ProtocolName: "httpupgrade",
+			Settings:     serial.ToTypedMessage(hs),
+		})
+	}
 	if c.SocketSettings != nil {
 		ss, err := c.SocketSettings.Build()
 		if err != nil {
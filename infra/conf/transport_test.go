@@ -2,6 +2,7 @@ package conf_test
 
 import (
 	"encoding/json"
+	"runtime"
 	"testing"
 
 	"github.com/golang/protobuf/proto"
@@ -14,8 +15,10 @@ import (
 	"github.com/xtls/xray-core/transport/internet/headers/http"
 	"github.com/xtls/xray-core/transport/internet/headers/noop"
 	"github.com/xtls/xray-core/transport/internet/headers/tls"
+	"github.com/xtls/xray-core/transport/internet/httpupgrade"
 	"github.com/xtls/xray-core/transport/internet/kcp"
 	"github.com/xtls/xray-core/transport/internet/quic"
+	"github.com/xtls/xray-core/transport/internet/splithttp"
 	"github.com/xtls/xray-core/transport/internet/tcp"
 	"github.com/xtls/xray-core/transport/internet/websocket"
 )
@@ -159,6 +162,74 @@ func TestSocketConfig(t *testing.T) {
 	if expectedOutput.ParseTFOValue() != -1 {
 		t.Fatalf("unexpected parsed TFO value, which should be -1")
 	}
+
+	// test "happyEyeballs", defaults are expected for the omitted fields
+	runMultiTestCase(t, []TestCase{
+		{
+			Input: `{
+				"domainStrategy": "UseIP",
+				"happyEyeballs": {
+					"prioritizeIPv6": true,
+					"tryDelayMs": 100
+				}
+			}`,
+			Parser: createParser(),
+			Output: &internet.SocketConfig{
+				DomainStrategy: internet.DomainStrategy_USE_IP,
+				HappyEyeballs: &internet.HappyEyeballsConfig{
+					PrioritizeIpv6:   true,
+					Interleave:       1,
+					TryDelayMs:       100,
+					MaxConcurrentTry: 4,
+				},
+			},
+		},
+	})
+
+	// test MPTCP and extended socket options
+	runMultiTestCase(t, []TestCase{
+		{
+			Input: `{
+				"tcpMptcp": true,
+				"tcpUserTimeout": 10000,
+				"tcpMaxSeg": 1400,
+				"v6only": true
+			}`,
+			Parser: createParser(),
+			Output: &internet.SocketConfig{
+				TcpMptcp:       true,
+				TcpUserTimeout: 10000,
+				TcpMaxSeg:      1400,
+				V6Only:         true,
+			},
+		},
+	})
+
+	// test custom socket options, which only support linux
+	customSockopt := `{
+		"customSockopt": [
+			{"level": 6, "opt": 13, "value": "bbr"},
+			{"level": 1, "opt": 7, "value": 4194304}
+		]
+	}`
+	if runtime.GOOS != "linux" && runtime.GOOS != "android" {
+		if _, err := createParser()(customSockopt); err == nil {
+			t.Error("expect error for customSockopt on ", runtime.GOOS)
+		}
+		return
+	}
+	runMultiTestCase(t, []TestCase{
+		{
+			Input:  customSockopt,
+			Parser: createParser(),
+			Output: &internet.SocketConfig{
+				CustomSockopt: []*internet.CustomSockopt{
+					{Level: 6, Opt: 13, Value: &internet.CustomSockopt_StringValue{StringValue: "bbr"}},
+					{Level: 1, Opt: 7, Value: &internet.CustomSockopt_IntValue{IntValue: 4194304}},
+				},
+			},
+		},
+	})
 }
 
 func TestTransportConfig(t *testing.T) {
@@ -312,3 +383,54 @@ func TestTransportConfig(t *testing.T) {
 		},
 	})
 }
+
+func TestSplitHTTPConfig(t *testing.T) {
+	creator := func() Buildable {
+		return new(SplitHTTPConfig)
+	}
+
+	runMultiTestCase(t, []TestCase{
+		{
+			Input: `{
+				"host": "example.com",
+				"path": "/sh",
+				"headers": {"User-Agent": "curl"},
+				"maxConcurrentUploads": 5,
+				"maxUploadSize": 65536
+			}`,
+			Parser: loadJSON(creator),
+			Output: &splithttp.Config{
+				Host:                 "example.com",
+				Path:                 "/sh",
+				Header:               map[string]string{"User-Agent": "curl"},
+				MaxConcurrentUploads: 5,
+				MaxUploadSize:        65536,
+			},
+		},
+	})
+}
+
+func TestHTTPUpgradeConfig(t *testing.T) {
+	creator := func() Buildable {
+		return new(HTTPUpgradeConfig)
+	}
+
+	runMultiTestCase(t, []TestCase{
+		{
+			Input: `{
+				"host": "example.com",
+				"path": "/up?ed=2048&a=b",
+				"headers": {"User-Agent": "curl"},
+				"acceptProxyProtocol": true
+			}`,
+			Parser: loadJSON(creator),
+			Output: &httpupgrade.Config{
+				Host:                "example.com",
+				Path:                "/up?a=b",
+				Header:              map[string]string{"User-Agent": "curl"},
+				AcceptProxyProtocol: true,
+				Ed:                  2048,
+			},
+		},
+	})
+}
@@ -333,6 +333,17 @@ func TestXrayConfig(t *testing.T) {
 	})
 }
 
+func TestConfig_ObservatoryConflict(t *testing.T) {
+	config := new(Config)
+	common.Must(json.Unmarshal([]byte(`{
+		"observatory": {"subjectSelector": ["proxy"]},
+		"burstObservatory": {"subjectSelector": ["proxy"]}
+	}`), config))
+	if _, err := config.Build(); err == nil {
+		t.Error("expect error for both observatory and burstObservatory configured")
+	}
+}
+
 func TestMuxConfig_Build(t *testing.T) {
 	tests := []struct {
 		name   string
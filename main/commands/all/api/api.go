@@ -15,9 +15,21 @@ var CmdAPI = &base.Command{
 		cmdGetStats,
 		cmdQueryStats,
 		cmdSysStats,
+		cmdQueryUsage,
+		cmdListConnections,
+		cmdCloseConnections,
+		cmdLookupFakeDNS,
+		cmdFakeDNSStats,
+		cmdFlushFakeDNS,
 		cmdAddInbounds,
 		cmdAddOutbounds,
 		cmdRemoveInbounds,
 		cmdRemoveOutbounds,
+		cmdAddRules,
+		cmdRemoveRules,
+		cmdListRules,
+		cmdAddBalancers,
+		cmdRemoveBalancers,
+		cmdReloadConfig,
 	},
 }
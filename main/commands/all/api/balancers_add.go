@@ -0,0 +1,79 @@
+package api
+
+import (
+	"fmt"
+
+	"github.com/xtls/xray-core/app/router"
+	routerService "github.com/xtls/xray-core/app/router/command"
+	"github.com/xtls/xray-core/infra/conf/serial"
+	"github.com/xtls/xray-core/main/commands/base"
+)
+
+var cmdAddBalancers = &base.Command{
+	CustomFlags: true,
+	UsageLine:   "{{.Exec}} api adb [--server=127.0.0.1:8080] <c1.json> [c2.json]...",
+	Short:       "Add balancers",
+	Long: `
+Add balancers to Xray. Balancers are read from the "routing" section of the
+configs. A balancer with the same tag as an existing one replaces it.
+Arguments:
+	-s, -server 
+		The API server address. Default 127.0.0.1:8080
+	-t, -timeout
+		Timeout seconds to call API. Default 3
+Example:
+    {{.Exec}} {{.LongName}} --server=127.0.0.1:8080 c1.json c2.json
+`,
+	Run: executeAddBalancers,
+}
+
+func executeAddBalancers(cmd *base.Command, args []string) {
+	setSharedFlags(cmd)
+	cmd.Flag.Parse(args)
+	unnamedArgs := cmd.Flag.Args()
+	if len(unnamedArgs) == 0 {
+		fmt.Println("reading from stdin:")
+		unnamedArgs = []string{"stdin:"}
+	}
+
+	bs := make([]*router.BalancingRule, 0)
+	for _, arg := range unnamedArgs {
+		r, err := loadArg(arg)
+		if err != nil {
+			base.Fatalf("failed to load %s: %s", arg, err)
+		}
+		conf, err := serial.DecodeJSONConfig(r)
+		if err != nil {
+			base.Fatalf("failed to decode %s: %s", arg, err)
+		}
+		if conf.RouterConfig == nil {
+			continue
+		}
+		for _, b := range conf.RouterConfig.Balancers {
+			br, err := b.Build()
+			if err != nil {
+				base.Fatalf("failed to build conf: %s", err)
+			}
+			bs = append(bs, br)
+		}
+	}
+	if len(bs) == 0 {
+		base.Fatalf("no valid balancer found")
+	}
+
+	conn, ctx, close := dialAPIServer()
+	defer close()
+
+	client := routerService.NewRoutingServiceClient(conn)
+	for _, b := range bs {
+		fmt.Println("adding:", b.Tag)
+		r := &routerService.AddBalancerRequest{
+			Balancer: b,
+		}
+		resp, err := client.AddBalancer(ctx, r)
+		if err != nil {
+			base.Fatalf("failed to add balancer: %s", err)
+		}
+		showJSONResponse(resp)
+	}
+}
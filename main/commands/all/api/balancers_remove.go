@@ -0,0 +1,76 @@
+package api
+
+import (
+	"fmt"
+
+	routerService "github.com/xtls/xray-core/app/router/command"
+	"github.com/xtls/xray-core/infra/conf/serial"
+	"github.com/xtls/xray-core/main/commands/base"
+)
+
+var cmdRemoveBalancers = &base.Command{
+	CustomFlags: true,
+	UsageLine:   "{{.Exec}} api rmb [--server=127.0.0.1:8080] <json_file|tag> [json_file] [tag]...",
+	Short:       "Remove balancers",
+	Long: `
+Remove balancers from Xray. A balancer still used by a routing rule
+can't be removed.
+Arguments:
+	-s, -server 
+		The API server address. Default 127.0.0.1:8080
+	-t, -timeout
+		Timeout seconds to call API. Default 3
+Example:
+    {{.Exec}} {{.LongName}} --server=127.0.0.1:8080 c1.json "tag name"
+`,
+	Run: executeRemoveBalancers,
+}
+
+func executeRemoveBalancers(cmd *base.Command, args []string) {
+	setSharedFlags(cmd)
+	cmd.Flag.Parse(args)
+	unnamedArgs := cmd.Flag.Args()
+	if len(unnamedArgs) == 0 {
+		fmt.Println("reading from stdin:")
+		unnamedArgs = []string{"stdin:"}
+	}
+
+	tags := make([]string, 0)
+	for _, arg := range unnamedArgs {
+		if r, err := loadArg(arg); err == nil {
+			conf, err := serial.DecodeJSONConfig(r)
+			if err != nil {
+				base.Fatalf("failed to decode %s: %s", arg, err)
+			}
+			if conf.RouterConfig != nil {
+				for _, b := range conf.RouterConfig.Balancers {
+					tags = append(tags, b.Tag)
+				}
+			}
+		} else {
+			// take request as tag
+			tags = append(tags, arg)
+		}
+	}
+
+	if len(tags) == 0 {
+		base.Fatalf("no balancer to remove")
+	}
+	fmt.Println("removing balancers:", tags)
+
+	conn, ctx, close := dialAPIServer()
+	defer close()
+
+	client := routerService.NewRoutingServiceClient(conn)
+	for _, tag := range tags {
+		fmt.Println("removing:", tag)
+		r := &routerService.RemoveBalancerRequest{
+			Tag: tag,
+		}
+		resp, err := client.RemoveBalancer(ctx, r)
+		if err != nil {
+			base.Fatalf("failed to remove balancer: %s", err)
+		}
+		showJSONResponse(resp)
+	}
+}
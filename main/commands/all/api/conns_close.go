@@ -0,0 +1,58 @@
+package api
+
+import (
+	"strconv"
+
+	connectionService "github.com/xtls/xray-core/app/dispatcher/command"
+	"github.com/xtls/xray-core/main/commands/base"
+)
+
+var cmdCloseConnections = &base.Command{
+	CustomFlags: true,
+	UsageLine:   "{{.Exec}} api closeconns [--server=127.0.0.1:8080] [-email ''] [id]...",
+	Short:       "Close live connections",
+	Long: `
+Close live connections of Xray by their IDs, or all connections of a user.
+Arguments:
+	-s, -server 
+		The API server address. Default 127.0.0.1:8080
+	-t, -timeout
+		Timeout seconds to call API. Default 3
+	-email
+		Close all connections of the user.
+Example:
+	{{.Exec}} {{.LongName}} --server=127.0.0.1:8080 12 13
+	{{.Exec}} {{.LongName}} --server=127.0.0.1:8080 -email "love@example.com"
+`,
+	Run: executeCloseConnections,
+}
+
+func executeCloseConnections(cmd *base.Command, args []string) {
+	setSharedFlags(cmd)
+	email := cmd.Flag.String("email", "", "")
+	cmd.Flag.Parse(args)
+
+	r := &connectionService.CloseConnectionsRequest{
+		Email: *email,
+	}
+	for _, arg := range cmd.Flag.Args() {
+		id, err := strconv.ParseUint(arg, 10, 64)
+		if err != nil {
+			base.Fatalf("invalid connection ID %s: %s", arg, err)
+		}
+		r.Ids = append(r.Ids, id)
+	}
+	if len(r.Ids) == 0 && len(r.Email) == 0 {
+		base.Fatalf("no connection to close")
+	}
+
+	conn, ctx, close := dialAPIServer()
+	defer close()
+
+	client := connectionService.NewConnectionServiceClient(conn)
+	resp, err := client.CloseConnections(ctx, r)
+	if err != nil {
+		base.Fatalf("failed to close connections: %s", err)
+	}
+	showJSONResponse(resp)
+}
@@ -0,0 +1,49 @@
+package api
+
+import (
+	connectionService "github.com/xtls/xray-core/app/dispatcher/command"
+	"github.com/xtls/xray-core/main/commands/base"
+)
+
+var cmdListConnections = &base.Command{
+	CustomFlags: true,
+	UsageLine:   "{{.Exec}} api lsconns [--server=127.0.0.1:8080] [-inbound ''] [-email '']",
+	Short:       "List live connections",
+	Long: `
+List the live connections of Xray, with their inbound, user, source, destination,
+sniffed domain and protocol, outbound, start time and traffic.
+Arguments:
+	-s, -server 
+		The API server address. Default 127.0.0.1:8080
+	-t, -timeout
+		Timeout seconds to call API. Default 3
+	-inbound
+		Only list the connections of the inbound tag.
+	-email
+		Only list the connections of the user.
+Example:
+	{{.Exec}} {{.LongName}} --server=127.0.0.1:8080 -email "love@example.com"
+`,
+	Run: executeListConnections,
+}
+
+func executeListConnections(cmd *base.Command, args []string) {
+	setSharedFlags(cmd)
+	inbound := cmd.Flag.String("inbound", "", "")
+	email := cmd.Flag.String("email", "", "")
+	cmd.Flag.Parse(args)
+
+	conn, ctx, close := dialAPIServer()
+	defer close()
+
+	client := connectionService.NewConnectionServiceClient(conn)
+	r := &connectionService.ListConnectionsRequest{
+		InboundTag: *inbound,
+		Email:      *email,
+	}
+	resp, err := client.ListConnections(ctx, r)
+	if err != nil {
+		base.Fatalf("failed to list connections: %s", err)
+	}
+	showJSONResponse(resp)
+}
@@ -0,0 +1,39 @@
+package api
+
+import (
+	fakednsService "github.com/xtls/xray-core/app/dns/fakedns/command"
+	"github.com/xtls/xray-core/main/commands/base"
+)
+
+var cmdFlushFakeDNS = &base.Command{
+	CustomFlags: true,
+	UsageLine:   "{{.Exec}} api fdnsflush [--server=127.0.0.1:8080]",
+	Short:       "Flush fake DNS pools",
+	Long: `
+Forget all fake IPs assigned by fake DNS. Connections to the fake IPs
+assigned before can no longer be mapped back to their domains.
+Arguments:
+	-s, -server 
+		The API server address. Default 127.0.0.1:8080
+	-t, -timeout
+		Timeout seconds to call API. Default 3
+Example:
+	{{.Exec}} {{.LongName}} --server=127.0.0.1:8080
+`,
+	Run: executeFlushFakeDNS,
+}
+
+func executeFlushFakeDNS(cmd *base.Command, args []string) {
+	setSharedFlags(cmd)
+	cmd.Flag.Parse(args)
+
+	conn, ctx, close := dialAPIServer()
+	defer close()
+
+	client := fakednsService.NewFakeDNSServiceClient(conn)
+	resp, err := client.Flush(ctx, &fakednsService.FlushRequest{})
+	if err != nil {
+		base.Fatalf("failed to flush fake DNS: %s", err)
+	}
+	showJSONResponse(resp)
+}
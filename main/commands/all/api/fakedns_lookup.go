@@ -0,0 +1,58 @@
+package api
+
+import (
+	fakednsService "github.com/xtls/xray-core/app/dns/fakedns/command"
+	"github.com/xtls/xray-core/main/commands/base"
+)
+
+var cmdLookupFakeDNS = &base.Command{
+	CustomFlags: true,
+	UsageLine:   "{{.Exec}} api fdnslookup [--server=127.0.0.1:8080] [-ip ''] [-domain '']",
+	Short:       "Look up fake DNS mappings",
+	Long: `
+Look up the domain of a fake IP, or the fake IPs assigned to a domain.
+No fake IP is assigned by the lookup.
+Arguments:
+	-s, -server 
+		The API server address. Default 127.0.0.1:8080
+	-t, -timeout
+		Timeout seconds to call API. Default 3
+	-ip
+		The fake IP to look up the domain of.
+	-domain
+		The domain to look up the fake IPs of.
+Example:
+	{{.Exec}} {{.LongName}} --server=127.0.0.1:8080 -ip 198.18.0.1
+	{{.Exec}} {{.LongName}} --server=127.0.0.1:8080 -domain example.com
+`,
+	Run: executeLookupFakeDNS,
+}
+
+func executeLookupFakeDNS(cmd *base.Command, args []string) {
+	setSharedFlags(cmd)
+	ip := cmd.Flag.String("ip", "", "")
+	domain := cmd.Flag.String("domain", "", "")
+	cmd.Flag.Parse(args)
+
+	if (len(*ip) == 0) == (len(*domain) == 0) {
+		base.Fatalf("either -ip or -domain is required")
+	}
+
+	conn, ctx, close := dialAPIServer()
+	defer close()
+
+	client := fakednsService.NewFakeDNSServiceClient(conn)
+	if len(*ip) > 0 {
+		resp, err := client.LookupDomain(ctx, &fakednsService.LookupDomainRequest{Ip: *ip})
+		if err != nil {
+			base.Fatalf("failed to look up fake IP: %s", err)
+		}
+		showJSONResponse(resp)
+		return
+	}
+	resp, err := client.LookupIP(ctx, &fakednsService.LookupIPRequest{Domain: *domain})
+	if err != nil {
+		base.Fatalf("failed to look up domain: %s", err)
+	}
+	showJSONResponse(resp)
+}
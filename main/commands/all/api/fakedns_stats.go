@@ -0,0 +1,38 @@
+package api
+
+import (
+	fakednsService "github.com/xtls/xray-core/app/dns/fakedns/command"
+	"github.com/xtls/xray-core/main/commands/base"
+)
+
+var cmdFakeDNSStats = &base.Command{
+	CustomFlags: true,
+	UsageLine:   "{{.Exec}} api fdnsstats [--server=127.0.0.1:8080]",
+	Short:       "Get fake DNS pool usage",
+	Long: `
+Get the size and the number of assigned fake IPs of each fake DNS pool.
+Arguments:
+	-s, -server 
+		The API server address. Default 127.0.0.1:8080
+	-t, -timeout
+		Timeout seconds to call API. Default 3
+Example:
+	{{.Exec}} {{.LongName}} --server=127.0.0.1:8080
+`,
+	Run: executeFakeDNSStats,
+}
+
+func executeFakeDNSStats(cmd *base.Command, args []string) {
+	setSharedFlags(cmd)
+	cmd.Flag.Parse(args)
+
+	conn, ctx, close := dialAPIServer()
+	defer close()
+
+	client := fakednsService.NewFakeDNSServiceClient(conn)
+	resp, err := client.GetPoolStats(ctx, &fakednsService.GetPoolStatsRequest{})
+	if err != nil {
+		base.Fatalf("failed to get fake DNS pool stats: %s", err)
+	}
+	showJSONResponse(resp)
+}
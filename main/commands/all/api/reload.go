@@ -0,0 +1,57 @@
+package api
+
+import (
+	"fmt"
+
+	handlerService "github.com/xtls/xray-core/app/proxyman/command"
+	"github.com/xtls/xray-core/common/cmdarg"
+	"github.com/xtls/xray-core/core"
+	"github.com/xtls/xray-core/main/commands/base"
+)
+
+var cmdReloadConfig = &base.Command{
+	CustomFlags: true,
+	UsageLine:   "{{.Exec}} api reload [--server=127.0.0.1:8080] [c1.json] [c2.json]...",
+	Short:       "Reload config",
+	Long: `
+Reload the config of Xray. Only changed inbounds and outbounds are
+recreated, unchanged inbounds keep their existing connections.
+
+If no config is given, Xray reloads the config files it was started with.
+Arguments:
+	-s, -server 
+		The API server address. Default 127.0.0.1:8080
+	-t, -timeout
+		Timeout seconds to call API. Default 3
+Example:
+    {{.Exec}} {{.LongName}} --server=127.0.0.1:8080
+    {{.Exec}} {{.LongName}} --server=127.0.0.1:8080 c1.json c2.json
+`,
+	Run: executeReloadConfig,
+}
+
+func executeReloadConfig(cmd *base.Command, args []string) {
+	setSharedFlags(cmd)
+	cmd.Flag.Parse(args)
+	unnamedArgs := cmd.Flag.Args()
+
+	r := &handlerService.ReloadConfigRequest{}
+	if len(unnamedArgs) > 0 {
+		fmt.Println("loading:", unnamedArgs)
+		c, err := core.LoadConfig("auto", cmdarg.Arg(unnamedArgs))
+		if err != nil {
+			base.Fatalf("failed to load config: %s", err)
+		}
+		r.Config = c
+	}
+
+	conn, ctx, close := dialAPIServer()
+	defer close()
+
+	client := handlerService.NewHandlerServiceClient(conn)
+	resp, err := client.ReloadConfig(ctx, r)
+	if err != nil {
+		base.Fatalf("failed to reload config: %s", err)
+	}
+	showJSONResponse(resp)
+}
@@ -0,0 +1,88 @@
+package api
+
+import (
+	"fmt"
+
+	"github.com/xtls/xray-core/app/router"
+	routerService "github.com/xtls/xray-core/app/router/command"
+	"github.com/xtls/xray-core/infra/conf/serial"
+	"github.com/xtls/xray-core/main/commands/base"
+)
+
+var cmdAddRules = &base.Command{
+	CustomFlags: true,
+	UsageLine:   "{{.Exec}} api adrules [--server=127.0.0.1:8080] [-append] <c1.json> [c2.json]...",
+	Short:       "Add routing rules",
+	Long: `
+Add routing rules to Xray. Rules are read from the "routing" section of the
+configs. A rule with the same "ruleTag" as an existing one replaces it in place.
+Arguments:
+	-s, -server 
+		The API server address. Default 127.0.0.1:8080
+	-t, -timeout
+		Timeout seconds to call API. Default 3
+	-append
+		Append the rules to the end of the rule list, instead of
+		inserting them at the beginning.
+Example:
+    {{.Exec}} {{.LongName}} --server=127.0.0.1:8080 c1.json c2.json
+`,
+	Run: executeAddRules,
+}
+
+func executeAddRules(cmd *base.Command, args []string) {
+	setSharedFlags(cmd)
+	shouldAppend := cmd.Flag.Bool("append", false, "")
+	cmd.Flag.Parse(args)
+	unnamedArgs := cmd.Flag.Args()
+	if len(unnamedArgs) == 0 {
+		fmt.Println("reading from stdin:")
+		unnamedArgs = []string{"stdin:"}
+	}
+
+	rules := make([]*router.RoutingRule, 0)
+	for _, arg := range unnamedArgs {
+		r, err := loadArg(arg)
+		if err != nil {
+			base.Fatalf("failed to load %s: %s", arg, err)
+		}
+		conf, err := serial.DecodeJSONConfig(r)
+		if err != nil {
+			base.Fatalf("failed to decode %s: %s", arg, err)
+		}
+		if conf.RouterConfig == nil {
+			continue
+		}
+		rc, err := conf.RouterConfig.Build()
+		if err != nil {
+			base.Fatalf("failed to build conf: %s", err)
+		}
+		rules = append(rules, rc.Rule...)
+	}
+	if len(rules) == 0 {
+		base.Fatalf("no valid rule found")
+	}
+	if !*shouldAppend {
+		// Each rule is inserted at the beginning, so reverse them to keep their order.
+		for i, j := 0, len(rules)-1; i < j; i, j = i+1, j-1 {
+			rules[i], rules[j] = rules[j], rules[i]
+		}
+	}
+
+	conn, ctx, close := dialAPIServer()
+	defer close()
+
+	client := routerService.NewRoutingServiceClient(conn)
+	for _, rule := range rules {
+		fmt.Println("adding:", rule.RuleTag)
+		r := &routerService.AddRuleRequest{
+			Rule:         rule,
+			ShouldAppend: *shouldAppend,
+		}
+		resp, err := client.AddRule(ctx, r)
+		if err != nil {
+			base.Fatalf("failed to add rule: %s", err)
+		}
+		showJSONResponse(resp)
+	}
+}
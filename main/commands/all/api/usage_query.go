@@ -0,0 +1,48 @@
+package api
+
+import (
+	statsService "github.com/xtls/xray-core/app/stats/command"
+	"github.com/xtls/xray-core/main/commands/base"
+)
+
+var cmdQueryUsage = &base.Command{
+	CustomFlags: true,
+	UsageLine:   "{{.Exec}} api usagequery [--server=127.0.0.1:8080] [-pattern '']",
+	Short:       "Query usage of users",
+	Long: `
+Query the connections, source IPs, traffic and quota of users from Xray.
+Arguments:
+	-s, -server 
+		The API server address. Default 127.0.0.1:8080
+	-t, -timeout
+		Timeout seconds to call API. Default 3
+	-pattern
+		Pattern of the user emails.
+	-reset
+		Reset the traffic after fetching it, to restart the quota.
+Example:
+	{{.Exec}} {{.LongName}} --server=127.0.0.1:8080 -pattern "@example.com"
+`,
+	Run: executeQueryUsage,
+}
+
+func executeQueryUsage(cmd *base.Command, args []string) {
+	setSharedFlags(cmd)
+	pattern := cmd.Flag.String("pattern", "", "")
+	reset := cmd.Flag.Bool("reset", false, "")
+	cmd.Flag.Parse(args)
+
+	conn, ctx, close := dialAPIServer()
+	defer close()
+
+	client := statsService.NewStatsServiceClient(conn)
+	r := &statsService.QueryUserUsageRequest{
+		Pattern: *pattern,
+		Reset_:  *reset,
+	}
+	resp, err := client.QueryUserUsage(ctx, r)
+	if err != nil {
+		base.Fatalf("failed to query usage: %s", err)
+	}
+	showJSONResponse(resp)
+}
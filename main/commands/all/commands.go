@@ -12,7 +12,7 @@ func init() {
 	base.RootCommand.Commands = append(
 		base.RootCommand.Commands,
 		api.CmdAPI,
-		// cmdConvert,
+		cmdConvert,
 		tls.CmdTLS,
 		cmdUUID,
 		cmdX25519,
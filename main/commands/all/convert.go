@@ -1,32 +1,54 @@
 package all
 
 import (
-	"bytes"
 	"fmt"
-	"io"
-	"net/http"
-	"net/url"
 	"os"
+	"path/filepath"
+	"sort"
 	"strings"
-	"time"
 
-	"github.com/xtls/xray-core/common"
-	"github.com/xtls/xray-core/common/buf"
-	"github.com/xtls/xray-core/infra/conf"
+	"github.com/xtls/xray-core/common/cmdarg"
+	"github.com/xtls/xray-core/core"
 	"github.com/xtls/xray-core/infra/conf/serial"
 	"github.com/xtls/xray-core/main/commands/base"
+	"github.com/xtls/xray-core/main/confloader"
 	"google.golang.org/protobuf/proto"
 )
 
 var cmdConvert = &base.Command{
-	UsageLine: "{{.Exec}} convert [json file] [json file] ...",
-	Short:     "Convert multiple json config to protobuf",
+	CustomFlags: true,
+	UsageLine:   "{{.Exec}} convert [-c config.json]... [-confdir dir] [-format auto] [-type protobuf] [-o file] [config]...",
+	Short:       "Convert and merge configs between formats",
 	Long: `
-Convert multiple json config to protobuf.
+Convert and merge multiple config files into one.
+
+Config files are merged in order, the same way as "{{.Exec}} run" does.
+Files can be local paths, URLs, or "stdin:".
+
+Arguments:
+
+	-c, -config <file>
+		Config file to convert. Multiple assign is accepted.
+
+	-confdir <dir>
+		A dir with multiple config files, read after the -c files.
+
+	-format <format>
+		Format of the input files: auto, json, yaml or toml.
+		Default "auto", which detects the format by the file extension.
+
+	-type <type>
+		Type of the output: json, yaml, toml, protobuf, or text for the
+		compiled protobuf in text form. Default "protobuf".
+
+	-o <file>
+		Write the output to the file instead of stdout.
 
 Examples:
 
-    {{.Exec}} convert config.json c1.json c2.json <url>.json
+    {{.Exec}} {{.LongName}} -c config.json c1.json c2.json <url>.json > config.pb
+    {{.Exec}} {{.LongName}} -confdir /etc/xray -type yaml -o config.yaml
+    {{.Exec}} {{.LongName}} -c config.toml -type text
 	`,
 }
 
@@ -35,91 +57,125 @@ func init() {
 }
 
 func executeConvert(cmd *base.Command, args []string) {
-	unnamedArgs := cmdConvert.Flag.Args()
-	if len(unnamedArgs) < 1 {
-		base.Fatalf("empty config list")
-	}
-
-	conf := &conf.Config{}
-	for _, arg := range unnamedArgs {
-		fmt.Fprintf(os.Stderr, "Read config: %s", arg)
-		r, err := loadArg(arg)
-		common.Must(err)
-		c, err := serial.DecodeJSONConfig(r)
+	var files cmdarg.Arg
+	cmd.Flag.Var(&files, "config", "")
+	cmd.Flag.Var(&files, "c", "")
+	confDir := cmd.Flag.String("confdir", "", "")
+	format := cmd.Flag.String("format", "auto", "")
+	outType := cmd.Flag.String("type", "protobuf", "")
+	output := cmd.Flag.String("o", "", "")
+	cmd.Flag.Parse(args)
+
+	files = append(files, cmd.Flag.Args()...)
+	if len(*confDir) > 0 {
+		dirFiles, err := readConvertDir(*confDir)
 		if err != nil {
-			base.Fatalf(err.Error())
+			base.Fatalf("failed to read confdir: %s", err)
 		}
-		conf.Override(c, arg)
+		files = append(files, dirFiles...)
 	}
-
-	pbConfig, err := conf.Build()
-	if err != nil {
-		base.Fatalf(err.Error())
+	if len(files) == 0 {
+		base.Fatalf("empty config list")
 	}
 
-	bytesConfig, err := proto.Marshal(pbConfig)
+	var content []byte
+	var err error
+	switch t := strings.ToLower(*outType); t {
+	case "json", "yaml", "yml", "toml":
+		content, err = convertToFormat(files, *format, core.GetFormatByExtension(t))
+	case "protobuf", "pb", "text":
+		content, err = convertToProtobuf(files, *format, t == "text")
+	default:
+		base.Fatalf("unknown output type: %s", *outType)
+	}
 	if err != nil {
-		base.Fatalf("failed to marshal proto config: %s", err)
+		base.Fatalf("%s", err)
 	}
 
-	if _, err := os.Stdout.Write(bytesConfig); err != nil {
-		base.Fatalf("failed to write proto config: %s", err)
+	if len(*output) > 0 {
+		err = os.WriteFile(*output, content, 0o644)
+	} else {
+		_, err = os.Stdout.Write(content)
 	}
-}
-
-// loadArg loads one arg, maybe an remote url, or local file path
-func loadArg(arg string) (out io.Reader, err error) {
-	var data []byte
-	switch {
-	case strings.HasPrefix(arg, "http://"), strings.HasPrefix(arg, "https://"):
-		data, err = FetchHTTPContent(arg)
-
-	case arg == "stdin:":
-		data, err = io.ReadAll(os.Stdin)
-
-	default:
-		data, err = os.ReadFile(arg)
+	if err != nil {
+		base.Fatalf("failed to write output: %s", err)
 	}
+}
 
+// readConvertDir returns the config files in the dir, ordered by name.
+func readConvertDir(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
 	if err != nil {
-		return
+		return nil, err
+	}
+	var files []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		switch core.GetFormatByExtension(strings.TrimPrefix(filepath.Ext(entry.Name()), ".")) {
+		case "json", "yaml", "toml":
+			files = append(files, filepath.Join(dir, entry.Name()))
+		}
 	}
-	out = bytes.NewBuffer(data)
-	return
+	sort.Strings(files)
+	return files, nil
 }
 
-// FetchHTTPContent dials https for remote content
-func FetchHTTPContent(target string) ([]byte, error) {
-	parsedTarget, err := url.Parse(target)
-	if err != nil {
-		return nil, newError("invalid URL: ", target).Base(err)
+func getInputFormat(file string, format string) string {
+	if f := core.GetFormatByExtension(format); f != "" {
+		return f
+	}
+	if file == "stdin:" {
+		return "json"
 	}
+	return core.GetFormatByExtension(strings.TrimPrefix(filepath.Ext(file), "."))
+}
 
-	if s := strings.ToLower(parsedTarget.Scheme); s != "http" && s != "https" {
-		return nil, newError("invalid scheme: ", parsedTarget.Scheme)
+func convertToFormat(files []string, format string, outFormat string) ([]byte, error) {
+	var merged map[string]interface{}
+	for _, file := range files {
+		fmt.Fprintln(os.Stderr, "Read config:", file)
+		f := getInputFormat(file, format)
+		if f == "" || f == "protobuf" {
+			return nil, newError("unsupported format of ", file)
+		}
+		r, err := confloader.LoadConfig(file)
+		if err != nil {
+			return nil, newError("failed to read config: ", file).Base(err)
+		}
+		m, err := serial.DecodeConfigMap(r, f)
+		if err != nil {
+			return nil, newError("failed to decode config: ", file).Base(err)
+		}
+		if merged == nil {
+			merged = m
+			continue
+		}
+		serial.MergeConfigMap(merged, m, file)
 	}
+	return serial.EncodeConfigMap(merged, outFormat)
+}
 
-	client := &http.Client{
-		Timeout: 30 * time.Second,
+func convertToProtobuf(files []string, format string, text bool) ([]byte, error) {
+	formats := make([]string, len(files))
+	for i, file := range files {
+		fmt.Fprintln(os.Stderr, "Read config:", file)
+		formats[i] = getInputFormat(file, format)
+		if formats[i] == "" || formats[i] == "protobuf" {
+			return nil, newError("unsupported format of ", file)
+		}
 	}
-	resp, err := client.Do(&http.Request{
-		Method: "GET",
-		URL:    parsedTarget,
-		Close:  true,
-	})
+	pbConfig, err := serial.BuildConfig(files, formats)
 	if err != nil {
-		return nil, newError("failed to dial to ", target).Base(err)
+		return nil, newError("failed to build config").Base(err)
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != 200 {
-		return nil, newError("unexpected HTTP status code: ", resp.StatusCode)
+	if text {
+		return []byte(protoToText(pbConfig)), nil
 	}
-
-	content, err := buf.ReadAllToBytes(resp.Body)
+	content, err := proto.MarshalOptions{Deterministic: true}.Marshal(pbConfig)
 	if err != nil {
-		return nil, newError("failed to read HTTP response").Base(err)
+		return nil, newError("failed to marshal proto config").Base(err)
 	}
-
 	return content, nil
 }
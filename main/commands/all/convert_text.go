@@ -0,0 +1,85 @@
+package all
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	protov1 "github.com/golang/protobuf/proto"
+	"github.com/xtls/xray-core/common/serial"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// protoToText writes the message in the protobuf text format, with TypedMessages expanded into their instances
+// so that the output is readable and can be diffed.
+func protoToText(m proto.Message) string {
+	b := new(strings.Builder)
+	writeTextMessage(b, m.ProtoReflect(), "")
+	return b.String()
+}
+
+func writeTextMessage(b *strings.Builder, m protoreflect.Message, indent string) {
+	fields := m.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if !m.Has(fd) {
+			continue
+		}
+		v := m.Get(fd)
+		switch {
+		case fd.IsList():
+			list := v.List()
+			for j := 0; j < list.Len(); j++ {
+				writeTextField(b, fd, fd.Name(), list.Get(j), indent)
+			}
+		case fd.IsMap():
+			var keys []protoreflect.MapKey
+			v.Map().Range(func(k protoreflect.MapKey, _ protoreflect.Value) bool {
+				keys = append(keys, k)
+				return true
+			})
+			sort.Slice(keys, func(i, j int) bool {
+				return keys[i].String() < keys[j].String()
+			})
+			for _, k := range keys {
+				b.WriteString(indent + string(fd.Name()) + " {\n")
+				writeTextField(b, fd.MapKey(), "key", k.Value(), indent+"  ")
+				writeTextField(b, fd.MapValue(), "value", v.Map().Get(k), indent+"  ")
+				b.WriteString(indent + "}\n")
+			}
+		default:
+			writeTextField(b, fd, fd.Name(), v, indent)
+		}
+	}
+}
+
+func writeTextField(b *strings.Builder, fd protoreflect.FieldDescriptor, name protoreflect.Name, v protoreflect.Value, indent string) {
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		msg := v.Message()
+		if tm, ok := msg.Interface().(*serial.TypedMessage); ok {
+			if instance, err := tm.GetInstance(); err == nil {
+				b.WriteString(indent + string(name) + " { # " + tm.Type + "\n")
+				writeTextMessage(b, protov1.MessageV2(instance).ProtoReflect(), indent+"  ")
+				b.WriteString(indent + "}\n")
+				return
+			}
+		}
+		b.WriteString(indent + string(name) + " {\n")
+		writeTextMessage(b, msg, indent+"  ")
+		b.WriteString(indent + "}\n")
+	case protoreflect.EnumKind:
+		if ev := fd.Enum().Values().ByNumber(v.Enum()); ev != nil {
+			b.WriteString(fmt.Sprintf("%s%s: %s\n", indent, name, ev.Name()))
+		} else {
+			b.WriteString(fmt.Sprintf("%s%s: %d\n", indent, name, v.Enum()))
+		}
+	case protoreflect.StringKind:
+		b.WriteString(fmt.Sprintf("%s%s: %q\n", indent, name, v.String()))
+	case protoreflect.BytesKind:
+		b.WriteString(fmt.Sprintf("%s%s: %q\n", indent, name, v.Bytes()))
+	default:
+		b.WriteString(fmt.Sprintf("%s%s: %v\n", indent, name, v.Interface()))
+	}
+}
@@ -35,6 +35,9 @@ Default "auto".
 
 The -test flag tells Xray to test config files only, 
 without launching the server
+
+Sending SIGHUP to a running Xray reloads the config files. Unchanged
+inbounds keep their listeners and existing connections.
 	`,
 }
 
@@ -92,8 +95,15 @@ func executeRun(cmd *base.Command, args []string) {
 
 	{
 		osSignals := make(chan os.Signal, 1)
-		signal.Notify(osSignals, os.Interrupt, syscall.SIGTERM)
-		<-osSignals
+		signal.Notify(osSignals, os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
+		for sig := range osSignals {
+			if sig != syscall.SIGHUP {
+				break
+			}
+			if err := reloadXray(server); err != nil {
+				log.Println("Failed to reload:", err)
+			}
+		}
 	}
 }
 
@@ -123,7 +133,7 @@ func getRegepxByFormat() string {
 	}
 }
 
-func readConfDir(dirPath string) {
+func readConfDir(dirPath string, files *cmdarg.Arg) {
 	confs, err := os.ReadDir(dirPath)
 	if err != nil {
 		log.Fatalln(err)
@@ -134,22 +144,24 @@ func readConfDir(dirPath string) {
 			log.Fatalln(err)
 		}
 		if matched {
-			configFiles.Set(path.Join(dirPath, f.Name()))
+			files.Set(path.Join(dirPath, f.Name()))
 		}
 	}
 }
 
 func getConfigFilePath() cmdarg.Arg {
+	// Copy the files from flags, as the confdir is read again on reloading.
+	files := append(cmdarg.Arg{}, configFiles...)
 	if dirExists(configDir) {
 		log.Println("Using confdir from arg:", configDir)
-		readConfDir(configDir)
+		readConfDir(configDir, &files)
 	} else if envConfDir := platform.GetConfDirPath(); dirExists(envConfDir) {
 		log.Println("Using confdir from env:", envConfDir)
-		readConfDir(envConfDir)
+		readConfDir(envConfDir, &files)
 	}
 
-	if len(configFiles) > 0 {
-		return configFiles
+	if len(files) > 0 {
+		return files
 	}
 
 	if workingDir, err := os.Getwd(); err == nil {
@@ -177,7 +189,7 @@ func getConfigFormat() string {
 	return f
 }
 
-func startXray() (core.Server, error) {
+func loadConfig() (*core.Config, error) {
 	configFiles := getConfigFilePath()
 
 	// config, err := core.LoadConfig(getConfigFormat(), configFiles[0], configFiles)
@@ -186,11 +198,32 @@ func startXray() (core.Server, error) {
 	if err != nil {
 		return nil, newError("failed to load config files: [", configFiles.String(), "]").Base(err)
 	}
+	return c, nil
+}
+
+func startXray() (*core.Instance, error) {
+	c, err := loadConfig()
+	if err != nil {
+		return nil, err
+	}
 
 	server, err := core.New(c)
 	if err != nil {
 		return nil, newError("failed to create server").Base(err)
 	}
+	server.SetConfigLoader(loadConfig)
 
 	return server, nil
 }
+
+func reloadXray(server *core.Instance) error {
+	if err := server.Reload(nil); err != nil {
+		return newError("failed to reload server").Base(err)
+	}
+
+	// Explicitly triggering GC to remove garbage from config loading.
+	runtime.GC()
+	debug.FreeOSMemory()
+
+	return nil
+}
@@ -43,6 +43,7 @@ type Handler struct {
 	ownLinkVerifier ownLinkVerifier
 	server          net.Destination
 	timeout         time.Duration
+	blockTypes      map[dnsmessage.Type]bool
 }
 
 func (h *Handler) Init(config *Config, dnsClient dns.Client, policyManager policy.Manager) error {
@@ -56,6 +57,10 @@ func (h *Handler) Init(config *Config, dnsClient dns.Client, policyManager polic
 	if config.Server != nil {
 		h.server = config.Server.AsDestination()
 	}
+	h.blockTypes = make(map[dnsmessage.Type]bool, len(config.BlockTypes))
+	for _, t := range config.BlockTypes {
+		h.blockTypes[dnsmessage.Type(t)] = true
+	}
 	return nil
 }
 
@@ -63,7 +68,7 @@ func (h *Handler) isOwnLink(ctx context.Context) bool {
 	return h.ownLinkVerifier != nil && h.ownLinkVerifier.IsOwnLink(ctx)
 }
 
-func parseIPQuery(b []byte) (r bool, domain string, id uint16, qType dnsmessage.Type) {
+func parseQuery(b []byte) (r bool, header dnsmessage.Header, question dnsmessage.Question) {
 	var parser dnsmessage.Parser
 	header, err := parser.Start(b)
 	if err != nil {
@@ -71,18 +76,15 @@ func parseIPQuery(b []byte) (r bool, domain string, id uint16, qType dnsmessage.
 		return
 	}
 
-	id = header.ID
-	q, err := parser.Question()
+	question, err = parser.Question()
 	if err != nil {
 		newError("question").Base(err).WriteToLog()
 		return
 	}
-	qType = q.Type
-	if qType != dnsmessage.TypeA && qType != dnsmessage.TypeAAAA {
+	if question.Class != dnsmessage.ClassINET {
 		return
 	}
 
-	domain = q.Name.String()
 	r = true
 	return
 }
@@ -167,9 +169,9 @@ func (h *Handler) Process(ctx context.Context, link *transport.Link, d internet.
 			timer.Update()
 
 			if !h.isOwnLink(ctx) {
-				isIPQuery, domain, id, qType := parseIPQuery(b.Bytes())
-				if isIPQuery {
-					go h.handleIPQuery(id, qType, domain, writer)
+				isQuery, header, question := parseQuery(b.Bytes())
+				if isQuery {
+					go h.handleQuery(ctx, header, question, writer)
 					continue
 				}
 			}
@@ -206,86 +208,66 @@ func (h *Handler) Process(ctx context.Context, link *transport.Link, d internet.
 	return nil
 }
 
-func (h *Handler) handleIPQuery(id uint16, qType dnsmessage.Type, domain string, writer dns_proto.MessageWriter) {
-	var ips []net.IP
+func (h *Handler) handleQuery(ctx context.Context, header dnsmessage.Header, question dnsmessage.Question, writer dns_proto.MessageWriter) {
+	b, err := answerQuery(ctx, h.client, h.blockTypes, header, question)
+	if err != nil {
+		newError("failed to answer query").Base(err).WriteToLog(session.ExportIDToError(ctx))
+		return
+	}
+
+	if err := writer.WriteMessage(b); err != nil {
+		newError("write answer").Base(err).WriteToLog()
+	}
+}
+
+// answerQuery resolves the question with the DNS client, and returns the packed response to the query. The record
+// types in blockTypes are answered with no records.
+func answerQuery(ctx context.Context, client dns.Client, blockTypes map[dnsmessage.Type]bool, header dnsmessage.Header, question dnsmessage.Question) (*buf.Buffer, error) {
+	var answers []dnsmessage.Resource
 	var err error
 
-	var ttl uint32 = 600
-
-	switch qType {
-	case dnsmessage.TypeA:
-		ips, err = h.client.LookupIP(domain, dns.IPOption{
-			IPv4Enable: true,
-			IPv6Enable: false,
-			FakeEnable: true,
-		})
-	case dnsmessage.TypeAAAA:
-		ips, err = h.client.LookupIP(domain, dns.IPOption{
-			IPv4Enable: false,
-			IPv6Enable: true,
-			FakeEnable: true,
-		})
+	domain := question.Name.String()
+	if blockTypes[question.Type] {
+		newError("blocked ", question.Type, " query for ", domain).AtDebug().WriteToLog(session.ExportIDToError(ctx))
+	} else {
+		answers, err = client.Query(ctx, domain, question.Type)
 	}
 
 	rcode := dns.RCodeFromError(err)
-	if rcode == 0 && len(ips) == 0 && err != dns.ErrEmptyResponse {
-		newError("ip query").Base(err).WriteToLog()
-		return
+	if rcode == 0 && len(answers) == 0 && err != nil && err != dns.ErrEmptyResponse {
+		return nil, newError("failed to query ", question.Type, " for ", domain).Base(err)
 	}
+	return packResponse(header, question, dnsmessage.RCode(rcode), answers)
+}
 
-	switch qType {
-	case dnsmessage.TypeA:
-		for i, ip := range ips {
-			ips[i] = ip.To4()
-		}
-	case dnsmessage.TypeAAAA:
-		for i, ip := range ips {
-			ips[i] = ip.To16()
-		}
+// packResponse packs the response to the query with the given answers.
+func packResponse(header dnsmessage.Header, question dnsmessage.Question, rcode dnsmessage.RCode, answers []dnsmessage.Resource) (*buf.Buffer, error) {
+	msg := dnsmessage.Message{
+		Header: dnsmessage.Header{
+			ID:                 header.ID,
+			RCode:              rcode,
+			RecursionAvailable: true,
+			RecursionDesired:   header.RecursionDesired,
+			Response:           true,
+			Authoritative:      true,
+		},
+		Questions: []dnsmessage.Question{question},
+		Answers:   answers,
 	}
 
 	b := buf.New()
 	rawBytes := b.Extend(buf.Size)
-	builder := dnsmessage.NewBuilder(rawBytes[:0], dnsmessage.Header{
-		ID:                 id,
-		RCode:              dnsmessage.RCode(rcode),
-		RecursionAvailable: true,
-		RecursionDesired:   true,
-		Response:           true,
-		Authoritative:      true,
-	})
-	builder.EnableCompression()
-	common.Must(builder.StartQuestions())
-	common.Must(builder.Question(dnsmessage.Question{
-		Name:  dnsmessage.MustNewName(domain),
-		Class: dnsmessage.ClassINET,
-		Type:  qType,
-	}))
-	common.Must(builder.StartAnswers())
-
-	rHeader := dnsmessage.ResourceHeader{Name: dnsmessage.MustNewName(domain), Class: dnsmessage.ClassINET, TTL: ttl}
-	for _, ip := range ips {
-		if len(ip) == net.IPv4len {
-			var r dnsmessage.AResource
-			copy(r.A[:], ip)
-			common.Must(builder.AResource(rHeader, r))
-		} else {
-			var r dnsmessage.AAAAResource
-			copy(r.AAAA[:], ip)
-			common.Must(builder.AAAAResource(rHeader, r))
-		}
-	}
-	msgBytes, err := builder.Finish()
+	msgBytes, err := msg.AppendPack(rawBytes[:0])
 	if err != nil {
-		newError("pack message").Base(err).WriteToLog()
 		b.Release()
-		return
+		return nil, newError("pack message").Base(err)
 	}
-	b.Resize(0, int32(len(msgBytes)))
-
-	if err := writer.WriteMessage(b); err != nil {
-		newError("write IP answer").Base(err).WriteToLog()
+	if len(msgBytes) > int(buf.Size) {
+		b.Release()
+		return nil, newError("answer of ", question.Type, " for ", question.Name.String(), " is too large")
 	}
+	b.Resize(0, int32(len(msgBytes)))
+	return b, nil
 }
 
 type outboundConn struct {
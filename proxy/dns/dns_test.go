@@ -19,6 +19,7 @@ import (
 	"github.com/xtls/xray-core/core"
 	dns_proxy "github.com/xtls/xray-core/proxy/dns"
 	"github.com/xtls/xray-core/proxy/dokodemo"
+	"github.com/xtls/xray-core/proxy/freedom"
 	"github.com/xtls/xray-core/testing/servers/tcp"
 	"github.com/xtls/xray-core/testing/servers/udp"
 )
@@ -68,6 +69,25 @@ func (*staticHandler) ServeDNS(w dns.ResponseWriter, r *dns.Msg) {
 
 		case q.Name == "notexist.google.com." && q.Qtype == dns.TypeAAAA:
 			ans.MsgHdr.Rcode = dns.RcodeNameError
+
+		case q.Name == "google.com." && q.Qtype == dns.TypeMX:
+			rr, err := dns.NewRR("google.com. IN MX 10 smtp.google.com.")
+			common.Must(err)
+			ans.Answer = append(ans.Answer, rr)
+
+		case q.Name == "." && q.Qtype == dns.TypeNS:
+			rr, err := dns.NewRR(". IN NS a.root-servers.net.")
+			common.Must(err)
+			ans.Answer = append(ans.Answer, rr)
+
+		case q.Name == "google.com." && q.Qtype == 65:
+			rr, err := dns.NewRR("google.com. IN TXT \"not blocked\"")
+			common.Must(err)
+			ans.Answer = append(ans.Answer, rr)
+
+		// no answer, so that the query times out
+		case q.Name == "timeout.example.com.":
+			return
 		}
 	}
 	w.WriteMsg(ans)
@@ -123,7 +143,9 @@ func TestUDPDNSTunnel(t *testing.T) {
 		},
 		Outbound: []*core.OutboundHandlerConfig{
 			{
-				ProxySettings: serial.ToTypedMessage(&dns_proxy.Config{}),
+				ProxySettings: serial.ToTypedMessage(&dns_proxy.Config{
+					BlockTypes: []uint32{65},
+				}),
 			},
 		},
 	}
@@ -189,6 +211,81 @@ func TestUDPDNSTunnel(t *testing.T) {
 			t.Error("expected NameError, but got ", in.Rcode)
 		}
 	}
+
+	{
+		m1 := new(dns.Msg)
+		m1.Id = dns.Id()
+		m1.RecursionDesired = true
+		m1.Question = make([]dns.Question, 1)
+		m1.Question[0] = dns.Question{Name: "google.com.", Qtype: dns.TypeMX, Qclass: dns.ClassINET}
+
+		c := new(dns.Client)
+		in, _, err := c.Exchange(m1, "127.0.0.1:"+strconv.Itoa(int(serverPort)))
+		common.Must(err)
+
+		if len(in.Answer) != 1 {
+			t.Fatal("len(answer): ", len(in.Answer))
+		}
+
+		rr, ok := in.Answer[0].(*dns.MX)
+		if !ok {
+			t.Fatal("not MX record")
+		}
+		if rr.Preference != 10 || rr.Mx != "smtp.google.com." {
+			t.Error("unexpected MX record: ", rr)
+		}
+	}
+
+	{
+		m1 := new(dns.Msg)
+		m1.Id = dns.Id()
+		m1.RecursionDesired = true
+		m1.Question = make([]dns.Question, 1)
+		m1.Question[0] = dns.Question{Name: "google.com.", Qtype: 65, Qclass: dns.ClassINET}
+
+		c := new(dns.Client)
+		in, _, err := c.Exchange(m1, "127.0.0.1:"+strconv.Itoa(int(serverPort)))
+		common.Must(err)
+
+		if in.Rcode != dns.RcodeSuccess || len(in.Answer) != 0 {
+			t.Error("expected blocked query to be answered with no records, but got ", in)
+		}
+	}
+
+	{
+		m1 := new(dns.Msg)
+		m1.Id = dns.Id()
+		m1.RecursionDesired = true
+		m1.Question = make([]dns.Question, 1)
+		m1.Question[0] = dns.Question{Name: ".", Qtype: dns.TypeNS, Qclass: dns.ClassINET}
+
+		c := new(dns.Client)
+		in, _, err := c.Exchange(m1, "127.0.0.1:"+strconv.Itoa(int(serverPort)))
+		common.Must(err)
+
+		if len(in.Answer) != 1 {
+			t.Fatal("len(answer): ", len(in.Answer))
+		}
+		if rr, ok := in.Answer[0].(*dns.NS); !ok || rr.Ns != "a.root-servers.net." {
+			t.Error("unexpected root NS record: ", in.Answer[0])
+		}
+	}
+
+	{
+		m1 := new(dns.Msg)
+		m1.Id = dns.Id()
+		m1.RecursionDesired = true
+		m1.Question = make([]dns.Question, 1)
+		m1.Question[0] = dns.Question{Name: ".", Qtype: dns.TypeA, Qclass: dns.ClassINET}
+
+		c := new(dns.Client)
+		in, _, err := c.Exchange(m1, "127.0.0.1:"+strconv.Itoa(int(serverPort)))
+		common.Must(err)
+
+		if in.Rcode != dns.RcodeSuccess || len(in.Answer) != 0 {
+			t.Error("expected root A query to be answered with no records, but got ", in)
+		}
+	}
 }
 
 func TestTCPDNSTunnel(t *testing.T) {
@@ -366,3 +463,131 @@ func TestUDP2TCPDNSTunnel(t *testing.T) {
 		t.Error(r)
 	}
 }
+
+func TestDNSInbound(t *testing.T) {
+	port := udp.PickPort()
+
+	dnsServer := dns.Server{
+		Addr:    "127.0.0.1:" + port.String(),
+		Net:     "udp",
+		Handler: &staticHandler{},
+		UDPSize: 1200,
+	}
+	defer dnsServer.Shutdown()
+
+	go dnsServer.ListenAndServe()
+	time.Sleep(time.Second)
+
+	serverPort := tcp.PickPort()
+	config := &core.Config{
+		App: []*serial.TypedMessage{
+			serial.ToTypedMessage(&dnsapp.Config{
+				NameServers: []*net.Endpoint{
+					{
+						Network: net.Network_UDP,
+						Address: &net.IPOrDomain{
+							Address: &net.IPOrDomain_Ip{
+								Ip: []byte{127, 0, 0, 1},
+							},
+						},
+						Port: uint32(port),
+					},
+				},
+				StaticHosts: []*dnsapp.Config_HostMapping{
+					{
+						Type:   dnsapp.DomainMatchingType_Full,
+						Domain: "static.example.com",
+						Ip:     [][]byte{{10, 0, 0, 1}},
+					},
+				},
+			}),
+			serial.ToTypedMessage(&dispatcher.Config{}),
+			serial.ToTypedMessage(&proxyman.OutboundConfig{}),
+			serial.ToTypedMessage(&proxyman.InboundConfig{}),
+			serial.ToTypedMessage(&policy.Config{}),
+		},
+		Inbound: []*core.InboundHandlerConfig{
+			{
+				ProxySettings: serial.ToTypedMessage(&dns_proxy.ServerConfig{
+					BlockTypes: []uint32{65},
+				}),
+				ReceiverSettings: serial.ToTypedMessage(&proxyman.ReceiverConfig{
+					PortList: &net.PortList{Range: []*net.PortRange{net.SinglePortRange(serverPort)}},
+					Listen:   net.NewIPOrDomain(net.LocalHostIP),
+				}),
+			},
+		},
+		Outbound: []*core.OutboundHandlerConfig{
+			{
+				ProxySettings: serial.ToTypedMessage(&freedom.Config{}),
+			},
+		},
+	}
+
+	v, err := core.New(config)
+	common.Must(err)
+	common.Must(v.Start())
+	defer v.Close()
+
+	for _, network := range []string{"udp", "tcp"} {
+		c := &dns.Client{Net: network, Timeout: 10 * time.Second}
+
+		{
+			m1 := new(dns.Msg)
+			m1.SetQuestion("google.com.", dns.TypeA)
+			in, _, err := c.Exchange(m1, "127.0.0.1:"+strconv.Itoa(int(serverPort)))
+			common.Must(err)
+
+			if len(in.Answer) != 1 {
+				t.Fatal("len(answer): ", len(in.Answer))
+			}
+			rr, ok := in.Answer[0].(*dns.A)
+			if !ok {
+				t.Fatal("not A record")
+			}
+			if r := cmp.Diff(rr.A[:], net.IP{8, 8, 8, 8}); r != "" {
+				t.Error(r)
+			}
+		}
+
+		{
+			m1 := new(dns.Msg)
+			m1.SetQuestion("static.example.com.", dns.TypeA)
+			in, _, err := c.Exchange(m1, "127.0.0.1:"+strconv.Itoa(int(serverPort)))
+			common.Must(err)
+
+			if len(in.Answer) != 1 {
+				t.Fatal("len(answer): ", len(in.Answer))
+			}
+			rr, ok := in.Answer[0].(*dns.A)
+			if !ok {
+				t.Fatal("not A record")
+			}
+			if r := cmp.Diff(rr.A[:], net.IP{10, 0, 0, 1}); r != "" {
+				t.Error(r)
+			}
+		}
+
+		{
+			m1 := new(dns.Msg)
+			m1.SetQuestion("google.com.", 65)
+			in, _, err := c.Exchange(m1, "127.0.0.1:"+strconv.Itoa(int(serverPort)))
+			common.Must(err)
+
+			if len(in.Answer) != 0 {
+				t.Error("expected blocked type, but got ", in.Answer)
+			}
+		}
+
+		{
+			m1 := new(dns.Msg)
+			m1.SetQuestion("timeout.example.com.", dns.TypeA)
+			in, _, err := c.Exchange(m1, "127.0.0.1:"+strconv.Itoa(int(serverPort)))
+			common.Must(err)
+
+			if in.Rcode != dns.RcodeServerFailure {
+				t.Error("expected SERVFAIL for failed query, but got ", dns.RcodeToString[in.Rcode])
+			}
+		}
+	}
+}
@@ -0,0 +1,332 @@
+package dns
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/common/buf"
+	"github.com/xtls/xray-core/common/log"
+	"github.com/xtls/xray-core/common/net"
+	"github.com/xtls/xray-core/common/protocol"
+	dns_proto "github.com/xtls/xray-core/common/protocol/dns"
+	"github.com/xtls/xray-core/common/session"
+	"github.com/xtls/xray-core/common/signal"
+	"github.com/xtls/xray-core/common/task"
+	"github.com/xtls/xray-core/core"
+	"github.com/xtls/xray-core/features/dns"
+	"github.com/xtls/xray-core/features/policy"
+	"github.com/xtls/xray-core/features/routing"
+	"github.com/xtls/xray-core/transport/internet/stat"
+	"golang.org/x/net/dns/dnsmessage"
+	"golang.org/x/net/http2"
+)
+
+func init() {
+	common.Must(common.RegisterConfig((*ServerConfig)(nil), func(ctx context.Context, config interface{}) (interface{}, error) {
+		s := new(Server)
+		if err := core.RequireFeatures(ctx, func(dnsClient dns.Client, policyManager policy.Manager) error {
+			return s.Init(config.(*ServerConfig), dnsClient, policyManager)
+		}); err != nil {
+			return nil, err
+		}
+		return s, nil
+	}))
+}
+
+// Server is an inbound handler that answers DNS queries with the DNS client. It serves DNS over UDP and TCP, DNS over
+// TLS with TLS stream settings, and DNS over HTTPS if DohPath is set.
+type Server struct {
+	config        *ServerConfig
+	client        dns.Client
+	policyManager policy.Manager
+	blockTypes    map[dnsmessage.Type]bool
+}
+
+// Init initializes the Server with necessary parameters.
+func (s *Server) Init(config *ServerConfig, dnsClient dns.Client, policyManager policy.Manager) error {
+	s.config = config
+	s.client = dnsClient
+	s.policyManager = policyManager
+	s.blockTypes = make(map[dnsmessage.Type]bool, len(config.BlockTypes))
+	for _, t := range config.BlockTypes {
+		s.blockTypes[dnsmessage.Type(t)] = true
+	}
+	return nil
+}
+
+// Network implements proxy.Inbound.
+func (s *Server) Network() []net.Network {
+	if len(s.config.Networks) == 0 {
+		return []net.Network{net.Network_TCP, net.Network_UDP}
+	}
+	return s.config.Networks
+}
+
+// Process implements proxy.Inbound.
+func (s *Server) Process(ctx context.Context, network net.Network, conn stat.Connection, dispatcher routing.Dispatcher) error {
+	newError("processing DNS connection from: ", conn.RemoteAddr()).AtDebug().WriteToLog(session.ExportIDToError(ctx))
+
+	if inbound := session.InboundFromContext(ctx); inbound != nil {
+		inbound.User = &protocol.MemoryUser{
+			Level: s.config.UserLevel,
+		}
+	}
+	plcy := s.policyManager.ForLevel(s.config.UserLevel)
+
+	if network == net.Network_TCP && len(s.config.DohPath) > 0 {
+		return s.serveHTTP(ctx, conn, plcy)
+	}
+
+	var reader dns_proto.MessageReader
+	var writer dns_proto.MessageWriter
+	if network == net.Network_TCP {
+		reader = dns_proto.NewTCPReader(buf.NewReader(conn))
+		writer = &dns_proto.TCPWriter{
+			Writer: buf.NewWriter(conn),
+		}
+	} else {
+		reader = &dns_proto.UDPReader{
+			Reader: buf.NewPacketReader(conn),
+		}
+		writer = &dns_proto.UDPWriter{
+			Writer: &buf.SequentialWriter{Writer: conn},
+		}
+	}
+	writer = &lockedWriter{writer: writer}
+
+	ctx, cancel := context.WithCancel(ctx)
+	timer := signal.CancelAfterInactivity(ctx, cancel, plcy.Timeouts.ConnectionIdle)
+
+	var pending sync.WaitGroup
+	request := func() error {
+		// the answers to the queries in flight are written before the connection is closed
+		defer pending.Wait()
+
+		for {
+			b, err := reader.ReadMessage()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			timer.Update()
+
+			isQuery, header, question := parseQuery(b.Bytes())
+			b.Release()
+			if !isQuery {
+				continue
+			}
+
+			pending.Add(1)
+			go func() {
+				defer pending.Done()
+
+				answer := s.answer(ctx, conn.RemoteAddr(), header, question)
+				if answer == nil {
+					return
+				}
+				if err := writer.WriteMessage(answer); err != nil {
+					newError("failed to write DNS answer").Base(err).WriteToLog(session.ExportIDToError(ctx))
+					return
+				}
+				timer.Update()
+			}()
+		}
+	}
+
+	if err := task.Run(ctx, request); err != nil {
+		return newError("connection ends").Base(err)
+	}
+	return nil
+}
+
+// answer answers the query, and records it in the access log. A failed query is answered with SERVFAIL, so that the
+// client doesn't wait for its timeout. It returns nil only if the response fails to be packed.
+func (s *Server) answer(ctx context.Context, source net.Addr, header dnsmessage.Header, question dnsmessage.Question) *buf.Buffer {
+	msg := &log.AccessMessage{
+		From:   source,
+		To:     question.Name.String() + " " + strings.TrimPrefix(question.Type.String(), "Type"),
+		Status: log.AccessAccepted,
+		Reason: "",
+	}
+	if inbound := session.InboundFromContext(ctx); inbound != nil {
+		msg.InboundTag = inbound.Tag
+	}
+	if s.blockTypes[question.Type] {
+		msg.Status = log.AccessRejected
+		msg.Reason = "blocked"
+	}
+
+	b, err := answerQuery(ctx, s.client, s.blockTypes, header, question)
+	if err != nil {
+		msg.Status = log.AccessRejected
+		msg.Reason = err
+		newError("failed to answer DNS query").Base(err).WriteToLog(session.ExportIDToError(ctx))
+		b, err = packResponse(header, question, dnsmessage.RCodeServerFailure, nil)
+		if err != nil {
+			newError("failed to pack DNS response").Base(err).WriteToLog(session.ExportIDToError(ctx))
+		}
+	}
+	log.Record(msg)
+	return b
+}
+
+// serveHTTP serves DNS over HTTPS (RFC 8484) on the connection, in HTTP/2 if the client starts with the HTTP/2
+// connection preface, or in HTTP/1.1 otherwise.
+func (s *Server) serveHTTP(ctx context.Context, conn stat.Connection, plcy policy.Session) error {
+	handler := &dohHandler{
+		server: s,
+		ctx:    ctx,
+		source: conn.RemoteAddr(),
+	}
+
+	reader := bufio.NewReader(conn)
+	c := &bufferedConn{Conn: conn, reader: reader}
+	preface, err := reader.Peek(3)
+	if err != nil {
+		return newError("failed to read HTTP request").Base(err)
+	}
+
+	if string(preface) == http2.ClientPreface[:3] {
+		server := &http2.Server{
+			IdleTimeout: plcy.Timeouts.ConnectionIdle,
+		}
+		server.ServeConn(c, &http2.ServeConnOpts{
+			Context: ctx,
+			Handler: handler,
+		})
+		return nil
+	}
+
+	listener := newConnListener(c)
+	server := &http.Server{
+		Handler:     handler,
+		IdleTimeout: plcy.Timeouts.ConnectionIdle,
+		ConnState: func(_ net.Conn, state http.ConnState) {
+			if state == http.StateClosed || state == http.StateHijacked {
+				listener.Close()
+			}
+		},
+	}
+	server.Serve(listener)
+	return nil
+}
+
+type dohHandler struct {
+	server *Server
+	ctx    context.Context
+	source net.Addr
+}
+
+const dohMaxQuerySize = 65535
+
+// ServeHTTP implements http.Handler.
+func (h *dohHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != h.server.config.DohPath {
+		http.NotFound(w, r)
+		return
+	}
+
+	var query []byte
+	var err error
+	switch r.Method {
+	case http.MethodGet:
+		query, err = base64.RawURLEncoding.DecodeString(strings.TrimRight(r.URL.Query().Get("dns"), "="))
+	case http.MethodPost:
+		if r.Header.Get("Content-Type") != "application/dns-message" {
+			http.Error(w, "unsupported content type", http.StatusUnsupportedMediaType)
+			return
+		}
+		query, err = io.ReadAll(io.LimitReader(r.Body, dohMaxQuerySize))
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if err != nil {
+		http.Error(w, "invalid DNS query", http.StatusBadRequest)
+		return
+	}
+
+	isQuery, header, question := parseQuery(query)
+	if !isQuery {
+		http.Error(w, "invalid DNS query", http.StatusBadRequest)
+		return
+	}
+
+	answer := h.server.answer(h.ctx, h.source, header, question)
+	if answer == nil {
+		http.Error(w, "failed to resolve", http.StatusBadGateway)
+		return
+	}
+	defer answer.Release()
+
+	w.Header().Set("Content-Type", "application/dns-message")
+	w.Write(answer.Bytes())
+}
+
+type lockedWriter struct {
+	access sync.Mutex
+	writer dns_proto.MessageWriter
+}
+
+func (w *lockedWriter) WriteMessage(b *buf.Buffer) error {
+	w.access.Lock()
+	defer w.access.Unlock()
+
+	return w.writer.WriteMessage(b)
+}
+
+// bufferedConn is a connection whose data is read through the reader.
+type bufferedConn struct {
+	net.Conn
+	reader *bufio.Reader
+}
+
+func (c *bufferedConn) Read(b []byte) (int, error) {
+	return c.reader.Read(b)
+}
+
+// connListener is a net.Listener that accepts only the connection, and blocks afterwards until closed.
+type connListener struct {
+	conns chan net.Conn
+	done  chan struct{}
+	once  sync.Once
+	addr  net.Addr
+}
+
+func newConnListener(conn net.Conn) *connListener {
+	l := &connListener{
+		conns: make(chan net.Conn, 1),
+		done:  make(chan struct{}),
+		addr:  conn.LocalAddr(),
+	}
+	l.conns <- conn
+	return l
+}
+
+func (l *connListener) Accept() (net.Conn, error) {
+	select {
+	case conn := <-l.conns:
+		return conn, nil
+	case <-l.done:
+		return nil, io.EOF
+	}
+}
+
+func (l *connListener) Close() error {
+	l.once.Do(func() {
+		close(l.done)
+	})
+	return nil
+}
+
+func (l *connListener) Addr() net.Addr {
+	return l.addr
+}
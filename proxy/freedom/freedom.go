@@ -4,6 +4,9 @@ package freedom
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"io"
 	"time"
 
 	"github.com/xtls/xray-core/common"
@@ -158,9 +161,23 @@ func (h *Handler) Process(ctx context.Context, link *transport.Link, dialer inte
 
 		var writer buf.Writer
 		if destination.Network == net.Network_TCP {
-			writer = buf.NewWriter(conn)
+			if h.config.Fragment != nil {
+				writer = buf.NewWriter(&FragmentWriter{
+					fragment: h.config.Fragment,
+					writer:   conn,
+				})
+			} else {
+				writer = buf.NewWriter(conn)
+			}
 		} else {
 			writer = NewPacketWriter(conn, h, ctx, UDPOverride)
+			if len(h.config.Noises) > 0 {
+				writer = &NoisePacketWriter{
+					Writer:     writer,
+					noises:     h.config.Noises,
+					firstWrite: true,
+				}
+			}
 		}
 
 		if err := buf.Copy(input, writer, buf.UpdateActivity(timer)); err != nil {
@@ -309,3 +326,134 @@ func (w *PacketWriter) WriteMultiBuffer(mb buf.MultiBuffer) error {
 	}
 	return nil
 }
+
+// NoisePacketWriter sends the configured noises before the first packet.
+type NoisePacketWriter struct {
+	buf.Writer
+	noises     []*Noise
+	firstWrite bool
+}
+
+// WriteMultiBuffer implements buf.Writer.
+func (w *NoisePacketWriter) WriteMultiBuffer(mb buf.MultiBuffer) error {
+	if w.firstWrite && !mb.IsEmpty() {
+		w.firstWrite = false
+		// Noises are sent to the destination of the first packet.
+		dest := mb[0].UDP
+		for _, n := range w.noises {
+			b := buf.New()
+			if len(n.Packet) > 0 {
+				b.Write(n.Packet)
+			} else {
+				length := int32(buf.Size)
+				if l := randBetween(n.LengthMin, n.LengthMax); l < buf.Size {
+					length = int32(l)
+				}
+				// an empty datagram is not sent for a noise without packet or length
+				if length == 0 {
+					b.Release()
+					continue
+				}
+				if _, err := b.ReadFullFrom(rand.Reader, length); err != nil {
+					b.Release()
+					buf.ReleaseMulti(mb)
+					return err
+				}
+			}
+			if dest != nil {
+				udp := *dest
+				b.UDP = &udp
+			}
+			if err := w.Writer.WriteMultiBuffer(buf.MultiBuffer{b}); err != nil {
+				buf.ReleaseMulti(mb)
+				return newError("failed to send noise").Base(err)
+			}
+			time.Sleep(time.Duration(randBetween(n.DelayMin, n.DelayMax)) * time.Millisecond)
+		}
+	}
+	return w.Writer.WriteMultiBuffer(mb)
+}
+
+// FragmentWriter splits the first packets written to the underlying writer.
+type FragmentWriter struct {
+	fragment *Fragment
+	writer   io.Writer
+	count    uint64
+}
+
+// Write implements io.Writer.
+func (f *FragmentWriter) Write(b []byte) (int, error) {
+	f.count++
+
+	if f.fragment.PacketsFrom == 0 && f.fragment.PacketsTo == 1 {
+		if f.count != 1 || len(b) <= 5 || b[0] != 0x16 || b[5] != 0x01 {
+			return f.writer.Write(b)
+		}
+		recordLen := 5 + int(binary.BigEndian.Uint16(b[3:5]))
+		if len(b) < recordLen {
+			return f.writer.Write(b)
+		}
+		data := b[5:recordLen]
+		for from := 0; from < len(data); {
+			to := from + f.fragmentLength()
+			if to > len(data) {
+				to = len(data)
+			}
+			record := make([]byte, 5+to-from)
+			copy(record, b[:3])
+			binary.BigEndian.PutUint16(record[3:5], uint16(to-from))
+			copy(record[5:], data[from:to])
+			if _, err := f.writer.Write(record); err != nil {
+				return 0, err
+			}
+			from = to
+			if from < len(data) {
+				f.sleep()
+			}
+		}
+		if len(b) > recordLen {
+			n, err := f.writer.Write(b[recordLen:])
+			return recordLen + n, err
+		}
+		return len(b), nil
+	}
+
+	if f.count < f.fragment.PacketsFrom || f.count > f.fragment.PacketsTo {
+		return f.writer.Write(b)
+	}
+	for from := 0; from < len(b); {
+		to := from + f.fragmentLength()
+		if to > len(b) {
+			to = len(b)
+		}
+		n, err := f.writer.Write(b[from:to])
+		from += n
+		if err != nil {
+			return from, err
+		}
+		if from < len(b) {
+			f.sleep()
+		}
+	}
+	return len(b), nil
+}
+
+func (f *FragmentWriter) fragmentLength() int {
+	length := int(randBetween(f.fragment.LengthMin, f.fragment.LengthMax))
+	if length <= 0 {
+		return 1
+	}
+	return length
+}
+
+func (f *FragmentWriter) sleep() {
+	time.Sleep(time.Duration(randBetween(f.fragment.IntervalMin, f.fragment.IntervalMax)) * time.Millisecond)
+}
+
+// randBetween returns a random number in [left, right].
+func randBetween(left, right uint64) uint64 {
+	if right <= left {
+		return left
+	}
+	return left + uint64(dice.Roll(int(right-left+1)))
+}
@@ -0,0 +1,136 @@
+package freedom
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/common/buf"
+	"github.com/xtls/xray-core/common/net"
+)
+
+type recordWriter struct {
+	writes [][]byte
+}
+
+func (w *recordWriter) Write(b []byte) (int, error) {
+	w.writes = append(w.writes, append([]byte(nil), b...))
+	return len(b), nil
+}
+
+func TestFragmentTLSHello(t *testing.T) {
+	hello := make([]byte, 5+100)
+	copy(hello, []byte{0x16, 0x03, 0x01})
+	binary.BigEndian.PutUint16(hello[3:5], 100)
+	hello[5] = 0x01
+	for i := 6; i < len(hello); i++ {
+		hello[i] = byte(i)
+	}
+
+	w := new(recordWriter)
+	f := &FragmentWriter{
+		fragment: &Fragment{PacketsFrom: 0, PacketsTo: 1, LengthMin: 30, LengthMax: 30},
+		writer:   w,
+	}
+	n, err := f.Write(append(hello, 'x'))
+	common.Must(err)
+	if n != len(hello)+1 {
+		t.Error("unexpected written length ", n)
+	}
+	common.Must2(f.Write([]byte("next")))
+
+	if len(w.writes) != 6 {
+		t.Fatal("expect 6 writes, but got ", len(w.writes))
+	}
+	var data []byte
+	for i, record := range w.writes[:4] {
+		if !bytes.Equal(record[:3], hello[:3]) {
+			t.Error("unexpected record header ", record[:5])
+		}
+		length := int(binary.BigEndian.Uint16(record[3:5]))
+		if length != len(record)-5 || (i < 3 && length != 30) {
+			t.Error("unexpected record length ", length)
+		}
+		data = append(data, record[5:]...)
+	}
+	if !bytes.Equal(data, hello[5:]) {
+		t.Error("unexpected handshake data")
+	}
+	if string(w.writes[4]) != "x" || string(w.writes[5]) != "next" {
+		t.Error("expect the rest to be written as is")
+	}
+}
+
+func TestFragmentPackets(t *testing.T) {
+	w := new(recordWriter)
+	f := &FragmentWriter{
+		fragment: &Fragment{PacketsFrom: 2, PacketsTo: 2, LengthMin: 2, LengthMax: 2},
+		writer:   w,
+	}
+	for _, p := range []string{"first", "second", "third"} {
+		common.Must2(f.Write([]byte(p)))
+	}
+	var writes []string
+	for _, b := range w.writes {
+		writes = append(writes, string(b))
+	}
+	expected := []string{"first", "se", "co", "nd", "third"}
+	if len(writes) != len(expected) {
+		t.Fatal("unexpected writes ", writes)
+	}
+	for i := range expected {
+		if writes[i] != expected[i] {
+			t.Error("unexpected writes ", writes)
+		}
+	}
+}
+
+type multiBufferRecorder struct {
+	mbs []buf.MultiBuffer
+}
+
+func (w *multiBufferRecorder) WriteMultiBuffer(mb buf.MultiBuffer) error {
+	w.mbs = append(w.mbs, mb)
+	return nil
+}
+
+func TestNoisePacketWriter(t *testing.T) {
+	recorder := new(multiBufferRecorder)
+	w := &NoisePacketWriter{
+		Writer: recorder,
+		noises: []*Noise{
+			{Packet: []byte("noise")},
+			{LengthMin: 10, LengthMax: 20},
+			{},
+		},
+		firstWrite: true,
+	}
+	dest := net.UDPDestination(net.LocalHostIP, 53)
+	for i := 0; i < 2; i++ {
+		b := buf.New()
+		b.WriteString("payload")
+		b.UDP = &dest
+		common.Must(w.WriteMultiBuffer(buf.MultiBuffer{b}))
+	}
+
+	if len(recorder.mbs) != 4 {
+		t.Fatal("expect 4 writes, but got ", len(recorder.mbs))
+	}
+	if recorder.mbs[0].String() != "noise" {
+		t.Error("unexpected noise ", recorder.mbs[0].String())
+	}
+	if l := recorder.mbs[1].Len(); l < 10 || l > 20 {
+		t.Error("unexpected noise length ", l)
+	}
+	for _, mb := range recorder.mbs[:2] {
+		if *mb[0].UDP != dest {
+			t.Error("expect noise sent to ", dest, ", but got ", mb[0].UDP)
+		}
+	}
+	for _, mb := range recorder.mbs[2:] {
+		if mb.String() != "payload" {
+			t.Error("unexpected payload ", mb.String())
+		}
+	}
+}
@@ -229,6 +229,21 @@ func (s *Server) Process(ctx context.Context, network net.Network, conn stat.Con
 		panic("no inbound metadata")
 	}
 	inbound.User = user
+
+	userSession, err := policy.OpenUserSession(ctx, s.policyManager, user)
+	if err != nil {
+		log.Record(&log.AccessMessage{
+			From:   conn.RemoteAddr(),
+			To:     destination,
+			Status: log.AccessRejected,
+			Reason: err,
+			Email:  user.Email,
+		})
+		return newError("rejected by user limits").Base(err).AtInfo()
+	}
+	if userSession != nil {
+		defer userSession.Close()
+	}
 	sessionPolicy = s.policyManager.ForLevel(user.Level)
 
 	if destination.Network == net.Network_UDP { // handle udp request
@@ -457,6 +457,21 @@ func (h *Handler) Process(ctx context.Context, network net.Network, connection s
 	}
 	inbound.User = request.User
 
+	userSession, err := policy.OpenUserSession(ctx, h.policyManager, request.User)
+	if err != nil {
+		log.Record(&log.AccessMessage{
+			From:   connection.RemoteAddr(),
+			To:     request.Destination(),
+			Status: log.AccessRejected,
+			Reason: err,
+			Email:  request.User.Email,
+		})
+		return newError("rejected by user limits").Base(err).AtInfo()
+	}
+	if userSession != nil {
+		defer userSession.Close()
+	}
+
 	account := request.User.Account.(*vless.MemoryAccount)
 
 	responseAddons := &encoding.Addons{
@@ -5,11 +5,13 @@
 package mocks
 
 import (
+	context "context"
 	net "net"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
 	dns "github.com/xtls/xray-core/features/dns"
+	dnsmessage "golang.org/x/net/dns/dnsmessage"
 )
 
 // DNSClient is a mock of Client interface
@@ -64,6 +66,21 @@ func (mr *DNSClientMockRecorder) LookupIP(arg0, arg1 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LookupIP", reflect.TypeOf((*DNSClient)(nil).LookupIP), arg0, arg1)
 }
 
+// Query mocks base method
+func (m *DNSClient) Query(arg0 context.Context, arg1 string, arg2 dnsmessage.Type) ([]dnsmessage.Resource, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Query", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]dnsmessage.Resource)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Query indicates an expected call of Query
+func (mr *DNSClientMockRecorder) Query(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Query", reflect.TypeOf((*DNSClient)(nil).Query), arg0, arg1, arg2)
+}
+
 // Start mocks base method
 func (m *DNSClient) Start() error {
 	m.ctrl.T.Helper()
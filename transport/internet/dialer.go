@@ -108,7 +108,12 @@ func canLookupIP(ctx context.Context, dst net.Destination, sockopt *SocketConfig
 	if dst.Address.Family().IsIP() || dnsClient == nil {
 		return false
 	}
-	return sockopt.DomainStrategy != DomainStrategy_AS_IS
+	return sockopt.DomainStrategy != DomainStrategy_AS_IS || sockopt.HappyEyeballs != nil
+}
+
+// canRaceDial returns true if the connection attempts to the IPs should be raced with Happy Eyeballs.
+func canRaceDial(dst net.Destination, ips []net.IP, sockopt *SocketConfig) bool {
+	return dst.Network == net.Network_TCP && len(ips) > 1 && sockopt.HappyEyeballs != nil && len(sockopt.DialerProxy) == 0
 }
 
 func redirect(ctx context.Context, dst net.Destination, obt string) net.Conn {
@@ -141,7 +146,15 @@ func DialSystem(ctx context.Context, dest net.Destination, sockopt *SocketConfig
 	}
 
 	if canLookupIP(ctx, dest, sockopt) {
-		ips, err := lookupIP(dest.Address.String(), sockopt.DomainStrategy, src)
+		strategy := sockopt.DomainStrategy
+		if strategy == DomainStrategy_AS_IS {
+			// Happy Eyeballs needs the addresses of both families.
+			strategy = DomainStrategy_USE_IP
+		}
+		ips, err := lookupIP(dest.Address.String(), strategy, src)
+		if err == nil && canRaceDial(dest, ips, sockopt) {
+			return raceDialTCP(ctx, src, ips, dest.Port, sockopt)
+		}
 		if err == nil && len(ips) > 0 {
 			dest.Address = net.IPAddress(ips[dice.Roll(len(ips))])
 			newError("replace destination with " + dest.String()).AtInfo().WriteToLog()
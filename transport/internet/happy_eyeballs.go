@@ -0,0 +1,125 @@
+package internet
+
+import (
+	"context"
+	"time"
+
+	"github.com/xtls/xray-core/common/net"
+	"github.com/xtls/xray-core/common/session"
+)
+
+const (
+	defaultHappyEyeballsTryDelay      = 250 * time.Millisecond
+	defaultHappyEyeballsMaxConcurrent = 4
+)
+
+// raceDialTCP dials the IPs as described in RFC 8305. Connection attempts are started one after another with a delay,
+// and the first established connection wins, while the other attempts are canceled.
+func raceDialTCP(ctx context.Context, src net.Address, ips []net.IP, port net.Port, sockopt *SocketConfig) (net.Conn, error) {
+	if len(ips) == 0 {
+		return nil, newError("no IP to dial")
+	}
+	config := sockopt.HappyEyeballs
+	tryDelay := time.Duration(config.TryDelayMs) * time.Millisecond
+	if tryDelay == 0 {
+		tryDelay = defaultHappyEyeballsTryDelay
+	}
+	maxConcurrent := int(config.MaxConcurrentTry)
+	if maxConcurrent == 0 {
+		maxConcurrent = defaultHappyEyeballsMaxConcurrent
+	}
+	ips = sortIPs(ips, config.PrioritizeIpv6, config.Interleave)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type result struct {
+		dest net.Destination
+		conn net.Conn
+		err  error
+	}
+	results := make(chan result, len(ips))
+	next, active := 0, 0
+	var tryNext <-chan time.Time
+	dialNext := func() {
+		dest := net.TCPDestination(net.IPAddress(ips[next]), port)
+		go func() {
+			conn, err := effectiveSystemDialer.Dial(ctx, src, dest, sockopt)
+			results <- result{dest: dest, conn: conn, err: err}
+		}()
+		next++
+		active++
+		tryNext = time.After(tryDelay)
+	}
+	// closeLosers closes the connections of the attempts that are still running.
+	closeLosers := func() {
+		go func(n int) {
+			for i := 0; i < n; i++ {
+				if r := <-results; r.conn != nil {
+					r.conn.Close()
+				}
+			}
+		}(active)
+	}
+
+	dialNext()
+	var lastErr error
+	for {
+		var timeout <-chan time.Time
+		if next < len(ips) && active < maxConcurrent {
+			timeout = tryNext
+		}
+		select {
+		case r := <-results:
+			active--
+			if r.err == nil {
+				closeLosers()
+				return r.conn, nil
+			}
+			newError("failed to dial ", r.dest, " in happy eyeballs").Base(r.err).AtDebug().WriteToLog(session.ExportIDToError(ctx))
+			lastErr = r.err
+			if next < len(ips) {
+				dialNext()
+			} else if active == 0 {
+				return nil, lastErr
+			}
+		case <-timeout:
+			dialNext()
+		case <-ctx.Done():
+			closeLosers()
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// sortIPs interleaves the IPv4 and IPv6 addresses, starting from the preferred family.
+func sortIPs(ips []net.IP, prioritizeIPv6 bool, interleave uint32) []net.IP {
+	if interleave == 0 {
+		interleave = 1
+	}
+	var ip4, ip6 []net.IP
+	for _, ip := range ips {
+		if ip.To4() != nil {
+			ip4 = append(ip4, ip)
+		} else {
+			ip6 = append(ip6, ip)
+		}
+	}
+	preferred, other := ip4, ip6
+	if prioritizeIPv6 {
+		preferred, other = ip6, ip4
+	}
+
+	sorted := make([]net.IP, 0, len(ips))
+	for len(preferred) > 0 || len(other) > 0 {
+		for i := uint32(0); i < interleave && len(preferred) > 0; i++ {
+			sorted = append(sorted, preferred[0])
+			preferred = preferred[1:]
+		}
+		for i := uint32(0); i < interleave && len(other) > 0; i++ {
+			sorted = append(sorted, other[0])
+			other = other[1:]
+		}
+	}
+	return sorted
+}
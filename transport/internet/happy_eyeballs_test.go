@@ -0,0 +1,75 @@
+package internet_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/common/net"
+	"github.com/xtls/xray-core/features/dns"
+	"github.com/xtls/xray-core/testing/servers/tcp"
+	. "github.com/xtls/xray-core/transport/internet"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+type staticDNSClient struct {
+	ips []net.IP
+}
+
+func (*staticDNSClient) Type() interface{} {
+	return dns.ClientType()
+}
+
+func (*staticDNSClient) Start() error {
+	return nil
+}
+
+func (*staticDNSClient) Close() error {
+	return nil
+}
+
+func (c *staticDNSClient) LookupIP(domain string, option dns.IPOption) ([]net.IP, error) {
+	var ips []net.IP
+	for _, ip := range c.ips {
+		if (ip.To4() != nil && option.IPv4Enable) || (ip.To4() == nil && option.IPv6Enable) {
+			ips = append(ips, ip)
+		}
+	}
+	return ips, nil
+}
+
+func (*staticDNSClient) Query(ctx context.Context, domain string, qType dnsmessage.Type) ([]dnsmessage.Resource, error) {
+	return nil, nil
+}
+
+func TestDialHappyEyeballs(t *testing.T) {
+	server := &tcp.Server{}
+	dest, err := server.Start()
+	common.Must(err)
+	defer server.Close()
+
+	// 192.0.2.1 is reserved for documentation, so that the first attempt never succeeds.
+	InitSystemDialer(&staticDNSClient{ips: []net.IP{
+		net.ParseIP("192.0.2.1"),
+		net.ParseIP("127.0.0.1"),
+	}}, nil)
+	defer InitSystemDialer(nil, nil)
+
+	sockopt := &SocketConfig{
+		HappyEyeballs: &HappyEyeballsConfig{
+			TryDelayMs: 100,
+		},
+	}
+	start := time.Now()
+	conn, err := DialSystem(context.Background(), net.TCPDestination(net.DomainAddress("example.com"), dest.Port), sockopt)
+	common.Must(err)
+	defer conn.Close()
+
+	if conn.RemoteAddr().String() != "127.0.0.1:"+dest.Port.String() {
+		t.Error("unexpected remote address ", conn.RemoteAddr())
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Error("expect the second attempt to win, but took ", elapsed)
+	}
+}
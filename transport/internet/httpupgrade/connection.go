@@ -0,0 +1,55 @@
+package httpupgrade
+
+import (
+	"bufio"
+	"io"
+	"net"
+)
+
+// connection is the raw connection after the HTTP upgrade handshake.
+type connection struct {
+	net.Conn
+	// reader holds the data received before the connection is returned, which is read first.
+	reader     io.Reader
+	remoteAddr net.Addr
+}
+
+func newConnection(conn net.Conn, remoteAddr net.Addr, reader io.Reader) *connection {
+	return &connection{
+		Conn:       conn,
+		reader:     reader,
+		remoteAddr: remoteAddr,
+	}
+}
+
+// Read implements net.Conn.Read()
+func (c *connection) Read(b []byte) (int, error) {
+	if c.reader != nil {
+		n, err := c.reader.Read(b)
+		if err == io.EOF {
+			c.reader = nil
+			if n == 0 {
+				return c.Conn.Read(b)
+			}
+			err = nil
+		}
+		return n, err
+	}
+	return c.Conn.Read(b)
+}
+
+// RemoteAddr implements net.Conn.RemoteAddr()
+func (c *connection) RemoteAddr() net.Addr {
+	return c.remoteAddr
+}
+
+// bufferedData returns the data already buffered in the reader, or nil if there is none.
+func bufferedData(reader *bufio.Reader) []byte {
+	n := reader.Buffered()
+	if n == 0 {
+		return nil
+	}
+	data := make([]byte, n)
+	reader.Read(data)
+	return data
+}
@@ -0,0 +1,178 @@
+package httpupgrade
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/common/net"
+	"github.com/xtls/xray-core/common/session"
+	"github.com/xtls/xray-core/transport/internet"
+	"github.com/xtls/xray-core/transport/internet/stat"
+	"github.com/xtls/xray-core/transport/internet/tls"
+)
+
+// handshakeTimeout is the time limit to finish the HTTP upgrade handshake.
+const handshakeTimeout = time.Second * 8
+
+// Dial dials a new HTTPUpgrade connection to the given destination.
+func Dial(ctx context.Context, dest net.Destination, streamSettings *internet.MemoryStreamConfig) (stat.Connection, error) {
+	newError("creating connection to ", dest).WriteToLog(session.ExportIDToError(ctx))
+	var conn net.Conn
+	if streamSettings.ProtocolSettings.(*Config).Ed > 0 {
+		ctx, cancel := context.WithCancel(ctx)
+		conn = &delayDialConn{
+			dialed:         make(chan bool, 1),
+			cancel:         cancel,
+			ctx:            ctx,
+			dest:           dest,
+			streamSettings: streamSettings,
+		}
+	} else {
+		var err error
+		if conn, err = dialHTTPUpgrade(ctx, dest, streamSettings, nil); err != nil {
+			return nil, newError("failed to dial HTTPUpgrade").Base(err)
+		}
+	}
+	return stat.Connection(conn), nil
+}
+
+func init() {
+	common.Must(internet.RegisterTransportDialer(protocolName, Dial))
+}
+
+func dialHTTPUpgrade(ctx context.Context, dest net.Destination, streamSettings *internet.MemoryStreamConfig, ed []byte) (net.Conn, error) {
+	config := streamSettings.ProtocolSettings.(*Config)
+
+	pconn, err := internet.DialSystem(ctx, dest, streamSettings.SocketSettings)
+	if err != nil {
+		return nil, newError("failed to dial to ", dest).Base(err)
+	}
+
+	conn := pconn
+	scheme := "http"
+	if tlsConfig := tls.ConfigFromStreamSettings(streamSettings); tlsConfig != nil {
+		scheme = "https"
+		tlsConf := tlsConfig.GetTLSConfig(tls.WithDestination(dest), tls.WithNextProto("http/1.1"))
+		if fingerprint := tls.GetFingerprint(tlsConfig.Fingerprint); fingerprint != nil {
+			cn := tls.UClient(pconn, tlsConf, fingerprint).(*tls.UConn)
+			if err := cn.WebsocketHandshake(); err != nil {
+				pconn.Close()
+				return nil, newError("failed to perform TLS handshake").Base(err)
+			}
+			if !tlsConf.InsecureSkipVerify {
+				if err := cn.VerifyHostname(tlsConf.ServerName); err != nil {
+					pconn.Close()
+					return nil, newError("failed to verify hostname").Base(err)
+				}
+			}
+			conn = cn
+		} else {
+			conn = tls.Client(pconn, tlsConf)
+		}
+	}
+
+	host := config.Host
+	if host == "" {
+		host = dest.NetAddr()
+		if (scheme == "http" && dest.Port == 80) || (scheme == "https" && dest.Port == 443) {
+			host = dest.Address.String()
+		}
+	}
+	req, err := http.NewRequest("GET", scheme+"://"+host+config.GetNormalizedPath(), nil)
+	if err != nil {
+		conn.Close()
+		return nil, newError("failed to create upgrade request").Base(err)
+	}
+	req.Header = config.GetRequestHeader()
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "websocket")
+	if ed != nil {
+		req.Header.Set("Sec-WebSocket-Protocol", base64.RawURLEncoding.EncodeToString(ed))
+	}
+
+	conn.SetDeadline(time.Now().Add(handshakeTimeout))
+	if err := req.Write(conn); err != nil {
+		conn.Close()
+		return nil, newError("failed to send upgrade request").Base(err)
+	}
+	reader := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(reader, req)
+	if err != nil {
+		conn.Close()
+		return nil, newError("failed to read upgrade response").Base(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusSwitchingProtocols || !strings.EqualFold(resp.Header.Get("Upgrade"), "websocket") {
+		conn.Close()
+		return nil, newError("unexpected upgrade response: ", resp.Status)
+	}
+	conn.SetDeadline(time.Time{})
+
+	var extraReader io.Reader
+	if data := bufferedData(reader); data != nil {
+		extraReader = bytes.NewReader(data)
+	}
+	return newConnection(conn, conn.RemoteAddr(), extraReader), nil
+}
+
+type delayDialConn struct {
+	net.Conn
+	closed         bool
+	dialed         chan bool
+	cancel         context.CancelFunc
+	ctx            context.Context
+	dest           net.Destination
+	streamSettings *internet.MemoryStreamConfig
+}
+
+func (d *delayDialConn) Write(b []byte) (int, error) {
+	if d.closed {
+		return 0, io.ErrClosedPipe
+	}
+	if d.Conn == nil {
+		ed := b
+		if len(ed) > int(d.streamSettings.ProtocolSettings.(*Config).Ed) {
+			ed = nil
+		}
+		var err error
+		if d.Conn, err = dialHTTPUpgrade(d.ctx, d.dest, d.streamSettings, ed); err != nil {
+			d.Close()
+			return 0, newError("failed to dial HTTPUpgrade").Base(err)
+		}
+		d.dialed <- true
+		if ed != nil {
+			return len(ed), nil
+		}
+	}
+	return d.Conn.Write(b)
+}
+
+func (d *delayDialConn) Read(b []byte) (int, error) {
+	if d.closed {
+		return 0, io.ErrClosedPipe
+	}
+	if d.Conn == nil {
+		select {
+		case <-d.ctx.Done():
+			return 0, io.ErrUnexpectedEOF
+		case <-d.dialed:
+		}
+	}
+	return d.Conn.Read(b)
+}
+
+func (d *delayDialConn) Close() error {
+	d.closed = true
+	d.cancel()
+	if d.Conn == nil {
+		return nil
+	}
+	return d.Conn.Close()
+}
@@ -0,0 +1,9 @@
+/*
+Package httpupgrade implements HTTPUpgrade transport
+
+HTTPUpgrade transport performs an HTTP/1.1 Upgrade handshake compatible with WebSocket, and then transfers raw bytes
+on the connection without the WebSocket framing.
+*/
+package httpupgrade
+
+//go:generate go run github.com/xtls/xray-core/common/errors/errorgen
@@ -0,0 +1,135 @@
+package httpupgrade_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"io"
+	"testing"
+
+	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/common/net"
+	"github.com/xtls/xray-core/common/protocol/tls/cert"
+	"github.com/xtls/xray-core/testing/servers/tcp"
+	"github.com/xtls/xray-core/transport/internet"
+	. "github.com/xtls/xray-core/transport/internet/httpupgrade"
+	"github.com/xtls/xray-core/transport/internet/stat"
+	"github.com/xtls/xray-core/transport/internet/tls"
+)
+
+func echo(conn stat.Connection) {
+	go func() {
+		defer conn.Close()
+		io.Copy(conn, conn)
+	}()
+}
+
+func testEcho(t *testing.T, dest net.Destination, streamSettings *internet.MemoryStreamConfig, size int) {
+	conn, err := Dial(context.Background(), dest, streamSettings)
+	common.Must(err)
+	defer conn.Close()
+
+	payload := make([]byte, size)
+	common.Must2(rand.Read(payload))
+	common.Must2(conn.Write(payload))
+
+	response := make([]byte, size)
+	common.Must2(io.ReadFull(conn, response))
+	if !bytes.Equal(payload, response) {
+		t.Error("unexpected response")
+	}
+}
+
+func Test_listenHTTPUpgradeAndDial(t *testing.T) {
+	port := tcp.PickPort()
+	streamSettings := &internet.MemoryStreamConfig{
+		ProtocolName: "httpupgrade",
+		ProtocolSettings: &Config{
+			Path: "up",
+		},
+	}
+	listen, err := ListenHTTPUpgrade(context.Background(), net.LocalHostIP, port, streamSettings, echo)
+	common.Must(err)
+	defer listen.Close()
+
+	dest := net.TCPDestination(net.DomainAddress("localhost"), port)
+	testEcho(t, dest, streamSettings, 100)
+	testEcho(t, dest, streamSettings, 1024*1024)
+}
+
+func Test_listenHTTPUpgradeAndDial_EarlyData(t *testing.T) {
+	port := tcp.PickPort()
+	listen, err := ListenHTTPUpgrade(context.Background(), net.LocalHostIP, port, &internet.MemoryStreamConfig{
+		ProtocolName:     "httpupgrade",
+		ProtocolSettings: &Config{Path: "up"},
+	}, func(conn stat.Connection) {
+		go func() {
+			defer conn.Close()
+			var b [1024]byte
+			n, err := conn.Read(b[:])
+			if err != nil {
+				return
+			}
+			common.Must2(conn.Write(append(b[:n:n], conn.RemoteAddr().String()...)))
+		}()
+	})
+	common.Must(err)
+	defer listen.Close()
+
+	conn, err := Dial(context.Background(), net.TCPDestination(net.DomainAddress("localhost"), port), &internet.MemoryStreamConfig{
+		ProtocolName: "httpupgrade",
+		ProtocolSettings: &Config{
+			Path:   "up?a=b",
+			Header: map[string]string{"X-Forwarded-For": "1.1.1.1"},
+			Ed:     2048,
+		},
+	})
+	common.Must(err)
+	defer conn.Close()
+
+	common.Must2(conn.Write([]byte("early data")))
+	var b [1024]byte
+	n, err := conn.Read(b[:])
+	common.Must(err)
+	if string(b[:n]) != "early data1.1.1.1:0" {
+		t.Error("response: ", string(b[:n]))
+	}
+}
+
+func Test_listenHTTPUpgradeAndDial_TLS(t *testing.T) {
+	port := tcp.PickPort()
+	streamSettings := &internet.MemoryStreamConfig{
+		ProtocolName: "httpupgrade",
+		ProtocolSettings: &Config{
+			Path: "up",
+		},
+		SecurityType: "tls",
+		SecuritySettings: &tls.Config{
+			AllowInsecure: true,
+			Certificate:   []*tls.Certificate{tls.ParseCertificate(cert.MustGenerate(nil, cert.CommonName("localhost")))},
+		},
+	}
+	listen, err := ListenHTTPUpgrade(context.Background(), net.LocalHostIP, port, streamSettings, echo)
+	common.Must(err)
+	defer listen.Close()
+
+	testEcho(t, net.TCPDestination(net.DomainAddress("localhost"), port), streamSettings, 1024)
+}
+
+func Test_listenHTTPUpgradeAndDial_WrongHost(t *testing.T) {
+	port := tcp.PickPort()
+	listen, err := ListenHTTPUpgrade(context.Background(), net.LocalHostIP, port, &internet.MemoryStreamConfig{
+		ProtocolName:     "httpupgrade",
+		ProtocolSettings: &Config{Host: "example.com", Path: "up"},
+	}, echo)
+	common.Must(err)
+	defer listen.Close()
+
+	_, err = Dial(context.Background(), net.TCPDestination(net.DomainAddress("localhost"), port), &internet.MemoryStreamConfig{
+		ProtocolName:     "httpupgrade",
+		ProtocolSettings: &Config{Host: "example.org", Path: "up"},
+	})
+	if err == nil {
+		t.Error("expected error for mismatched host")
+	}
+}
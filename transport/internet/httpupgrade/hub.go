@@ -0,0 +1,211 @@
+package httpupgrade
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	gotls "crypto/tls"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/common/net"
+	http_proto "github.com/xtls/xray-core/common/protocol/http"
+	"github.com/xtls/xray-core/common/session"
+	"github.com/xtls/xray-core/transport/internet"
+	"github.com/xtls/xray-core/transport/internet/stat"
+	"github.com/xtls/xray-core/transport/internet/tls"
+)
+
+var replacer = strings.NewReplacer("+", "-", "/", "_", "=", "")
+
+type Listener struct {
+	listener  net.Listener
+	config    *Config
+	path      string
+	tlsConfig *gotls.Config
+	addConn   internet.ConnHandler
+	locker    *internet.FileLocker // for unix domain socket
+}
+
+// Addr implements net.Listener.Addr().
+func (l *Listener) Addr() net.Addr {
+	return l.listener.Addr()
+}
+
+// Close implements net.Listener.Close().
+func (l *Listener) Close() error {
+	if l.locker != nil {
+		l.locker.Release()
+	}
+	return l.listener.Close()
+}
+
+func (l *Listener) keepAccepting() {
+	for {
+		conn, err := l.listener.Accept()
+		if err != nil {
+			errStr := err.Error()
+			if strings.Contains(errStr, "closed") {
+				break
+			}
+			newError("failed to accepted raw connections").Base(err).AtWarning().WriteToLog()
+			if strings.Contains(errStr, "too many") {
+				time.Sleep(time.Millisecond * 500)
+			}
+			continue
+		}
+		go func() {
+			if l.tlsConfig != nil {
+				conn = tls.Server(conn, l.tlsConfig)
+			}
+			upgraded, err := l.upgrade(conn)
+			if err != nil {
+				newError("failed to upgrade connection from ", conn.RemoteAddr()).Base(err).AtInfo().WriteToLog()
+				conn.Close()
+				return
+			}
+			l.addConn(stat.Connection(upgraded))
+		}()
+	}
+}
+
+// upgrade performs the server side of the HTTP upgrade handshake.
+func (l *Listener) upgrade(conn net.Conn) (net.Conn, error) {
+	conn.SetDeadline(time.Now().Add(handshakeTimeout))
+	reader := bufio.NewReader(conn)
+	request, err := http.ReadRequest(reader)
+	if err != nil {
+		return nil, newError("failed to read upgrade request").Base(err)
+	}
+
+	if len(l.config.Host) > 0 && !strings.EqualFold(hostWithoutPort(request.Host), hostWithoutPort(l.config.Host)) {
+		writeResponse(conn, http.StatusNotFound, nil)
+		return nil, newError("unexpected host ", request.Host)
+	}
+	if request.URL.Path != l.path {
+		writeResponse(conn, http.StatusNotFound, nil)
+		return nil, newError("unexpected path ", request.URL.Path)
+	}
+	if request.Method != "GET" || !strings.EqualFold(request.Header.Get("Upgrade"), "websocket") ||
+		!strings.Contains(strings.ToLower(request.Header.Get("Connection")), "upgrade") {
+		writeResponse(conn, http.StatusBadRequest, nil)
+		return nil, newError("not an upgrade request")
+	}
+
+	var readers []io.Reader
+	responseHeader := http.Header{}
+	responseHeader.Set("Connection", "Upgrade")
+	responseHeader.Set("Upgrade", "websocket")
+	if str := request.Header.Get("Sec-WebSocket-Protocol"); str != "" {
+		if ed, err := base64.RawURLEncoding.DecodeString(replacer.Replace(str)); err == nil && len(ed) > 0 {
+			readers = append(readers, bytes.NewReader(ed))
+			responseHeader.Set("Sec-WebSocket-Protocol", str)
+		}
+	}
+	if err := writeResponse(conn, http.StatusSwitchingProtocols, responseHeader); err != nil {
+		return nil, newError("failed to send upgrade response").Base(err)
+	}
+	conn.SetDeadline(time.Time{})
+
+	if data := bufferedData(reader); data != nil {
+		readers = append(readers, bytes.NewReader(data))
+	}
+	var extraReader io.Reader
+	if len(readers) > 0 {
+		extraReader = io.MultiReader(readers...)
+	}
+
+	remoteAddr := conn.RemoteAddr()
+	forwardedAddrs := http_proto.ParseXForwardedFor(request.Header)
+	if len(forwardedAddrs) > 0 && forwardedAddrs[0].Family().IsIP() {
+		remoteAddr = &net.TCPAddr{
+			IP:   forwardedAddrs[0].IP(),
+			Port: int(0),
+		}
+	}
+
+	return newConnection(conn, remoteAddr, extraReader), nil
+}
+
+func writeResponse(conn net.Conn, statusCode int, header http.Header) error {
+	response := &http.Response{
+		StatusCode: statusCode,
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     header,
+	}
+	if statusCode != http.StatusSwitchingProtocols {
+		response.Close = true
+	}
+	return response.Write(conn)
+}
+
+func hostWithoutPort(host string) string {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		return h
+	}
+	return host
+}
+
+func ListenHTTPUpgrade(ctx context.Context, address net.Address, port net.Port, streamSettings *internet.MemoryStreamConfig, addConn internet.ConnHandler) (internet.Listener, error) {
+	l := &Listener{
+		config:  streamSettings.ProtocolSettings.(*Config),
+		addConn: addConn,
+	}
+	// the query of the configured path is sent by the client, but not matched
+	l.path = l.config.GetNormalizedPath()
+	if i := strings.IndexByte(l.path, '?'); i >= 0 {
+		l.path = l.path[:i]
+	}
+	if l.config.AcceptProxyProtocol {
+		if streamSettings.SocketSettings == nil {
+			streamSettings.SocketSettings = &internet.SocketConfig{}
+		}
+		streamSettings.SocketSettings.AcceptProxyProtocol = true
+	}
+	var listener net.Listener
+	var err error
+	if port == net.Port(0) { // unix
+		listener, err = internet.ListenSystem(ctx, &net.UnixAddr{
+			Name: address.Domain(),
+			Net:  "unix",
+		}, streamSettings.SocketSettings)
+		if err != nil {
+			return nil, newError("failed to listen unix domain socket(for HTTPUpgrade) on ", address).Base(err)
+		}
+		newError("listening unix domain socket(for HTTPUpgrade) on ", address).WriteToLog(session.ExportIDToError(ctx))
+		if locker := ctx.Value(address.Domain()); locker != nil {
+			l.locker = locker.(*internet.FileLocker)
+		}
+	} else { // tcp
+		listener, err = internet.ListenSystem(ctx, &net.TCPAddr{
+			IP:   address.IP(),
+			Port: int(port),
+		}, streamSettings.SocketSettings)
+		if err != nil {
+			return nil, newError("failed to listen TCP(for HTTPUpgrade) on ", address, ":", port).Base(err)
+		}
+		newError("listening TCP(for HTTPUpgrade) on ", address, ":", port).WriteToLog(session.ExportIDToError(ctx))
+	}
+
+	if streamSettings.SocketSettings != nil && streamSettings.SocketSettings.AcceptProxyProtocol {
+		newError("accepting PROXY protocol").AtWarning().WriteToLog(session.ExportIDToError(ctx))
+	}
+
+	if config := tls.ConfigFromStreamSettings(streamSettings); config != nil {
+		l.tlsConfig = config.GetTLSConfig(tls.WithNextProto("http/1.1"))
+	}
+
+	l.listener = listener
+	go l.keepAccepting()
+
+	return l, nil
+}
+
+func init() {
+	common.Must(internet.RegisterTransportListener(protocolName, ListenHTTPUpgrade))
+}
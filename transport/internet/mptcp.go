@@ -0,0 +1,18 @@
+//go:build go1.21
+// +build go1.21
+
+package internet
+
+import (
+	"github.com/xtls/xray-core/common/net"
+)
+
+// setDialerMPTCP enables Multipath TCP on the dialer. It falls back to TCP if MPTCP is not supported.
+func setDialerMPTCP(dialer *net.Dialer) {
+	dialer.SetMultipathTCP(true)
+}
+
+// setListenerMPTCP enables Multipath TCP on the listener. It falls back to TCP if MPTCP is not supported.
+func setListenerMPTCP(lc *net.ListenConfig) {
+	lc.SetMultipathTCP(true)
+}
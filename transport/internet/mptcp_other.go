@@ -0,0 +1,16 @@
+//go:build !go1.21
+// +build !go1.21
+
+package internet
+
+import (
+	"github.com/xtls/xray-core/common/net"
+)
+
+func setDialerMPTCP(dialer *net.Dialer) {
+	newError("MPTCP requires Go 1.21 or later").AtWarning().WriteToLog()
+}
+
+func setListenerMPTCP(lc *net.ListenConfig) {
+	newError("MPTCP requires Go 1.21 or later").AtWarning().WriteToLog()
+}
@@ -2,6 +2,7 @@ package internet
 
 import (
 	"net"
+	"strings"
 	"syscall"
 
 	"golang.org/x/sys/unix"
@@ -89,6 +90,10 @@ func applyOutboundSocketOptions(network string, address string, fd uintptr, conf
 				return newError("failed to set TCP_CONGESTION", err)
 			}
 		}
+
+		if err := applyTCPSegmentOptions(fd, config); err != nil {
+			return err
+		}
 	}
 
 	if config.Tproxy.IsEnabled() {
@@ -97,7 +102,7 @@ func applyOutboundSocketOptions(network string, address string, fd uintptr, conf
 		}
 	}
 
-	return nil
+	return applyCustomSockopts(fd, config)
 }
 
 func applyInboundSocketOptions(network string, fd uintptr, config *SocketConfig) error {
@@ -139,6 +144,10 @@ func applyInboundSocketOptions(network string, fd uintptr, config *SocketConfig)
 				return newError("failed to set TCP_CONGESTION", err)
 			}
 		}
+
+		if err := applyTCPSegmentOptions(fd, config); err != nil {
+			return err
+		}
 	}
 
 	if config.Tproxy.IsEnabled() {
@@ -155,6 +164,44 @@ func applyInboundSocketOptions(network string, fd uintptr, config *SocketConfig)
 		}
 	}
 
+	if config.V6Only && strings.HasSuffix(network, "6") {
+		if err := syscall.SetsockoptInt(int(fd), syscall.IPPROTO_IPV6, syscall.IPV6_V6ONLY, 1); err != nil {
+			return newError("failed to set IPV6_V6ONLY").Base(err)
+		}
+	}
+
+	return applyCustomSockopts(fd, config)
+}
+
+func applyTCPSegmentOptions(fd uintptr, config *SocketConfig) error {
+	if config.TcpUserTimeout > 0 {
+		if err := syscall.SetsockoptInt(int(fd), syscall.IPPROTO_TCP, unix.TCP_USER_TIMEOUT, int(config.TcpUserTimeout)); err != nil {
+			return newError("failed to set TCP_USER_TIMEOUT").Base(err)
+		}
+	}
+	if config.TcpMaxSeg > 0 {
+		if err := syscall.SetsockoptInt(int(fd), syscall.IPPROTO_TCP, syscall.TCP_MAXSEG, int(config.TcpMaxSeg)); err != nil {
+			return newError("failed to set TCP_MAXSEG").Base(err)
+		}
+	}
+	return nil
+}
+
+func applyCustomSockopts(fd uintptr, config *SocketConfig) error {
+	for _, opt := range config.CustomSockopt {
+		var err error
+		switch value := opt.Value.(type) {
+		case *CustomSockopt_IntValue:
+			err = syscall.SetsockoptInt(int(fd), int(opt.Level), int(opt.Opt), int(value.IntValue))
+		case *CustomSockopt_StringValue:
+			err = syscall.SetsockoptString(int(fd), int(opt.Level), int(opt.Opt), value.StringValue)
+		default:
+			err = newError("no value")
+		}
+		if err != nil {
+			return newError("failed to set custom sockopt ", opt.Level, ":", opt.Opt).Base(err)
+		}
+	}
 	return nil
 }
 
@@ -9,6 +9,7 @@ import (
 	"github.com/xtls/xray-core/common/net"
 	"github.com/xtls/xray-core/testing/servers/tcp"
 	. "github.com/xtls/xray-core/transport/internet"
+	"golang.org/x/sys/unix"
 )
 
 func TestSockOptMark(t *testing.T) {
@@ -40,3 +41,40 @@ func TestSockOptMark(t *testing.T) {
 	})
 	common.Must(err)
 }
+
+func TestSockOptCustom(t *testing.T) {
+	tcpServer := tcp.Server{
+		MsgProcessor: func(b []byte) []byte {
+			return b
+		},
+	}
+	dest, err := tcpServer.Start()
+	common.Must(err)
+	defer tcpServer.Close()
+
+	dialer := DefaultSystemDialer{}
+	conn, err := dialer.Dial(context.Background(), nil, dest, &SocketConfig{
+		TcpUserTimeout: 5000,
+		CustomSockopt: []*CustomSockopt{
+			{Level: syscall.SOL_SOCKET, Opt: syscall.SO_KEEPALIVE, Value: &CustomSockopt_IntValue{IntValue: 1}},
+		},
+	})
+	common.Must(err)
+	defer conn.Close()
+
+	rawConn, err := conn.(*net.TCPConn).SyscallConn()
+	common.Must(err)
+	err = rawConn.Control(func(fd uintptr) {
+		timeout, err := syscall.GetsockoptInt(int(fd), syscall.IPPROTO_TCP, unix.TCP_USER_TIMEOUT)
+		common.Must(err)
+		if timeout != 5000 {
+			t.Error("unexpected TCP_USER_TIMEOUT ", timeout)
+		}
+		keepAlive, err := syscall.GetsockoptInt(int(fd), syscall.SOL_SOCKET, syscall.SO_KEEPALIVE)
+		common.Must(err)
+		if keepAlive != 1 {
+			t.Error("unexpected SO_KEEPALIVE ", keepAlive)
+		}
+	})
+	common.Must(err)
+}
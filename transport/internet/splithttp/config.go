@@ -0,0 +1,56 @@
+package splithttp
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/transport/internet"
+)
+
+const protocolName = "splithttp"
+
+const (
+	defaultMaxConcurrentUploads = 10
+	defaultMaxUploadSize        = 1000000
+)
+
+// GetNormalizedPath returns the path prefix of the service, which always starts and ends with '/'.
+func (c *Config) GetNormalizedPath() string {
+	path := c.Path
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	if !strings.HasSuffix(path, "/") {
+		path += "/"
+	}
+	return path
+}
+
+func (c *Config) GetRequestHeader() http.Header {
+	header := http.Header{}
+	for k, v := range c.Header {
+		header.Add(k, v)
+	}
+	return header
+}
+
+func (c *Config) GetNormalizedMaxConcurrentUploads() int {
+	if c.MaxConcurrentUploads == 0 {
+		return defaultMaxConcurrentUploads
+	}
+	return int(c.MaxConcurrentUploads)
+}
+
+func (c *Config) GetNormalizedMaxUploadSize() int {
+	if c.MaxUploadSize == 0 {
+		return defaultMaxUploadSize
+	}
+	return int(c.MaxUploadSize)
+}
+
+func init() {
+	common.Must(internet.RegisterProtocolConfigCreator(protocolName, func() interface{} {
+		return new(Config)
+	}))
+}
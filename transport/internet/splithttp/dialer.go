@@ -0,0 +1,280 @@
+package splithttp
+
+import (
+	"bytes"
+	"context"
+	gotls "crypto/tls"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/common/buf"
+	"github.com/xtls/xray-core/common/net"
+	"github.com/xtls/xray-core/common/net/cnc"
+	"github.com/xtls/xray-core/common/session"
+	"github.com/xtls/xray-core/common/uuid"
+	"github.com/xtls/xray-core/transport/internet"
+	"github.com/xtls/xray-core/transport/internet/stat"
+	"github.com/xtls/xray-core/transport/internet/tls"
+	"github.com/xtls/xray-core/transport/pipe"
+	"golang.org/x/net/http2"
+)
+
+type dialerConf struct {
+	net.Destination
+	*internet.MemoryStreamConfig
+}
+
+var (
+	globalDialerMap    map[dialerConf]*http.Client
+	globalDialerAccess sync.Mutex
+)
+
+// isH3 returns true if HTTP/3 is the only protocol in ALPN.
+func isH3(tlsConfig *tls.Config) bool {
+	return tlsConfig != nil && len(tlsConfig.NextProtocol) == 1 && tlsConfig.NextProtocol[0] == "h3"
+}
+
+// isH1 returns true if TLS is disabled, or HTTP/1.1 is the only protocol in ALPN.
+func isH1(tlsConfig *tls.Config) bool {
+	return tlsConfig == nil || (len(tlsConfig.NextProtocol) == 1 && tlsConfig.NextProtocol[0] == "http/1.1")
+}
+
+func getHTTPClient(ctx context.Context, dest net.Destination, streamSettings *internet.MemoryStreamConfig) *http.Client {
+	globalDialerAccess.Lock()
+	defer globalDialerAccess.Unlock()
+
+	if globalDialerMap == nil {
+		globalDialerMap = make(map[dialerConf]*http.Client)
+	}
+
+	if client, found := globalDialerMap[dialerConf{dest, streamSettings}]; found {
+		return client
+	}
+
+	tlsConfig := tls.ConfigFromStreamSettings(streamSettings)
+
+	dialContext := func(ctxInner context.Context) (net.Conn, error) {
+		dctx := session.ContextWithID(ctxInner, session.IDFromContext(ctx))
+		dctx = session.ContextWithOutbound(dctx, session.OutboundFromContext(ctx))
+
+		conn, err := internet.DialSystem(dctx, dest, streamSettings.SocketSettings)
+		if err != nil {
+			return nil, newError("failed to dial to ", dest).Base(err)
+		}
+		return conn, nil
+	}
+
+	var transport http.RoundTripper
+	switch {
+	case isH3(tlsConfig):
+		transport = newH3Transport(ctx, dest, streamSettings, tlsConfig)
+	case isH1(tlsConfig):
+		h1Transport := &http.Transport{
+			DialContext: func(ctxInner context.Context, network string, addr string) (net.Conn, error) {
+				return dialContext(ctxInner)
+			},
+			DisableCompression:  true,
+			MaxIdleConnsPerHost: streamSettings.ProtocolSettings.(*Config).GetNormalizedMaxConcurrentUploads() + 1,
+			IdleConnTimeout:     time.Second * 90,
+		}
+		if tlsConfig != nil {
+			h1Transport.DialTLSContext = func(ctxInner context.Context, network string, addr string) (net.Conn, error) {
+				conn, err := dialContext(ctxInner)
+				if err != nil {
+					return nil, err
+				}
+				return handshake(conn, tlsConfig.GetTLSConfig(tls.WithDestination(dest)), tlsConfig.Fingerprint, "http/1.1")
+			}
+		}
+		transport = h1Transport
+	default:
+		transport = &http2.Transport{
+			TLSClientConfig: tlsConfig.GetTLSConfig(tls.WithDestination(dest)),
+			DialTLS: func(network string, addr string, tlsConf *gotls.Config) (net.Conn, error) {
+				conn, err := dialContext(context.Background())
+				if err != nil {
+					return nil, err
+				}
+				return handshake(conn, tlsConf, tlsConfig.Fingerprint, http2.NextProtoTLS)
+			},
+		}
+	}
+
+	client := &http.Client{
+		Transport: transport,
+	}
+	globalDialerMap[dialerConf{dest, streamSettings}] = client
+	return client
+}
+
+// handshake performs the TLS handshake, and checks the negotiated protocol.
+func handshake(conn net.Conn, config *gotls.Config, fingerprintName string, nextProto string) (net.Conn, error) {
+	var cn tls.Interface
+	if fingerprint := tls.GetFingerprint(fingerprintName); fingerprint != nil {
+		cn = tls.UClient(conn, config, fingerprint).(*tls.UConn)
+	} else {
+		cn = tls.Client(conn, config).(*tls.Conn)
+	}
+	if err := cn.Handshake(); err != nil {
+		conn.Close()
+		return nil, newError("failed to perform TLS handshake").Base(err)
+	}
+	if !config.InsecureSkipVerify {
+		if err := cn.VerifyHostname(config.ServerName); err != nil {
+			conn.Close()
+			return nil, newError("failed to verify hostname").Base(err)
+		}
+	}
+	negotiatedProtocol, _ := cn.NegotiatedProtocol()
+	if negotiatedProtocol != nextProto && (negotiatedProtocol != "" || nextProto == http2.NextProtoTLS) {
+		conn.Close()
+		return nil, newError("unexpected ALPN protocol ", negotiatedProtocol, "; want ", nextProto)
+	}
+	return cn, nil
+}
+
+// Dial dials a new connection to the given destination.
+func Dial(ctx context.Context, dest net.Destination, streamSettings *internet.MemoryStreamConfig) (stat.Connection, error) {
+	newError("dialing splithttp to ", dest).WriteToLog(session.ExportIDToError(ctx))
+
+	config := streamSettings.ProtocolSettings.(*Config)
+	client := getHTTPClient(ctx, dest, streamSettings)
+
+	scheme := "http"
+	if tls.ConfigFromStreamSettings(streamSettings) != nil {
+		scheme = "https"
+	}
+	sessionID := uuid.New()
+	sessionURL := url.URL{
+		Scheme: scheme,
+		Host:   dest.NetAddr(),
+		Path:   config.GetNormalizedPath() + sessionID.String(),
+	}
+
+	downloadCtx, cancelDownload := context.WithCancel(context.Background())
+	download := &lazyDownload{
+		ready:  make(chan struct{}),
+		cancel: cancelDownload,
+	}
+	go func() {
+		defer close(download.ready)
+
+		req, err := http.NewRequestWithContext(downloadCtx, "GET", sessionURL.String(), nil)
+		if err != nil {
+			download.err = err
+			return
+		}
+		req.Header = config.GetRequestHeader()
+		req.Host = config.Host
+		resp, err := client.Do(req)
+		if err != nil {
+			download.err = newError("failed to send download request").Base(err)
+			return
+		}
+		if resp.StatusCode != 200 {
+			resp.Body.Close()
+			download.err = newError("unexpected status ", resp.StatusCode)
+			return
+		}
+		download.body = resp.Body
+	}()
+
+	maxUploadSize := config.GetNormalizedMaxUploadSize()
+	uploadPipeReader, uploadPipeWriter := pipe.New(pipe.WithSizeLimit(int32(maxUploadSize)))
+
+	go func() {
+		var seq uint64
+		semaphore := make(chan struct{}, config.GetNormalizedMaxConcurrentUploads())
+		for {
+			mb, err := uploadPipeReader.ReadMultiBuffer()
+			if err != nil {
+				return
+			}
+			for !mb.IsEmpty() {
+				var chunk buf.MultiBuffer
+				mb, chunk = buf.SplitSize(mb, int32(maxUploadSize))
+				payload := make([]byte, chunk.Len())
+				chunk.Copy(payload)
+				buf.ReleaseMulti(chunk)
+
+				semaphore <- struct{}{}
+				go func(seq uint64, payload []byte) {
+					defer func() { <-semaphore }()
+
+					uploadURL := sessionURL
+					uploadURL.Path += "/" + strconv.FormatUint(seq, 10)
+					if err := upload(client, config, uploadURL.String(), payload); err != nil {
+						newError("failed to upload").Base(err).WriteToLog(session.ExportIDToError(ctx))
+						// the connection is broken without the lost data
+						uploadPipeReader.Interrupt()
+						download.Close()
+					}
+				}(seq, payload)
+				seq++
+			}
+		}
+	}()
+
+	return cnc.NewConnection(
+		cnc.ConnectionOutput(download),
+		cnc.ConnectionInputMulti(uploadPipeWriter),
+		cnc.ConnectionOnClose(common.ChainedClosable{uploadPipeWriter, download}),
+	), nil
+}
+
+func upload(client *http.Client, config *Config, url string, payload []byte) error {
+	req, err := http.NewRequest("POST", url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header = config.GetRequestHeader()
+	req.Host = config.Host
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != 200 {
+		return newError("unexpected status ", resp.StatusCode)
+	}
+	return nil
+}
+
+// lazyDownload is the body of the download response, which is available after the response header is received.
+type lazyDownload struct {
+	ready  chan struct{}
+	body   io.ReadCloser
+	err    error
+	cancel context.CancelFunc
+}
+
+// Read implements io.Reader.
+func (d *lazyDownload) Read(b []byte) (int, error) {
+	<-d.ready
+	if d.err != nil {
+		return 0, d.err
+	}
+	return d.body.Read(b)
+}
+
+// Close implements io.Closer.
+func (d *lazyDownload) Close() error {
+	d.cancel()
+	go func() {
+		<-d.ready
+		if d.body != nil {
+			d.body.Close()
+		}
+	}()
+	return nil
+}
+
+func init() {
+	common.Must(internet.RegisterTransportDialer(protocolName, Dial))
+}
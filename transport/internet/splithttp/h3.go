@@ -0,0 +1,82 @@
+package splithttp
+
+import (
+	"context"
+	gotls "crypto/tls"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/quic-go/quic-go"
+	"github.com/quic-go/quic-go/http3"
+	"github.com/xtls/xray-core/common/net"
+	"github.com/xtls/xray-core/common/session"
+	"github.com/xtls/xray-core/transport/internet"
+	"github.com/xtls/xray-core/transport/internet/tls"
+)
+
+func newH3Transport(ctx context.Context, dest net.Destination, streamSettings *internet.MemoryStreamConfig, tlsConfig *tls.Config) http.RoundTripper {
+	return &http3.RoundTripper{
+		DisableCompression: true,
+		TLSClientConfig:    tlsConfig.GetTLSConfig(tls.WithDestination(dest)),
+		QuicConfig: &quic.Config{
+			HandshakeIdleTimeout: time.Second * 8,
+			MaxIdleTimeout:       time.Second * 300,
+			KeepAlivePeriod:      time.Second * 10,
+		},
+		Dial: func(ctxInner context.Context, addr string, tlsCfg *gotls.Config, cfg *quic.Config) (quic.EarlyConnection, error) {
+			dctx := session.ContextWithID(ctxInner, session.IDFromContext(ctx))
+			dctx = session.ContextWithOutbound(dctx, session.OutboundFromContext(ctx))
+
+			conn, err := internet.DialSystem(dctx, net.UDPDestination(dest.Address, dest.Port), streamSettings.SocketSettings)
+			if err != nil {
+				return nil, newError("failed to dial to ", dest).Base(err)
+			}
+			var packetConn net.PacketConn
+			switch c := conn.(type) {
+			case *internet.PacketConnWrapper:
+				packetConn = c.Conn
+			case *net.UDPConn:
+				packetConn = c
+			default:
+				conn.Close()
+				return nil, newError("unsupported connection for HTTP/3 ", conn.RemoteAddr())
+			}
+			remoteAddr, err := net.ResolveUDPAddr("udp", conn.RemoteAddr().String())
+			if err != nil {
+				conn.Close()
+				return nil, err
+			}
+
+			quicConn, err := quic.DialEarlyContext(ctxInner, packetConn, remoteAddr, addr, tlsCfg, cfg)
+			if err != nil {
+				conn.Close()
+				return nil, err
+			}
+			// the packet conn is not closed by quic-go, as it is not created there
+			go func() {
+				<-quicConn.Context().Done()
+				conn.Close()
+			}()
+			return quicConn, nil
+		},
+	}
+}
+
+func listenH3(ctx context.Context, addr *net.UDPAddr, streamSettings *internet.MemoryStreamConfig, tlsConfig *tls.Config, handler http.Handler) (io.Closer, error) {
+	conn, err := internet.ListenSystemPacket(ctx, addr, streamSettings.SocketSettings)
+	if err != nil {
+		return nil, err
+	}
+	server := &http3.Server{
+		TLSConfig: tlsConfig.GetTLSConfig(tls.WithNextProto("h3")),
+		Handler:   handler,
+	}
+	go func() {
+		if err := server.Serve(conn); err != nil {
+			newError("stopping serving HTTP/3").Base(err).WriteToLog(session.ExportIDToError(ctx))
+		}
+		conn.Close()
+	}()
+	return server, nil
+}
@@ -0,0 +1,333 @@
+package splithttp
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/common/net"
+	"github.com/xtls/xray-core/common/net/cnc"
+	http_proto "github.com/xtls/xray-core/common/protocol/http"
+	"github.com/xtls/xray-core/common/serial"
+	"github.com/xtls/xray-core/common/session"
+	"github.com/xtls/xray-core/common/signal/done"
+	"github.com/xtls/xray-core/transport/internet"
+	"github.com/xtls/xray-core/transport/internet/stat"
+	"github.com/xtls/xray-core/transport/internet/tls"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+)
+
+// sessionTimeout is the time to wait for the download request after the first upload request of a session.
+const sessionTimeout = time.Second * 30
+
+type httpSession struct {
+	uploadQueue *uploadQueue
+	// connected is closed when the download request of the session is received.
+	connected *done.Instance
+}
+
+type Listener struct {
+	sync.Mutex
+	server   *http.Server
+	h3server io.Closer
+	listener net.Listener
+	local    net.Addr
+	config   *Config
+	handler  internet.ConnHandler
+	sessions sync.Map             // string -> *httpSession
+	locker   *internet.FileLocker // for unix domain socket
+}
+
+func (l *Listener) Addr() net.Addr {
+	return l.local
+}
+
+func (l *Listener) Close() error {
+	if l.locker != nil {
+		l.locker.Release()
+	}
+	if l.h3server != nil {
+		return l.h3server.Close()
+	}
+	return l.server.Close()
+}
+
+func (l *Listener) upsertSession(sessionID string) *httpSession {
+	if s, found := l.sessions.Load(sessionID); found {
+		return s.(*httpSession)
+	}
+
+	l.Lock()
+	defer l.Unlock()
+
+	if s, found := l.sessions.Load(sessionID); found {
+		return s.(*httpSession)
+	}
+	s := &httpSession{
+		uploadQueue: newUploadQueue(2 * l.config.GetNormalizedMaxConcurrentUploads()),
+		connected:   done.New(),
+	}
+	l.sessions.Store(sessionID, s)
+
+	go func() {
+		timer := time.NewTimer(sessionTimeout)
+		defer timer.Stop()
+		select {
+		case <-s.connected.Wait():
+		case <-timer.C:
+			l.sessions.Delete(sessionID)
+			s.uploadQueue.Close()
+		}
+	}()
+	return s
+}
+
+func (l *Listener) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	if len(l.config.Host) > 0 && !strings.EqualFold(hostWithoutPort(request.Host), hostWithoutPort(l.config.Host)) {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	path := l.config.GetNormalizedPath()
+	if !strings.HasPrefix(request.URL.Path, path) {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	// the path is /{sessionID} for download, and /{sessionID}/{seq} for upload
+	parts := strings.Split(request.URL.Path[len(path):], "/")
+	sessionID := parts[0]
+	if sessionID == "" || len(parts) > 2 {
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	switch {
+	case request.Method == "POST" && len(parts) == 2:
+		l.handleUpload(writer, request, sessionID, parts[1])
+	case request.Method == "GET" && len(parts) == 1:
+		l.handleDownload(writer, request, sessionID)
+	default:
+		writer.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (l *Listener) handleUpload(writer http.ResponseWriter, request *http.Request, sessionID string, seqStr string) {
+	seq, err := strconv.ParseUint(seqStr, 10, 64)
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	maxUploadSize := l.config.GetNormalizedMaxUploadSize()
+	payload, err := io.ReadAll(io.LimitReader(request.Body, int64(maxUploadSize)+1))
+	if err != nil {
+		newError("failed to read upload request").Base(err).WriteToLog()
+		writer.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	if len(payload) > maxUploadSize {
+		writer.WriteHeader(http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	s := l.upsertSession(sessionID)
+	if err := s.uploadQueue.Push(Packet{Payload: payload, Seq: seq}); err != nil {
+		newError("failed to upload").Base(err).WriteToLog()
+		writer.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	writer.WriteHeader(http.StatusOK)
+}
+
+func (l *Listener) handleDownload(writer http.ResponseWriter, request *http.Request, sessionID string) {
+	s := l.upsertSession(sessionID)
+	l.Lock()
+	if s.connected.Done() {
+		l.Unlock()
+		// a session is downloaded only once
+		writer.WriteHeader(http.StatusConflict)
+		return
+	}
+	s.connected.Close()
+	l.Unlock()
+	defer l.sessions.Delete(sessionID)
+
+	// prevent the response from being buffered by proxies
+	writer.Header().Set("X-Accel-Buffering", "no")
+	writer.Header().Set("Cache-Control", "no-store")
+	writer.Header().Set("Content-Type", "text/event-stream")
+	writer.WriteHeader(http.StatusOK)
+	if f, ok := writer.(http.Flusher); ok {
+		f.Flush()
+	}
+
+	remoteAddr := l.Addr()
+	dest, err := net.ParseDestination(request.RemoteAddr)
+	if err != nil {
+		newError("failed to parse request remote addr: ", request.RemoteAddr).Base(err).WriteToLog()
+	} else {
+		remoteAddr = &net.TCPAddr{
+			IP:   dest.Address.IP(),
+			Port: int(dest.Port),
+		}
+	}
+
+	forwardedAddress := http_proto.ParseXForwardedFor(request.Header)
+	if len(forwardedAddress) > 0 && forwardedAddress[0].Family().IsIP() {
+		remoteAddr = &net.TCPAddr{
+			IP:   forwardedAddress[0].IP(),
+			Port: 0,
+		}
+	}
+
+	done := done.New()
+	responseWriter := &flushWriter{w: writer}
+	conn := cnc.NewConnection(
+		cnc.ConnectionOutput(s.uploadQueue),
+		cnc.ConnectionInput(responseWriter),
+		cnc.ConnectionOnClose(common.ChainedClosable{done, s.uploadQueue}),
+		cnc.ConnectionLocalAddr(l.Addr()),
+		cnc.ConnectionRemoteAddr(remoteAddr),
+	)
+	l.handler(stat.Connection(conn))
+
+	select {
+	case <-done.Wait():
+	case <-request.Context().Done():
+		conn.Close()
+	}
+	// the response writer must not be used after the handler returns
+	responseWriter.Close()
+}
+
+// flushWriter writes to the response, and flushes it immediately.
+type flushWriter struct {
+	access sync.Mutex
+	w      http.ResponseWriter
+	closed bool
+}
+
+func (fw *flushWriter) Write(p []byte) (int, error) {
+	fw.access.Lock()
+	defer fw.access.Unlock()
+
+	if fw.closed {
+		return 0, io.ErrClosedPipe
+	}
+	n, err := fw.w.Write(p)
+	if f, ok := fw.w.(http.Flusher); ok && err == nil {
+		f.Flush()
+	}
+	return n, err
+}
+
+func (fw *flushWriter) Close() error {
+	fw.access.Lock()
+	defer fw.access.Unlock()
+
+	fw.closed = true
+	return nil
+}
+
+func hostWithoutPort(host string) string {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		return h
+	}
+	return host
+}
+
+func ListenSH(ctx context.Context, address net.Address, port net.Port, streamSettings *internet.MemoryStreamConfig, handler internet.ConnHandler) (internet.Listener, error) {
+	l := &Listener{
+		config:  streamSettings.ProtocolSettings.(*Config),
+		handler: handler,
+	}
+	tlsConfig := tls.ConfigFromStreamSettings(streamSettings)
+
+	if isH3(tlsConfig) {
+		if port == net.Port(0) {
+			return nil, newError("HTTP/3 is not supported on unix domain socket")
+		}
+		l.local = &net.UDPAddr{
+			IP:   address.IP(),
+			Port: int(port),
+		}
+		server, err := listenH3(ctx, l.local.(*net.UDPAddr), streamSettings, tlsConfig, l)
+		if err != nil {
+			return nil, newError("failed to listen HTTP/3 on ", address, ":", port).Base(err)
+		}
+		newError("listening HTTP/3 on ", address, ":", port).WriteToLog(session.ExportIDToError(ctx))
+		l.h3server = server
+		return l, nil
+	}
+
+	var listener net.Listener
+	var err error
+	if port == net.Port(0) { // unix
+		l.local = &net.UnixAddr{
+			Name: address.Domain(),
+			Net:  "unix",
+		}
+		listener, err = internet.ListenSystem(ctx, l.local, streamSettings.SocketSettings)
+		if err != nil {
+			return nil, newError("failed to listen unix domain socket on ", address).Base(err)
+		}
+		newError("listening unix domain socket on ", address).WriteToLog(session.ExportIDToError(ctx))
+		if locker := ctx.Value(address.Domain()); locker != nil {
+			l.locker = locker.(*internet.FileLocker)
+		}
+	} else { // tcp
+		l.local = &net.TCPAddr{
+			IP:   address.IP(),
+			Port: int(port),
+		}
+		listener, err = internet.ListenSystem(ctx, l.local, streamSettings.SocketSettings)
+		if err != nil {
+			return nil, newError("failed to listen TCP on ", address, ":", port).Base(err)
+		}
+		newError("listening TCP on ", address, ":", port).WriteToLog(session.ExportIDToError(ctx))
+	}
+
+	if streamSettings.SocketSettings != nil && streamSettings.SocketSettings.AcceptProxyProtocol {
+		newError("accepting PROXY protocol").AtWarning().WriteToLog(session.ExportIDToError(ctx))
+	}
+
+	l.listener = listener
+	if tlsConfig == nil {
+		l.server = &http.Server{
+			Addr:              serial.Concat(address, ":", port),
+			Handler:           h2c.NewHandler(l, &http2.Server{}),
+			ReadHeaderTimeout: time.Second * 4,
+		}
+	} else {
+		l.server = &http.Server{
+			Addr:              serial.Concat(address, ":", port),
+			TLSConfig:         tlsConfig.GetTLSConfig(tls.WithNextProto("h2", "http/1.1")),
+			Handler:           l,
+			ReadHeaderTimeout: time.Second * 4,
+		}
+	}
+
+	go func() {
+		var err error
+		if tlsConfig == nil {
+			err = l.server.Serve(l.listener)
+		} else {
+			err = l.server.ServeTLS(l.listener, "", "")
+		}
+		if err != nil {
+			newError("stopping serving splithttp").Base(err).WriteToLog(session.ExportIDToError(ctx))
+		}
+	}()
+
+	return l, nil
+}
+
+func init() {
+	common.Must(internet.RegisterTransportListener(protocolName, ListenSH))
+}
@@ -0,0 +1,9 @@
+/*
+Package splithttp implements SplitHTTP transport
+
+SplitHTTP transport carries the downlink of a connection as a streamed response of a GET request, and the uplink as a
+sequence of POST requests, so that it works through HTTP proxies and CDNs which do not support bidirectional streams.
+*/
+package splithttp
+
+//go:generate go run github.com/xtls/xray-core/common/errors/errorgen
@@ -0,0 +1,111 @@
+package splithttp_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"io"
+	"testing"
+
+	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/common/net"
+	"github.com/xtls/xray-core/common/protocol/tls/cert"
+	"github.com/xtls/xray-core/testing/servers/tcp"
+	"github.com/xtls/xray-core/transport/internet"
+	. "github.com/xtls/xray-core/transport/internet/splithttp"
+	"github.com/xtls/xray-core/transport/internet/stat"
+	"github.com/xtls/xray-core/transport/internet/tls"
+)
+
+func echo(conn stat.Connection) {
+	go func() {
+		defer conn.Close()
+		io.Copy(conn, conn)
+	}()
+}
+
+func testEcho(t *testing.T, dest net.Destination, streamSettings *internet.MemoryStreamConfig, size int) {
+	conn, err := Dial(context.Background(), dest, streamSettings)
+	common.Must(err)
+	defer conn.Close()
+
+	payload := make([]byte, size)
+	common.Must2(rand.Read(payload))
+	go func() {
+		common.Must2(conn.Write(payload))
+	}()
+
+	response := make([]byte, size)
+	common.Must2(io.ReadFull(conn, response))
+	if !bytes.Equal(payload, response) {
+		t.Error("unexpected response")
+	}
+}
+
+func Test_listenSHAndDial(t *testing.T) {
+	port := tcp.PickPort()
+	streamSettings := &internet.MemoryStreamConfig{
+		ProtocolName: "splithttp",
+		ProtocolSettings: &Config{
+			Path:          "sh",
+			MaxUploadSize: 4096,
+		},
+	}
+	listen, err := ListenSH(context.Background(), net.LocalHostIP, port, streamSettings, echo)
+	common.Must(err)
+	defer listen.Close()
+
+	dest := net.TCPDestination(net.DomainAddress("localhost"), port)
+	testEcho(t, dest, streamSettings, 100)
+	// the uploads are sent in multiple requests, which may arrive out of order
+	testEcho(t, dest, streamSettings, 1024*1024)
+}
+
+func Test_listenSHAndDial_TLS(t *testing.T) {
+	port := tcp.PickPort()
+	for _, nextProtocol := range [][]string{nil, {"http/1.1"}} {
+		streamSettings := &internet.MemoryStreamConfig{
+			ProtocolName: "splithttp",
+			ProtocolSettings: &Config{
+				Path: "sh",
+			},
+			SecurityType: "tls",
+			SecuritySettings: &tls.Config{
+				AllowInsecure: true,
+				NextProtocol:  nextProtocol,
+				Certificate:   []*tls.Certificate{tls.ParseCertificate(cert.MustGenerate(nil, cert.CommonName("localhost")))},
+			},
+		}
+		listen, err := ListenSH(context.Background(), net.LocalHostIP, port, streamSettings, echo)
+		common.Must(err)
+
+		testEcho(t, net.TCPDestination(net.DomainAddress("localhost"), port), streamSettings, 64*1024)
+		common.Must(listen.Close())
+	}
+}
+
+func Test_listenSHAndDial_Host(t *testing.T) {
+	port := tcp.PickPort()
+	listen, err := ListenSH(context.Background(), net.LocalHostIP, port, &internet.MemoryStreamConfig{
+		ProtocolName: "splithttp",
+		ProtocolSettings: &Config{
+			Host: "example.com",
+		},
+	}, echo)
+	common.Must(err)
+	defer listen.Close()
+
+	conn, err := Dial(context.Background(), net.TCPDestination(net.LocalHostIP, port), &internet.MemoryStreamConfig{
+		ProtocolName: "splithttp",
+		ProtocolSettings: &Config{
+			Host: "example.org",
+		},
+	})
+	common.Must(err)
+	defer conn.Close()
+
+	var b [1]byte
+	if _, err := conn.Read(b[:]); err == nil {
+		t.Error("expect error for mismatched host")
+	}
+}
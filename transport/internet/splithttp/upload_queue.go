@@ -0,0 +1,110 @@
+package splithttp
+
+import (
+	"container/heap"
+	"io"
+	"sync"
+)
+
+// Packet is the body of an upload request.
+type Packet struct {
+	Payload []byte
+	Seq     uint64
+}
+
+type packetHeap []Packet
+
+func (h packetHeap) Len() int           { return len(h) }
+func (h packetHeap) Less(i, j int) bool { return h[i].Seq < h[j].Seq }
+func (h packetHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+func (h *packetHeap) Push(x interface{}) {
+	*h = append(*h, x.(Packet))
+}
+
+func (h *packetHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	*h = old[:n-1]
+	return x
+}
+
+// uploadQueue reorders the packets of upload requests, which may arrive out of order.
+type uploadQueue struct {
+	access     sync.Mutex
+	cond       *sync.Cond
+	packets    packetHeap
+	nextSeq    uint64
+	maxPackets int
+	closed     bool
+}
+
+func newUploadQueue(maxPackets int) *uploadQueue {
+	q := &uploadQueue{
+		maxPackets: maxPackets,
+	}
+	q.cond = sync.NewCond(&q.access)
+	return q
+}
+
+// Push adds a packet to the queue. It blocks while the queue is full, unless the packet is the next one to read.
+func (q *uploadQueue) Push(p Packet) error {
+	q.access.Lock()
+	defer q.access.Unlock()
+
+	for !q.closed && len(q.packets) >= q.maxPackets && p.Seq != q.nextSeq {
+		q.cond.Wait()
+	}
+	if q.closed {
+		return io.ErrClosedPipe
+	}
+	if p.Seq < q.nextSeq {
+		// duplicated packet
+		return nil
+	}
+	heap.Push(&q.packets, p)
+	q.cond.Broadcast()
+	return nil
+}
+
+// Read implements io.Reader. It reads the payloads of the packets in the order of their sequence numbers.
+func (q *uploadQueue) Read(b []byte) (int, error) {
+	q.access.Lock()
+	defer q.access.Unlock()
+
+	for {
+		for len(q.packets) > 0 && q.packets[0].Seq < q.nextSeq {
+			heap.Pop(&q.packets)
+		}
+		if len(q.packets) > 0 && q.packets[0].Seq == q.nextSeq {
+			packet := &q.packets[0]
+			n := copy(b, packet.Payload)
+			if n < len(packet.Payload) {
+				packet.Payload = packet.Payload[n:]
+			} else {
+				heap.Pop(&q.packets)
+				q.nextSeq++
+				q.cond.Broadcast()
+			}
+			if n == 0 && len(b) > 0 {
+				continue
+			}
+			return n, nil
+		}
+		if q.closed {
+			return 0, io.EOF
+		}
+		q.cond.Wait()
+	}
+}
+
+// Close implements io.Closer.
+func (q *uploadQueue) Close() error {
+	q.access.Lock()
+	defer q.access.Unlock()
+
+	q.closed = true
+	q.cond.Broadcast()
+	return nil
+}
@@ -0,0 +1,28 @@
+package splithttp
+
+import (
+	"io"
+	"testing"
+
+	"github.com/xtls/xray-core/common"
+)
+
+func TestUploadQueue(t *testing.T) {
+	q := newUploadQueue(4)
+	common.Must(q.Push(Packet{Payload: []byte("world"), Seq: 1}))
+	common.Must(q.Push(Packet{Payload: []byte("hello "), Seq: 0}))
+	// duplicated
+	common.Must(q.Push(Packet{Payload: []byte("hello "), Seq: 0}))
+	common.Must(q.Push(Packet{Payload: []byte("!"), Seq: 2}))
+	common.Must(q.Close())
+
+	b, err := io.ReadAll(q)
+	common.Must(err)
+	if string(b) != "hello world!" {
+		t.Error("unexpected content: ", string(b))
+	}
+
+	if err := q.Push(Packet{Payload: []byte("?"), Seq: 3}); err == nil {
+		t.Error("expect error after close")
+	}
+}
@@ -54,6 +54,9 @@ func (dl *DefaultListener) Listen(ctx context.Context, addr net.Addr, sockopt *S
 		if sockopt != nil && (sockopt.TcpKeepAliveInterval != 0 || sockopt.TcpKeepAliveIdle != 0) {
 			lc.KeepAlive = time.Duration(-1)
 		}
+		if sockopt != nil && sockopt.TcpMptcp {
+			setListenerMPTCP(&lc)
+		}
 	case *net.UnixAddr:
 		lc.Control = nil
 		network = addr.Network()